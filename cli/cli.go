@@ -24,6 +24,10 @@ func Execute(ctx context.Context) {
 		cmdResource(),
 		cmdAction(),
 		cmdLogs(),
+		cmdSecret(),
+		cmdSchedule(),
+		cmdExpiry(),
+		cmdModule(),
 	)
 
 	cmdx.SetHelp(rootCmd)
@@ -24,11 +24,27 @@ type Config struct {
 	Service   serveConfig      `mapstructure:"service"`
 	PGConnStr string           `mapstructure:"pg_conn_str" default:"postgres://postgres@localhost:5432/entropy?sslmode=disable"`
 	Telemetry telemetry.Config `mapstructure:"telemetry"`
+	Expiry    expiryConf       `mapstructure:"expiry"`
+	Autoscale autoscaleConf    `mapstructure:"autoscale"`
+
+	// PluginDir is the directory with executables of out-of-process module
+	// drivers. Plugins are not loaded if this is empty.
+	PluginDir string `mapstructure:"plugin_dir" default:""`
+
+	// SecretKey is the base64-encoded 32-byte key used to encrypt project
+	// secrets in the database. Secrets are stored in plaintext if this is
+	// empty.
+	SecretKey string `mapstructure:"secret_key" default:""`
 }
 
 type serveConfig struct {
 	Host string `mapstructure:"host" default:""`
 	Port int    `mapstructure:"port" default:"8080"`
+
+	// RevealKey must be presented by callers to see secret values of
+	// resources. Secrets are always masked if this is empty. It is a single
+	// key shared by all callers, and is not tied to any authentication.
+	RevealKey string `mapstructure:"reveal_key" default:""`
 }
 
 type workerConf struct {
@@ -39,6 +55,22 @@ type workerConf struct {
 	PollInterval time.Duration `mapstructure:"poll_interval" default:"100ms"`
 }
 
+type expiryConf struct {
+	// WarnBefore is the duration before the expiry of a resource at which
+	// a warning is raised. Warnings are disabled if this is zero.
+	WarnBefore time.Duration `mapstructure:"warn_before" default:"24h"`
+
+	// WebhookURL, if set, receives a POST request with the resource as JSON
+	// for every expiry warning.
+	WebhookURL string `mapstructure:"webhook_url" default:""`
+}
+
+type autoscaleConf struct {
+	// Interval between successive checks of all the resources for scaling.
+	// Autoscaling is disabled if this is zero.
+	Interval time.Duration `mapstructure:"interval" default:"1m"`
+}
+
 func (serveCfg serveConfig) addr() string {
 	return fmt.Sprintf("%s:%d", serveCfg.Host, serveCfg.Port)
 }
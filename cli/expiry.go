@@ -0,0 +1,182 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/odpf/salt/term" // nolint
+	"github.com/spf13/cobra"
+	entropyv1beta1 "go.buf.build/odpf/gwv/odpf/proton/odpf/entropy/v1beta1"
+	"go.uber.org/zap"
+
+	"github.com/odpf/entropy/core"
+	"github.com/odpf/entropy/core/resource"
+	resourcesv1 "github.com/odpf/entropy/internal/server/v1/resources"
+)
+
+func cmdExpiry() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "expiry",
+		Short: "Manage expiry of resources",
+		Annotations: map[string]string{
+			"group:core": "true",
+		},
+		Example: heredoc.Doc(`
+			$ entropy expiry extend <resource-urn> --by=72h
+			$ entropy expiry extend <resource-urn> --at=2022-10-01T22:00:00Z
+			$ entropy expiry clear <resource-urn>
+		`),
+	}
+
+	cmd.AddCommand(
+		extendExpiryCommand(),
+		clearExpiryCommand(),
+	)
+
+	return cmd
+}
+
+func extendExpiryCommand() *cobra.Command {
+	var at string
+	var by time.Duration
+	cmd := &cobra.Command{
+		Use:   "extend <resource-urn>",
+		Short: "extend the expiry of a resource by a duration (--by) or to a time (--at)",
+		Args:  cobra.ExactArgs(1),
+		RunE: handleErr(func(cmd *cobra.Command, args []string) error {
+			if (at == "") == (by == 0) {
+				return fmt.Errorf("exactly one of --at or --by must be set")
+			}
+
+			var expiresAt time.Time
+			if at != "" {
+				var err error
+				expiresAt, err = time.Parse(time.RFC3339, at)
+				if err != nil {
+					return fmt.Errorf("invalid value for --at: %w", err)
+				}
+			} else {
+				current, err := currentExpiry(cmd, args[0])
+				if err != nil {
+					return err
+				}
+				expiresAt = current.Add(by)
+			}
+
+			var res struct {
+				URN       string    `json:"urn"`
+				ExpiresAt time.Time `json:"expires_at"`
+			}
+			reqBody := map[string]interface{}{"expires_at": expiresAt}
+			path := fmt.Sprintf("/api/v1beta1/resources/%s/expiry", url.PathEscape(args[0]))
+			if err := callHTTP(cmd, http.MethodPut, path, reqBody, &res); err != nil {
+				return err
+			}
+
+			fmt.Println(term.Greenf("resource '%s' now expires at %s", res.URN, res.ExpiresAt.Format(time.RFC3339)))
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(&at, "at", "", "new expiry time (RFC3339)")
+	cmd.Flags().DurationVar(&by, "by", 0, "duration to extend the current expiry by")
+
+	return cmd
+}
+
+func clearExpiryCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clear <resource-urn>",
+		Short: "remove the expiry of a resource so it is never deleted automatically",
+		Args:  cobra.ExactArgs(1),
+		RunE: handleErr(func(cmd *cobra.Command, args []string) error {
+			var res struct {
+				URN string `json:"urn"`
+			}
+			path := fmt.Sprintf("/api/v1beta1/resources/%s/expiry", url.PathEscape(args[0]))
+			if err := callHTTP(cmd, http.MethodDelete, path, nil, &res); err != nil {
+				return err
+			}
+
+			fmt.Println(term.Greenf("resource '%s' no longer expires", res.URN))
+			return nil
+		}),
+	}
+
+	return cmd
+}
+
+// currentExpiry returns the expiry of the resource, or the current time if
+// the resource has no expiry.
+func currentExpiry(cmd *cobra.Command, urn string) (time.Time, error) {
+	client, cancel, err := createClient(cmd)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer cancel()
+
+	res, err := client.GetResource(cmd.Context(), &entropyv1beta1.GetResourceRequest{Urn: urn})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	label, ok := res.GetResource().GetLabels()[resourcesv1.LabelExpiresAt]
+	if !ok {
+		return time.Now(), nil
+	}
+	return time.Parse(time.RFC3339, label)
+}
+
+// expiryNotifier returns a function that logs a warning for resources that
+// are about to expire and, if configured, notifies the webhook.
+func expiryNotifier(zapLog *zap.Logger, conf expiryConf) core.ExpiryNotifyFn {
+	const webhookTimeout = 10 * time.Second
+	client := &http.Client{Timeout: webhookTimeout}
+
+	return func(ctx context.Context, res resource.Resource) error {
+		zapLog.Warn("resource is about to expire",
+			zap.String("urn", res.URN),
+			zap.Timep("expires_at", res.ExpiresAt),
+		)
+
+		if conf.WebhookURL == "" {
+			return nil
+		}
+
+		body, err := json.Marshal(map[string]interface{}{
+			"event":      "resource_expiry_warning",
+			"urn":        res.URN,
+			"kind":       res.Kind,
+			"project":    res.Project,
+			"name":       res.Name,
+			"labels":     res.Labels,
+			"expires_at": res.ExpiresAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, conf.WebhookURL, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode >= http.StatusBadRequest {
+			return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+// httpTimeout bounds the requests to the HTTP API of the server.
+const httpTimeout = 10 * time.Second
+
+// callHTTP sends a request to the HTTP API of the server, with body (if not
+// nil) as JSON, and decodes the JSON response into out (if not nil). The
+// path may include a query string.
+func callHTTP(cmd *cobra.Command, method, path string, body, out interface{}) error {
+	c, err := loadConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	host := net.JoinHostPort(c.Service.Host, strconv.Itoa(c.Service.Port))
+	req, err := http.NewRequestWithContext(cmd.Context(), method, fmt.Sprintf("http://%s%s", host, path), reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := (&http.Client{Timeout: httpTimeout}).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		var apiErr errors.Error
+		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err != nil || apiErr.Code == "" {
+			return fmt.Errorf("request failed with status %s", resp.Status) // nolint
+		}
+		return apiErr
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
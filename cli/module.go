@@ -0,0 +1,172 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/ghodss/yaml"
+	"github.com/odpf/salt/printer"
+	"github.com/odpf/salt/term" // nolint
+	"github.com/spf13/cobra"
+
+	"github.com/odpf/entropy/core/module"
+)
+
+func cmdModule() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "module",
+		Aliases: []string{"modules"},
+		Short:   "Manage modules",
+		Annotations: map[string]string{
+			"group:other": "server",
+		},
+		Example: heredoc.Doc(`
+			$ entropy module describe
+			$ entropy module describe firehose -o yaml
+			$ entropy module revisions <module-urn>
+			$ entropy module rollback <module-urn> <revision>
+		`),
+	}
+
+	cmd.AddCommand(
+		describeModuleCommand(),
+		listModuleRevisionsCommand(),
+		rollbackModuleCommand(),
+	)
+
+	return cmd
+}
+
+func describeModuleCommand() *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:   "describe [kind]",
+		Short: "list supported module kinds or describe actions & schemas of a kind",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: handleErr(func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				var resp struct {
+					Kinds []module.Description `json:"kinds"`
+				}
+				if err := callHTTP(cmd, http.MethodGet, "/api/v1beta1/module-kinds", nil, &resp); err != nil {
+					return err
+				}
+				descs := resp.Kinds
+
+				report := [][]string{{"KIND", "ACTIONS", "DEPENDENCIES"}}
+				for _, desc := range descs {
+					var actions, deps []string
+					for _, act := range desc.Actions {
+						actions = append(actions, act.Name)
+					}
+					for key, kind := range desc.Dependencies {
+						deps = append(deps, fmt.Sprintf("%s=%s", key, kind))
+					}
+					sort.Strings(deps)
+					report = append(report, []string{desc.Kind, strings.Join(actions, ", "), strings.Join(deps, ", ")})
+				}
+				printer.Table(os.Stdout, report)
+				fmt.Println(term.Cyanf("\nTo view actions & schemas of a kind, use `entropy module describe <kind>`"))
+				return nil
+			}
+
+			var resp struct {
+				Kind module.Description `json:"kind"`
+			}
+			path := fmt.Sprintf("/api/v1beta1/module-kinds/%s", url.PathEscape(args[0]))
+			if err := callHTTP(cmd, http.MethodGet, path, nil, &resp); err != nil {
+				return err
+			}
+
+			b, err := json.MarshalIndent(resp.Kind, "", "\t")
+			if err != nil {
+				return err
+			}
+
+			switch output {
+			case "", outputJSON:
+				fmt.Println(string(b))
+
+			case outputYAML, outputYML:
+				y, err := yaml.JSONToYAML(b)
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(y))
+
+			default:
+				return fmt.Errorf("unsupported format '%s'", output)
+			}
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVarP(&output, "out", "o", "", "output format, `-o json | yaml`")
+
+	return cmd
+}
+
+func listModuleRevisionsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revisions <module-urn>",
+		Short: "list revisions of the configs of a module",
+		Args:  cobra.ExactArgs(1),
+		RunE: handleErr(func(cmd *cobra.Command, args []string) error {
+			var resp struct {
+				Revisions []module.Revision `json:"revisions"`
+			}
+			path := fmt.Sprintf("/api/v1beta1/modules/%s/revisions", url.PathEscape(args[0]))
+			if err := callHTTP(cmd, http.MethodGet, path, nil, &resp); err != nil {
+				return err
+			}
+			revs := resp.Revisions
+
+			report := [][]string{{"REVISION", "REASON", "CREATED AT"}}
+			for _, rev := range revs {
+				report = append(report, []string{strconv.FormatInt(rev.Revision, 10), rev.Reason, rev.CreatedAt.Format(time.RFC3339)})
+			}
+			printer.Table(os.Stdout, report)
+			fmt.Println("\nTotal: ", len(revs))
+			return nil
+		}),
+	}
+
+	return cmd
+}
+
+func rollbackModuleCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollback <module-urn> <revision>",
+		Short: "restore configs of a module to a previous revision",
+		Args:  cobra.ExactArgs(2),
+		RunE: handleErr(func(cmd *cobra.Command, args []string) error {
+			revision, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid revision '%s': %w", args[1], err)
+			}
+
+			var resp struct {
+				Module module.Module `json:"module"`
+			}
+			reqBody := map[string]interface{}{"revision": revision}
+			path := fmt.Sprintf("/api/v1beta1/modules/%s/rollback", url.PathEscape(args[0]))
+			if err := callHTTP(cmd, http.MethodPost, path, reqBody, &resp); err != nil {
+				return err
+			}
+			mod := resp.Module
+
+			fmt.Println(term.Greenf("module '%s' rolled back to revision %d (now at revision %d)", mod.URN, revision, mod.Revision))
+			return nil
+		}),
+	}
+
+	return cmd
+}
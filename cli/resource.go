@@ -10,6 +10,9 @@ import (
 	"github.com/MakeNowJust/heredoc"
 	"github.com/odpf/salt/printer"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc/metadata"
+
+	resourcesv1 "github.com/odpf/entropy/internal/server/v1/resources"
 )
 
 func cmdResource() *cobra.Command {
@@ -96,12 +99,13 @@ func createResourceCommand() *cobra.Command {
 }
 
 func listAllResourcesCommand() *cobra.Command {
-	var output, kind, project string
+	var output, kind, project, health string
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "list all resources",
 		Example: heredoc.Doc(`
 			$ entropy resource list --kind=<resource-kind> --project=<project-name> --out=json
+			$ entropy resource list --kind=firehose --health=DEGRADED
 		`),
 		Annotations: map[string]string{
 			"action:core": "true",
@@ -120,7 +124,12 @@ func listAllResourcesCommand() *cobra.Command {
 			}
 			defer cancel()
 
-			res, err := client.ListResources(cmd.Context(), &reqBody)
+			ctx := cmd.Context()
+			if health != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, resourcesv1.HealthFilterKey, health)
+			}
+
+			res, err := client.ListResources(ctx, &reqBody)
 			if err != nil {
 				return err
 			}
@@ -154,6 +163,7 @@ func listAllResourcesCommand() *cobra.Command {
 	cmd.Flags().StringVarP(&output, "out", "o", "", "output format, `-o json | yaml`")
 	cmd.Flags().StringVarP(&kind, "kind", "k", "", "kind of resources")
 	cmd.Flags().StringVarP(&project, "project", "p", "", "project of resources")
+	cmd.Flags().StringVar(&health, "health", "", "health of resources, `HEALTHY | DEGRADED | UNKNOWN`")
 
 	return cmd
 }
@@ -198,9 +208,14 @@ func viewResourceCommand() *cobra.Command {
 			} else {
 				r := res.GetResource()
 
+				moduleRevision := r.GetLabels()[resourcesv1.LabelModuleRevision]
+				if moduleRevision == "" {
+					moduleRevision = "-"
+				}
+
 				printer.Table(os.Stdout, [][]string{
-					{"URN", "NAME", "KIND", "PROJECT", "STATUS"},
-					{r.Urn, r.Name, r.Kind, r.Project, r.State.Status.String()},
+					{"URN", "NAME", "KIND", "PROJECT", "STATUS", "MODULE REVISION"},
+					{r.Urn, r.Name, r.Kind, r.Project, r.State.Status.String(), moduleRevision},
 				})
 
 				fmt.Println(term.Cyanf("\nTo view all the data in JSON/YAML format, use flag `-o json | yaml`"))
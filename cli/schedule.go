@@ -0,0 +1,210 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/ghodss/yaml"
+	"github.com/odpf/salt/printer"
+	"github.com/odpf/salt/term" // nolint
+	"github.com/spf13/cobra"
+
+	"github.com/odpf/entropy/core/resource"
+)
+
+func cmdSchedule() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "schedule",
+		Aliases: []string{"schedules"},
+		Short:   "Manage scheduled actions",
+		Annotations: map[string]string{
+			"group:core": "true",
+		},
+		Example: heredoc.Doc(`
+			$ entropy schedule create scale --urn=<resource-urn> --at=2022-10-01T22:00:00Z --file=<params-file>
+			$ entropy schedule create scale --urn=<resource-urn> --cron="0 22 * * 1-5" --file=<params-file>
+			$ entropy schedule list --urn=<resource-urn> --status=PENDING
+			$ entropy schedule runs <schedule-id>
+			$ entropy schedule cancel <schedule-id>
+		`),
+	}
+
+	cmd.AddCommand(
+		createScheduleCommand(),
+		listSchedulesCommand(),
+		listScheduleRunsCommand(),
+		cancelScheduleCommand(),
+	)
+
+	return cmd
+}
+
+func createScheduleCommand() *cobra.Command {
+	var urn, file, at, cronExpr string
+	cmd := &cobra.Command{
+		Use:   "create <action-name>",
+		Short: "schedule an action on a resource, once (--at) or recurring (--cron)",
+		Args:  cobra.ExactArgs(1),
+		RunE: handleErr(func(cmd *cobra.Command, args []string) error {
+			if (at == "") == (cronExpr == "") {
+				return fmt.Errorf("exactly one of --at or --cron must be set")
+			}
+
+			body := map[string]interface{}{
+				"urn":    urn,
+				"action": args[0],
+			}
+			if file != "" {
+				params, err := readJSONFile(file)
+				if err != nil {
+					return err
+				}
+				body["params"] = params
+			}
+			if cronExpr != "" {
+				body["cron"] = cronExpr
+			} else {
+				runAt, err := time.Parse(time.RFC3339, at)
+				if err != nil {
+					return fmt.Errorf("invalid value for --at: %w", err)
+				}
+				body["run_at"] = runAt
+			}
+
+			var resp struct {
+				Schedule resource.ScheduledAction `json:"schedule"`
+			}
+			if err := callHTTP(cmd, http.MethodPost, "/api/v1beta1/schedules", body, &resp); err != nil {
+				return err
+			}
+
+			fmt.Println("ID: \t", term.Greenf(resp.Schedule.ID))
+			fmt.Println("Next Run: \t", resp.Schedule.RunAt.Format(time.RFC3339))
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVarP(&urn, "urn", "u", "", "urn of the resource")
+	cmd.Flags().StringVarP(&file, "file", "f", "", "path to the params file")
+	cmd.Flags().StringVar(&at, "at", "", "time to run the action at (RFC3339)")
+	cmd.Flags().StringVar(&cronExpr, "cron", "", "cron expression to run the action on (e.g., 'CRON_TZ=Asia/Kolkata 0 22 * * 1-5')")
+	_ = cmd.MarkFlagRequired("urn")
+
+	return cmd
+}
+
+func listSchedulesCommand() *cobra.Command {
+	var urn, status string
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "list scheduled actions",
+		RunE: handleErr(func(cmd *cobra.Command, args []string) error {
+			query := url.Values{}
+			if urn != "" {
+				query.Set("urn", urn)
+			}
+			if status != "" {
+				query.Set("status", status)
+			}
+
+			var resp struct {
+				Schedules []resource.ScheduledAction `json:"schedules"`
+			}
+			if err := callHTTP(cmd, http.MethodGet, "/api/v1beta1/schedules?"+query.Encode(), nil, &resp); err != nil {
+				return err
+			}
+			schedules := resp.Schedules
+
+			report := [][]string{{"ID", "URN", "ACTION", "CRON", "RUN AT", "STATUS"}}
+			for _, sa := range schedules {
+				report = append(report, []string{sa.ID, sa.URN, sa.Action, sa.Cron, sa.RunAt.Format(time.RFC3339), sa.Status})
+			}
+			printer.Table(os.Stdout, report)
+			fmt.Println("\nTotal: ", len(schedules))
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVarP(&urn, "urn", "u", "", "urn of the resource")
+	cmd.Flags().StringVarP(&status, "status", "s", "", "status of the scheduled actions")
+
+	return cmd
+}
+
+func listScheduleRunsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "runs <schedule-id>",
+		Short: "list outcomes of all runs of a scheduled action",
+		Args:  cobra.ExactArgs(1),
+		RunE: handleErr(func(cmd *cobra.Command, args []string) error {
+			var resp struct {
+				Runs []resource.ScheduleRun `json:"runs"`
+			}
+			path := fmt.Sprintf("/api/v1beta1/schedules/%s/runs", url.PathEscape(args[0]))
+			if err := callHTTP(cmd, http.MethodGet, path, nil, &resp); err != nil {
+				return err
+			}
+			runs := resp.Runs
+
+			report := [][]string{{"RUN AT", "STATUS", "RESULT"}}
+			for _, run := range runs {
+				report = append(report, []string{run.RunAt.Format(time.RFC3339), run.Status, run.Result})
+			}
+			printer.Table(os.Stdout, report)
+			fmt.Println("\nTotal: ", len(runs))
+			return nil
+		}),
+	}
+
+	return cmd
+}
+
+func cancelScheduleCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cancel <schedule-id>",
+		Short: "cancel a pending scheduled action",
+		Args:  cobra.ExactArgs(1),
+		RunE: handleErr(func(cmd *cobra.Command, args []string) error {
+			path := fmt.Sprintf("/api/v1beta1/schedules/%s/cancel", url.PathEscape(args[0]))
+			if err := callHTTP(cmd, http.MethodPost, path, nil, nil); err != nil {
+				return err
+			}
+
+			fmt.Println(term.Greenf("scheduled action '%s' cancelled", args[0]))
+			return nil
+		}),
+	}
+
+	return cmd
+}
+
+func readJSONFile(filePath string) (json.RawMessage, error) {
+	b, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	switch filepath.Ext(filePath) {
+	case ".json":
+		if !json.Valid(b) {
+			return nil, fmt.Errorf("invalid json in '%s'", filePath)
+		}
+		return b, nil
+
+	case ".yaml", ".yml":
+		j, err := yaml.YAMLToJSON(b)
+		if err != nil {
+			return nil, fmt.Errorf("invalid yaml: %w", err)
+		}
+		return j, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported file type") // nolint
+	}
+}
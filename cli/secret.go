@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/odpf/salt/printer"
+	"github.com/odpf/salt/term" // nolint
+	"github.com/spf13/cobra"
+
+	"github.com/odpf/entropy/core/secret"
+)
+
+func cmdSecret() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "secret",
+		Aliases: []string{"secrets"},
+		Short:   "Manage project secrets",
+		Annotations: map[string]string{
+			"group:core": "true",
+		},
+		Example: heredoc.Doc(`
+			$ echo -n "s3cr3t" | entropy secret set <project> <name>
+			$ entropy secret list <project>
+			$ entropy secret delete <project> <name>
+
+			Resource configs refer to a secret using '{"secret_ref": "<name>"}'.
+		`),
+	}
+
+	cmd.AddCommand(
+		setSecretCommand(),
+		listSecretsCommand(),
+		deleteSecretCommand(),
+	)
+
+	return cmd
+}
+
+func setSecretCommand() *cobra.Command {
+	var value string
+	cmd := &cobra.Command{
+		Use:   "set <project> <name>",
+		Short: "create or update a secret (value is read from stdin unless --value is set)",
+		Args:  cobra.ExactArgs(2),
+		RunE: handleErr(func(cmd *cobra.Command, args []string) error {
+			if !cmd.Flags().Changed("value") {
+				b, err := io.ReadAll(cmd.InOrStdin())
+				if err != nil {
+					return err
+				}
+				value = strings.TrimRight(string(b), "\r\n")
+			}
+
+			var resp struct {
+				Secret secret.Secret `json:"secret"`
+			}
+			path := fmt.Sprintf("/api/v1beta1/projects/%s/secrets/%s", url.PathEscape(args[0]), url.PathEscape(args[1]))
+			if err := callHTTP(cmd, http.MethodPut, path, map[string]string{"value": value}, &resp); err != nil {
+				return err
+			}
+			sec := resp.Secret
+
+			fmt.Println(term.Greenf("secret '%s' saved in project '%s'", sec.Name, sec.Project))
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(&value, "value", "", "value of the secret")
+
+	return cmd
+}
+
+func listSecretsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list <project>",
+		Short: "list secrets of a project (values are never shown)",
+		Args:  cobra.ExactArgs(1),
+		RunE: handleErr(func(cmd *cobra.Command, args []string) error {
+			var resp struct {
+				Secrets []secret.Secret `json:"secrets"`
+			}
+			path := fmt.Sprintf("/api/v1beta1/projects/%s/secrets", url.PathEscape(args[0]))
+			if err := callHTTP(cmd, http.MethodGet, path, nil, &resp); err != nil {
+				return err
+			}
+			secrets := resp.Secrets
+
+			report := [][]string{{"NAME", "PROJECT", "UPDATED AT"}}
+			for _, sec := range secrets {
+				report = append(report, []string{sec.Name, sec.Project, sec.UpdatedAt.String()})
+			}
+			printer.Table(os.Stdout, report)
+			fmt.Println("\nTotal: ", len(secrets))
+			return nil
+		}),
+	}
+
+	return cmd
+}
+
+func deleteSecretCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete <project> <name>",
+		Short: "delete a secret",
+		Args:  cobra.ExactArgs(2),
+		RunE: handleErr(func(cmd *cobra.Command, args []string) error {
+			path := fmt.Sprintf("/api/v1beta1/projects/%s/secrets/%s", url.PathEscape(args[0]), url.PathEscape(args[1]))
+			if err := callHTTP(cmd, http.MethodDelete, path, nil, nil); err != nil {
+				return err
+			}
+
+			fmt.Println(term.Greenf("secret '%s' deleted from project '%s'", args[1], args[0]))
+			return nil
+		}),
+	}
+
+	return cmd
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"context"
+	"encoding/base64"
 	"time"
 
 	"github.com/newrelic/go-agent/v3/newrelic"
@@ -10,11 +11,21 @@ import (
 
 	"github.com/odpf/entropy/core"
 	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/secret"
 	entropyserver "github.com/odpf/entropy/internal/server"
 	"github.com/odpf/entropy/internal/store/postgres"
 	"github.com/odpf/entropy/modules"
 	"github.com/odpf/entropy/modules/firehose"
+	"github.com/odpf/entropy/modules/helmrelease"
+	"github.com/odpf/entropy/modules/kafkacluster"
+	"github.com/odpf/entropy/modules/kafkatopic"
+	"github.com/odpf/entropy/modules/kubecronjob"
+	"github.com/odpf/entropy/modules/kubejob"
+	"github.com/odpf/entropy/modules/kubemanifests"
+	"github.com/odpf/entropy/modules/kubenamespace"
 	"github.com/odpf/entropy/modules/kubernetes"
+	"github.com/odpf/entropy/modules/plugin"
+	"github.com/odpf/entropy/modules/postgresdatabase"
 	"github.com/odpf/entropy/pkg/logger"
 	"github.com/odpf/entropy/pkg/telemetry"
 	"github.com/odpf/entropy/pkg/worker"
@@ -77,9 +88,18 @@ func runServer(baseCtx context.Context, nrApp *newrelic.Application, zapLog *zap
 	ctx, cancel := context.WithCancel(baseCtx)
 	defer cancel()
 
-	store := setupStorage(zapLog, cfg.PGConnStr)
-	moduleService := module.NewService(setupRegistry(zapLog), store)
-	resourceService := core.New(store, moduleService, asyncWorker, time.Now, zapLog)
+	resourceService, moduleService, secretService, plugins := setupServices(zapLog, cfg, asyncWorker)
+	defer closePlugins(zapLog, plugins)
+
+	for _, p := range plugins {
+		kind := p.Descriptor().Kind
+		go p.Supervise(ctx, func(err error) {
+			zapLog.Error("module plugin is not running, calls to it will fail until it restarts",
+				zap.String("module_kind", kind),
+				zap.Error(err),
+			)
+		})
+	}
 
 	if err := asyncWorker.Register(core.JobKindSyncResource, resourceService.HandleSyncJob); err != nil {
 		return err
@@ -89,13 +109,73 @@ func runServer(baseCtx context.Context, nrApp *newrelic.Application, zapLog *zap
 		return err
 	}
 
-	return entropyserver.Serve(ctx, cfg.Service.addr(), nrApp, zapLog, resourceService, moduleService)
+	if err := asyncWorker.Register(core.JobKindScheduledAction, resourceService.HandleScheduledActionJob); err != nil {
+		return err
+	}
+
+	if err := asyncWorker.Register(core.JobKindResourceExpiry, resourceService.HandleExpiryJob); err != nil {
+		return err
+	}
+
+	if err := asyncWorker.Register(core.JobKindResourceExpiryWarning, resourceService.HandleExpiryWarningJob); err != nil {
+		return err
+	}
+
+	if err := asyncWorker.Register(core.JobKindAutoscale, resourceService.HandleAutoscaleJob); err != nil {
+		return err
+	}
+
+	if err := resourceService.StartAutoscaling(ctx); err != nil {
+		return err
+	}
+
+	return entropyserver.Serve(ctx, cfg.Service.addr(), nrApp, zapLog,
+		resourceService, moduleService, moduleService, secretService, resourceService, cfg.Service.RevealKey)
+}
+
+// setupServices returns the services along with the clients of the module
+// plugins in use. Plugins exit along with this process, but long-running
+// callers should close them on exit.
+func setupServices(zapLog *zap.Logger, cfg Config, asyncWorker *worker.Worker) (*core.Service, *module.Service, *secret.Service, []*plugin.Client) {
+	store := setupStorage(zapLog, cfg.PGConnStr, cfg.SecretKey)
+	secretService := secret.NewService(store)
+	registry, plugins := setupRegistry(zapLog, cfg.PluginDir)
+	moduleService := module.NewService(registry, store, secretService)
+	resourceService := core.New(store, moduleService, asyncWorker, time.Now, zapLog,
+		core.WithExpiryWarning(cfg.Expiry.WarnBefore, expiryNotifier(zapLog, cfg.Expiry)),
+		core.WithAutoscaling(cfg.Autoscale.Interval),
+	)
+	return resourceService, moduleService, secretService, plugins
 }
 
-func setupRegistry(logger *zap.Logger) module.Registry {
+func setupRegistry(logger *zap.Logger, pluginDir string) (module.Registry, []*plugin.Client) {
 	supported := []module.Descriptor{
 		kubernetes.Module,
 		firehose.Module,
+		helmrelease.Module,
+		kubemanifests.Module,
+		kafkacluster.Module,
+		kafkatopic.Module,
+		postgresdatabase.Module,
+		kubenamespace.Module,
+		kubejob.Module,
+		kubecronjob.Module,
+	}
+
+	var plugins []*plugin.Client
+	if pluginDir != "" {
+		var err error
+		plugins, err = plugin.LoadDir(context.Background(), pluginDir)
+		if err != nil {
+			logger.Fatal("failed to load module plugins",
+				zap.String("plugin_dir", pluginDir),
+				zap.Error(err),
+			)
+		}
+
+		for _, p := range plugins {
+			supported = append(supported, p.Descriptor())
+		}
 	}
 
 	registry := &modules.Registry{}
@@ -107,7 +187,18 @@ func setupRegistry(logger *zap.Logger) module.Registry {
 			)
 		}
 	}
-	return registry
+	return registry, plugins
+}
+
+func closePlugins(logger *zap.Logger, plugins []*plugin.Client) {
+	for _, p := range plugins {
+		if err := p.Close(); err != nil {
+			logger.Warn("failed to stop module plugin",
+				zap.String("module_kind", p.Descriptor().Kind),
+				zap.Error(err),
+			)
+		}
+	}
 }
 
 func setupWorker(logger *zap.Logger, conf workerConf) *worker.Worker {
@@ -129,11 +220,23 @@ func setupWorker(logger *zap.Logger, conf workerConf) *worker.Worker {
 	return asyncWorker
 }
 
-func setupStorage(logger *zap.Logger, pgConStr string) *postgres.Store {
+func setupStorage(logger *zap.Logger, pgConStr, secretKey string) *postgres.Store {
 	store, err := postgres.Open(pgConStr)
 	if err != nil {
 		logger.Fatal("failed to connect to Postgres database",
 			zap.Error(err), zap.String("conn_str", pgConStr))
 	}
+
+	if secretKey == "" {
+		logger.Warn("secret_key is not set, project secrets are stored in plaintext")
+		return store
+	}
+
+	key, err := base64.StdEncoding.DecodeString(secretKey)
+	if err != nil {
+		logger.Fatal("secret_key must be base64-encoded", zap.Error(err))
+	} else if err := store.EncryptSecrets(key); err != nil {
+		logger.Fatal("invalid secret_key", zap.Error(err))
+	}
 	return store
 }
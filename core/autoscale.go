@@ -0,0 +1,146 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/worker"
+)
+
+const JobKindAutoscale = "autoscale_resources"
+
+const autoscaleDecisionTimeout = 10 * time.Second
+
+// WithAutoscaling enables autoscaling of the resources whose modules support
+// it. Resources are checked once every interval.
+func WithAutoscaling(interval time.Duration) Option {
+	return func(s *Service) {
+		s.autoscaleInterval = interval
+	}
+}
+
+// StartAutoscaling enqueues the first autoscale job, if autoscaling is
+// enabled. Every autoscale job enqueues the next one. So this is safe to
+// call any number of times (e.g., on every startup).
+func (s *Service) StartAutoscaling(ctx context.Context) error {
+	if s.autoscaleInterval <= 0 {
+		return nil
+	}
+	return s.enqueueAutoscaleJob(ctx, s.clock())
+}
+
+// HandleAutoscaleJob is meant to be invoked by asyncWorker periodically. Every
+// resource that is not busy with another change is given to its module to
+// decide whether it should be scaled, and the decided action is applied.
+func (s *Service) HandleAutoscaleJob(ctx context.Context, job worker.Job) ([]byte, error) {
+	if s.autoscaleInterval <= 0 {
+		return skipResult("autoscaling disabled"), nil
+	}
+
+	// the next run is enqueued first, so that a failure of this run does not
+	// stop autoscaling altogether.
+	if err := s.enqueueAutoscaleJob(ctx, job.RunAt); err != nil {
+		return nil, &worker.RetryableError{Cause: err, RetryAfter: 5 * time.Second}
+	}
+
+	resources, err := s.store.List(ctx, resource.Filter{})
+	if err != nil {
+		return nil, err
+	}
+
+	scaled := map[string]string{}
+	failed := map[string]string{}
+	for _, res := range resources {
+		if res.State.Status != resource.StatusCompleted {
+			continue
+		}
+
+		// failure to scale one resource should not affect the others.
+		reason, err := s.autoscale(ctx, res)
+		if err != nil {
+			failed[res.URN] = errors.Verbose(err).Error()
+		} else if reason != "" {
+			scaled[res.URN] = reason
+		}
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"checked": len(resources),
+		"scaled":  scaled,
+		"failed":  failed,
+	})
+}
+
+// autoscale applies the action decided by the module for the resource, if
+// any, and returns the reason for it. The reason is also recorded with the
+// revision created by the action.
+func (s *Service) autoscale(ctx context.Context, res resource.Resource) (string, error) {
+	decision, err := s.autoscaleDecision(ctx, res)
+	if err != nil {
+		if errors.Is(err, errors.ErrUnsupported) {
+			return "", nil
+		}
+		return "", err
+	} else if decision == nil {
+		return "", nil
+	}
+
+	// the resource may have changed since it was listed.
+	current, err := s.getIdleResource(ctx, res.URN, "autoscale")
+	if err != nil {
+		return "", err
+	}
+
+	act := decision.Action
+	act.Labels = current.Labels
+	planned, err := s.planChange(ctx, *current, act)
+	if err != nil {
+		return "", err
+	}
+	planned.Resource.CreatedAt = current.CreatedAt
+	planned.Resource.UpdatedAt = s.clock()
+	planned.Resource.ExpiresAt = current.ExpiresAt
+
+	reason := fmt.Sprintf("autoscaled: %s", decision.Reason)
+	if err := s.upsert(ctx, *planned, false, true, reason); err != nil {
+		return "", err
+	}
+	return reason, nil
+}
+
+// autoscaleDecision asks the module of the resource for a decision, giving
+// it at most autoscaleDecisionTimeout. So a module waiting on an unreachable
+// system does not hold up the resources after it.
+func (s *Service) autoscaleDecision(ctx context.Context, res resource.Resource) (*module.ScaleDecision, error) {
+	ctx, cancel := context.WithTimeout(ctx, autoscaleDecisionTimeout)
+	defer cancel()
+
+	modSpec, err := s.generateModuleSpec(ctx, res)
+	if err != nil {
+		return nil, err
+	}
+	return s.moduleSvc.Autoscale(ctx, *modSpec)
+}
+
+func (s *Service) enqueueAutoscaleJob(ctx context.Context, after time.Time) error {
+	// runs are aligned to the interval, so that the same job is enqueued
+	// by every instance and by every previous run.
+	runAt := after.Truncate(s.autoscaleInterval).Add(s.autoscaleInterval)
+
+	job := worker.Job{
+		ID:      fmt.Sprintf(JobKindAutoscale+"-%d", runAt.Unix()),
+		Kind:    JobKindAutoscale,
+		RunAt:   runAt,
+		Payload: []byte("{}"),
+	}
+
+	if err := s.worker.Enqueue(ctx, job); err != nil && !errors.Is(err, worker.ErrJobExists) {
+		return err
+	}
+	return nil
+}
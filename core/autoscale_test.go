@@ -0,0 +1,171 @@
+package core_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/odpf/entropy/core"
+	"github.com/odpf/entropy/core/mocks"
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/worker"
+)
+
+func TestService_StartAutoscaling(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Disabled", func(t *testing.T) {
+		t.Parallel()
+
+		svc := core.New(&mocks.ResourceStore{}, &mocks.ModuleService{}, &mocks.AsyncWorker{}, deadClock, nil)
+		assert.NoError(t, svc.StartAutoscaling(context.Background()))
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		t.Parallel()
+
+		wantRunAt := frozenTime.Truncate(time.Minute).Add(time.Minute)
+
+		asyncWorker := &mocks.AsyncWorker{}
+		asyncWorker.EXPECT().
+			Enqueue(mock.Anything, mock.Anything).
+			Run(func(ctx context.Context, jobs ...worker.Job) {
+				require.Len(t, jobs, 1)
+				assert.Equal(t, core.JobKindAutoscale, jobs[0].Kind)
+				assert.Equal(t, wantRunAt, jobs[0].RunAt)
+			}).
+			Return(worker.ErrJobExists).Once()
+
+		svc := core.New(&mocks.ResourceStore{}, &mocks.ModuleService{}, asyncWorker, deadClock, nil,
+			core.WithAutoscaling(time.Minute),
+		)
+		assert.NoError(t, svc.StartAutoscaling(context.Background()))
+	})
+}
+
+func TestService_HandleAutoscaleJob(t *testing.T) {
+	t.Parallel()
+
+	job := worker.Job{Kind: core.JobKindAutoscale, RunAt: frozenTime}
+
+	t.Run("Disabled", func(t *testing.T) {
+		t.Parallel()
+
+		svc := core.New(&mocks.ResourceStore{}, &mocks.ModuleService{}, &mocks.AsyncWorker{}, deadClock, nil)
+
+		got, err := svc.HandleAutoscaleJob(context.Background(), job)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"status": "skipped", "reason": "autoscaling disabled"}`, string(got))
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		scalable := resource.Resource{
+			URN:     "orn:entropy:mock:project:scalable",
+			Kind:    "mock",
+			Name:    "scalable",
+			Project: "project",
+			Labels:  map[string]string{"team": "data"},
+			State:   resource.State{Status: resource.StatusCompleted},
+		}
+		unsupported := resource.Resource{
+			URN:     "orn:entropy:other:project:unsupported",
+			Kind:    "other",
+			Name:    "unsupported",
+			Project: "project",
+			State:   resource.State{Status: resource.StatusCompleted},
+		}
+		failing := resource.Resource{
+			URN:     "orn:entropy:mock:project:failing",
+			Kind:    "mock",
+			Name:    "failing",
+			Project: "project",
+			State:   resource.State{Status: resource.StatusCompleted},
+		}
+		busy := resource.Resource{
+			URN:     "orn:entropy:mock:project:busy",
+			Kind:    "mock",
+			Name:    "busy",
+			Project: "project",
+			State:   resource.State{Status: resource.StatusPending},
+		}
+
+		scaleAct := module.ActionRequest{Name: "scale", Params: json.RawMessage(`{"replicas":4}`)}
+
+		asyncWorker := &mocks.AsyncWorker{}
+		asyncWorker.EXPECT().
+			Enqueue(mock.Anything, mock.Anything).
+			Run(func(ctx context.Context, jobs ...worker.Job) {
+				require.Len(t, jobs, 1)
+				assert.Equal(t, core.JobKindAutoscale, jobs[0].Kind)
+				assert.Equal(t, frozenTime.Truncate(time.Minute).Add(time.Minute), jobs[0].RunAt)
+			}).
+			Return(nil).Once()
+
+		resourceRepo := &mocks.ResourceStore{}
+		resourceRepo.EXPECT().
+			List(mock.Anything, resource.Filter{}).
+			Return([]resource.Resource{scalable, unsupported, failing, busy}, nil).Once()
+		resourceRepo.EXPECT().
+			GetByURN(mock.Anything, scalable.URN).
+			Return(&scalable, nil).Once()
+		resourceRepo.EXPECT().
+			Update(mock.Anything, mock.Anything, true, "autoscaled: consumer lag is above the target", mock.Anything).
+			Run(func(ctx context.Context, r resource.Resource, saveRevision bool, reason string, hooks ...resource.MutationHook) {
+				assert.Equal(t, scalable.URN, r.URN)
+				assert.Equal(t, scalable.Labels, r.Labels)
+				assert.Equal(t, resource.StatusPending, r.State.Status)
+			}).
+			Return(nil).Once()
+
+		// every module is given a deadline for its decision.
+		withDeadline := mock.MatchedBy(func(ctx context.Context) bool {
+			_, ok := ctx.Deadline()
+			return ok
+		})
+
+		mod := &mocks.ModuleService{}
+		mod.EXPECT().
+			Autoscale(withDeadline, module.ExpandedResource{Resource: scalable, Dependencies: map[string]module.ResolvedDependency{}}).
+			Return(&module.ScaleDecision{Action: scaleAct, Reason: "consumer lag is above the target"}, nil).Once()
+		mod.EXPECT().
+			Autoscale(withDeadline, module.ExpandedResource{Resource: unsupported, Dependencies: map[string]module.ResolvedDependency{}}).
+			Return(nil, errors.ErrUnsupported).Once()
+		mod.EXPECT().
+			Autoscale(withDeadline, module.ExpandedResource{Resource: failing, Dependencies: map[string]module.ResolvedDependency{}}).
+			Return(nil, errors.New("kafka unreachable")).Once()
+		mod.EXPECT().
+			GetOutput(mock.Anything, mock.Anything).
+			Return(nil, nil).Once()
+		mod.EXPECT().
+			PlanAction(mock.Anything, mock.Anything, module.ActionRequest{Name: "scale", Params: scaleAct.Params, Labels: scalable.Labels}).
+			Return(&module.Plan{
+				Resource: resource.Resource{
+					URN:     scalable.URN,
+					Kind:    scalable.Kind,
+					Name:    scalable.Name,
+					Project: scalable.Project,
+					State:   resource.State{Status: resource.StatusPending},
+				},
+				Reason: "firehose scaled",
+			}, nil).Once()
+
+		svc := core.New(resourceRepo, mod, asyncWorker, deadClock, nil, core.WithAutoscaling(time.Minute))
+
+		got, err := svc.HandleAutoscaleJob(context.Background(), job)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"checked": 4,
+			"scaled": {"orn:entropy:mock:project:scalable": "autoscaled: consumer lag is above the target"},
+			"failed": {"orn:entropy:mock:project:failing": "kafka unreachable"}
+		}`, string(got))
+	})
+}
@@ -22,31 +22,46 @@ type Service struct {
 	store     resource.Store
 	worker    AsyncWorker
 	moduleSvc ModuleService
+
+	expiryWarnBefore time.Duration
+	expiryNotify     ExpiryNotifyFn
+
+	autoscaleInterval time.Duration
 }
 
+// Option customises the Service created by New.
+type Option func(s *Service)
+
 type ModuleService interface {
 	PlanAction(ctx context.Context, res module.ExpandedResource, act module.ActionRequest) (*module.Plan, error)
 	SyncState(ctx context.Context, res module.ExpandedResource) (*resource.State, error)
 	StreamLogs(ctx context.Context, res module.ExpandedResource, filter map[string]string) (<-chan module.LogChunk, error)
 	GetOutput(ctx context.Context, res module.ExpandedResource) (json.RawMessage, error)
+	GetSecrets(ctx context.Context, kind, project string) (*module.Secrets, error)
+	Autoscale(ctx context.Context, res module.ExpandedResource) (*module.ScaleDecision, error)
+	GetHealth(ctx context.Context, res module.ExpandedResource) (*resource.Health, error)
 }
 
 type AsyncWorker interface {
 	Enqueue(ctx context.Context, jobs ...worker.Job) error
 }
 
-func New(repo resource.Store, moduleSvc ModuleService, asyncWorker AsyncWorker, clockFn func() time.Time, lg *zap.Logger) *Service {
+func New(repo resource.Store, moduleSvc ModuleService, asyncWorker AsyncWorker, clockFn func() time.Time, lg *zap.Logger, opts ...Option) *Service {
 	if clockFn == nil {
 		clockFn = time.Now
 	}
 
-	return &Service{
+	svc := &Service{
 		logger:    lg,
 		clock:     clockFn,
 		store:     repo,
 		worker:    asyncWorker,
 		moduleSvc: moduleSvc,
 	}
+	for _, opt := range opts {
+		opt(svc)
+	}
+	return svc
 }
 
 func (s *Service) generateModuleSpec(ctx context.Context, res resource.Resource) (*module.ExpandedResource, error) {
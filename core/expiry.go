@@ -0,0 +1,221 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/worker"
+)
+
+const (
+	JobKindResourceExpiry        = "resource_expiry"
+	JobKindResourceExpiryWarning = "resource_expiry_warning"
+)
+
+// maxExpiryAttempts limits the number of times an expiry (or warning) job
+// is retried when the resource is busy or a transient failure occurs.
+const maxExpiryAttempts = 20
+
+// ExpiryNotifyFn is invoked ahead of the expiry of a resource to warn the
+// owners about the upcoming deletion.
+type ExpiryNotifyFn func(ctx context.Context, res resource.Resource) error
+
+type expiryJobPayload struct {
+	URN       string    `json:"urn"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// WithExpiryWarning enables warnings for resources that are about to expire.
+// notify is invoked 'warnBefore' the expiry time of the resource.
+func WithExpiryWarning(warnBefore time.Duration, notify ExpiryNotifyFn) Option {
+	return func(s *Service) {
+		s.expiryWarnBefore = warnBefore
+		s.expiryNotify = notify
+	}
+}
+
+// ExtendExpiry moves the expiry of the resource to the given time, which
+// must be later than the current expiry. Resources without an expiry can
+// also be given one using this.
+func (s *Service) ExtendExpiry(ctx context.Context, urn string, expiresAt time.Time) (*resource.Resource, error) {
+	if err := s.validateExpiry(&expiresAt); err != nil {
+		return nil, err
+	}
+	return s.setExpiry(ctx, urn, "extend_expiry", &expiresAt)
+}
+
+// ClearExpiry removes the expiry of the resource, so that it is no longer
+// deleted automatically. The expiry jobs already enqueued are skipped when
+// they run.
+func (s *Service) ClearExpiry(ctx context.Context, urn string) (*resource.Resource, error) {
+	return s.setExpiry(ctx, urn, "clear_expiry", nil)
+}
+
+func (s *Service) setExpiry(ctx context.Context, urn, operation string, expiresAt *time.Time) (*resource.Resource, error) {
+	// the stored version is used since only the expiry is changed. the
+	// output from the module must not be written back.
+	res, err := s.store.GetByURN(ctx, urn)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return nil, errors.ErrNotFound.WithMsgf("resource with urn '%s' not found", urn)
+		}
+		return nil, errors.ErrInternal.WithCausef(err.Error())
+	} else if !res.State.IsTerminal() {
+		return nil, errors.ErrInvalid.
+			WithMsgf("cannot perform '%s' on resource in '%s'", operation, res.State.Status)
+	} else if expiresAt != nil && res.ExpiresAt != nil && !expiresAt.After(*res.ExpiresAt) {
+		return nil, errors.ErrInvalid.
+			WithMsgf("expires_at must be later than the current expiry (%s)", res.ExpiresAt.Format(time.RFC3339))
+	}
+
+	res.ExpiresAt = expiresAt
+	res.UpdatedAt = s.clock()
+
+	enqueueJobs := func(ctx context.Context) error {
+		return s.enqueueExpiryJobs(ctx, *res)
+	}
+
+	if err := s.store.Update(ctx, *res, false, "", enqueueJobs); err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return nil, errors.ErrNotFound.WithMsgf("resource with urn '%s' does not exist", urn)
+		}
+		return nil, errors.ErrInternal.WithCausef(err.Error())
+	}
+	return res, nil
+}
+
+// HandleExpiryJob is meant to be invoked by asyncWorker when the expiry time
+// of a resource is reached. The resource is deleted unless its expiry was
+// changed after the job was enqueued.
+func (s *Service) HandleExpiryJob(ctx context.Context, job worker.Job) ([]byte, error) {
+	const busyRetryBackoff = 30 * time.Second
+
+	res, skip, err := s.resolveExpiryJob(ctx, job)
+	if err != nil || skip != "" {
+		return skipResult(skip), err
+	}
+
+	if err := s.DeleteResource(ctx, res.URN); err != nil {
+		isRetryable := errors.Is(err, errors.ErrInternal) ||
+			(errors.Is(err, errors.ErrInvalid) && s.isResourceBusy(ctx, res.URN))
+		if isRetryable && job.AttemptsDone+1 < maxExpiryAttempts {
+			return nil, &worker.RetryableError{
+				Cause:      errors.Verbose(err),
+				RetryAfter: busyRetryBackoff,
+			}
+		}
+		return nil, errors.Verbose(err)
+	}
+
+	return json.Marshal(map[string]interface{}{"status": "deleted"})
+}
+
+// HandleExpiryWarningJob is meant to be invoked by asyncWorker ahead of the
+// expiry of a resource.
+func (s *Service) HandleExpiryWarningJob(ctx context.Context, job worker.Job) ([]byte, error) {
+	const retryBackoff = 1 * time.Minute
+
+	res, skip, err := s.resolveExpiryJob(ctx, job)
+	if err != nil || skip != "" {
+		return skipResult(skip), err
+	} else if s.expiryNotify == nil {
+		return skipResult("warnings disabled"), nil
+	}
+
+	if err := s.expiryNotify(ctx, *res); err != nil {
+		if job.AttemptsDone+1 < maxExpiryAttempts {
+			return nil, &worker.RetryableError{
+				Cause:      err,
+				RetryAfter: retryBackoff,
+			}
+		}
+		return nil, err
+	}
+
+	return json.Marshal(map[string]interface{}{"status": "notified"})
+}
+
+// resolveExpiryJob returns the resource the expiry job is meant for. If the
+// job is stale, a non-empty reason for skipping it is returned instead.
+func (s *Service) resolveExpiryJob(ctx context.Context, job worker.Job) (*resource.Resource, string, error) {
+	var data expiryJobPayload
+	if err := json.Unmarshal(job.Payload, &data); err != nil {
+		return nil, "", err
+	}
+
+	res, err := s.store.GetByURN(ctx, data.URN)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return nil, "resource not found", nil
+		}
+		return nil, "", &worker.RetryableError{Cause: err, RetryAfter: 5 * time.Second}
+	}
+
+	if res.ExpiresAt == nil || res.ExpiresAt.Unix() != data.ExpiresAt.Unix() {
+		// expiry was changed after the job was enqueued.
+		return nil, "expiry changed", nil
+	} else if res.State.InDeletion() {
+		return nil, "resource in deletion", nil
+	}
+	return res, "", nil
+}
+
+func (s *Service) validateExpiry(expiresAt *time.Time) error {
+	if expiresAt != nil && !expiresAt.After(s.clock()) {
+		return errors.ErrInvalid.WithMsgf("expires_at must be a time in the future")
+	}
+	return nil
+}
+
+func (s *Service) enqueueExpiryJobs(ctx context.Context, res resource.Resource) error {
+	if res.ExpiresAt == nil {
+		return nil
+	}
+	expiresAt := *res.ExpiresAt
+
+	payload, err := json.Marshal(expiryJobPayload{
+		URN:       res.URN,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	jobs := []worker.Job{
+		{
+			ID:      fmt.Sprintf(JobKindResourceExpiry+"-%s-%d", res.URN, expiresAt.Unix()),
+			Kind:    JobKindResourceExpiry,
+			RunAt:   expiresAt,
+			Payload: payload,
+		},
+	}
+
+	warnAt := expiresAt.Add(-s.expiryWarnBefore)
+	if s.expiryNotify != nil && s.expiryWarnBefore > 0 && warnAt.After(s.clock()) {
+		jobs = append(jobs, worker.Job{
+			ID:      fmt.Sprintf(JobKindResourceExpiryWarning+"-%s-%d", res.URN, expiresAt.Unix()),
+			Kind:    JobKindResourceExpiryWarning,
+			RunAt:   warnAt,
+			Payload: payload,
+		})
+	}
+
+	for _, job := range jobs {
+		if err := s.worker.Enqueue(ctx, job); err != nil && !errors.Is(err, worker.ErrJobExists) {
+			return err
+		}
+	}
+	return nil
+}
+
+func skipResult(reason string) []byte {
+	if reason == "" {
+		return nil
+	}
+	b, _ := json.Marshal(map[string]interface{}{"status": "skipped", "reason": reason})
+	return b
+}
@@ -0,0 +1,256 @@
+package core_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/odpf/entropy/core"
+	"github.com/odpf/entropy/core/mocks"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/worker"
+)
+
+func TestService_ExtendExpiry(t *testing.T) {
+	t.Parallel()
+
+	currentExpiry := frozenTime.Add(48 * time.Hour)
+	sampleRes := resource.Resource{
+		URN:       "orn:entropy:mock:project:child",
+		Kind:      "mock",
+		Name:      "child",
+		Project:   "project",
+		ExpiresAt: &currentExpiry,
+		State: resource.State{
+			Status: resource.StatusCompleted,
+			Output: json.RawMessage(`{"replicas": 1}`),
+		},
+	}
+
+	notify := func(ctx context.Context, res resource.Resource) error { return nil }
+
+	tests := []struct {
+		name      string
+		setup     func(t *testing.T) *core.Service
+		expiresAt time.Time
+		wantErr   error
+	}{
+		{
+			name: "PastExpiry",
+			setup: func(t *testing.T) *core.Service {
+				t.Helper()
+				return core.New(&mocks.ResourceStore{}, &mocks.ModuleService{}, &mocks.AsyncWorker{}, deadClock, nil)
+			},
+			expiresAt: frozenTime.Add(-1 * time.Hour),
+			wantErr:   errors.ErrInvalid,
+		},
+		{
+			name: "EarlierThanCurrentExpiry",
+			setup: func(t *testing.T) *core.Service {
+				t.Helper()
+				resourceRepo := &mocks.ResourceStore{}
+				resourceRepo.EXPECT().
+					GetByURN(mock.Anything, sampleRes.URN).
+					Return(&sampleRes, nil).Once()
+
+				return core.New(resourceRepo, &mocks.ModuleService{}, &mocks.AsyncWorker{}, deadClock, nil)
+			},
+			expiresAt: frozenTime.Add(24 * time.Hour),
+			wantErr:   errors.ErrInvalid,
+		},
+		{
+			name: "ResourceBusy",
+			setup: func(t *testing.T) *core.Service {
+				t.Helper()
+				busyRes := sampleRes
+				busyRes.State = resource.State{Status: resource.StatusPending}
+
+				resourceRepo := &mocks.ResourceStore{}
+				resourceRepo.EXPECT().
+					GetByURN(mock.Anything, sampleRes.URN).
+					Return(&busyRes, nil).Once()
+
+				return core.New(resourceRepo, &mocks.ModuleService{}, &mocks.AsyncWorker{}, deadClock, nil)
+			},
+			expiresAt: frozenTime.Add(72 * time.Hour),
+			wantErr:   errors.ErrInvalid,
+		},
+		{
+			name: "Success",
+			setup: func(t *testing.T) *core.Service {
+				t.Helper()
+				resourceRepo := &mocks.ResourceStore{}
+				resourceRepo.EXPECT().
+					GetByURN(mock.Anything, sampleRes.URN).
+					Return(&sampleRes, nil).Once()
+				resourceRepo.EXPECT().
+					Update(mock.Anything, mock.Anything, false, "", mock.Anything).
+					Run(func(ctx context.Context, r resource.Resource, saveRevision bool, reason string, hooks ...resource.MutationHook) {
+						assert.Equal(t, sampleRes.State, r.State)
+						assert.Len(t, hooks, 1)
+						assert.NoError(t, hooks[0](ctx))
+					}).
+					Return(nil).Once()
+
+				mockWorker := &mocks.AsyncWorker{}
+				mockWorker.EXPECT().
+					Enqueue(mock.Anything, mock.Anything).
+					Run(func(ctx context.Context, jobs ...worker.Job) {
+						assert.Len(t, jobs, 1)
+					}).
+					Return(nil).Twice()
+
+				return core.New(resourceRepo, &mocks.ModuleService{}, mockWorker, deadClock, nil,
+					core.WithExpiryWarning(24*time.Hour, notify),
+				)
+			},
+			expiresAt: frozenTime.Add(72 * time.Hour),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			svc := tt.setup(t)
+
+			got, err := svc.ExtendExpiry(context.Background(), sampleRes.URN, tt.expiresAt)
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				assert.Truef(t, errors.Is(err, tt.wantErr), "'%s' != '%s'", tt.wantErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expiresAt, *got.ExpiresAt)
+		})
+	}
+}
+
+func TestService_ClearExpiry(t *testing.T) {
+	t.Parallel()
+
+	currentExpiry := frozenTime.Add(48 * time.Hour)
+	sampleRes := resource.Resource{
+		URN:       "orn:entropy:mock:project:child",
+		Kind:      "mock",
+		Name:      "child",
+		Project:   "project",
+		ExpiresAt: &currentExpiry,
+		State:     resource.State{Status: resource.StatusCompleted},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		resourceRepo := &mocks.ResourceStore{}
+		resourceRepo.EXPECT().
+			GetByURN(mock.Anything, sampleRes.URN).
+			Return(&sampleRes, nil).Once()
+		resourceRepo.EXPECT().
+			Update(mock.Anything, mock.Anything, false, "", mock.Anything).
+			Run(func(ctx context.Context, r resource.Resource, saveRevision bool, reason string, hooks ...resource.MutationHook) {
+				assert.Nil(t, r.ExpiresAt)
+				assert.Len(t, hooks, 1)
+				// no expiry jobs are enqueued for the resource.
+				assert.NoError(t, hooks[0](ctx))
+			}).
+			Return(nil).Once()
+
+		svc := core.New(resourceRepo, &mocks.ModuleService{}, &mocks.AsyncWorker{}, deadClock, nil)
+
+		got, err := svc.ClearExpiry(context.Background(), sampleRes.URN)
+		assert.NoError(t, err)
+		assert.Nil(t, got.ExpiresAt)
+	})
+
+	t.Run("ResourceBusy", func(t *testing.T) {
+		t.Parallel()
+
+		busyRes := sampleRes
+		busyRes.State = resource.State{Status: resource.StatusPending}
+
+		resourceRepo := &mocks.ResourceStore{}
+		resourceRepo.EXPECT().
+			GetByURN(mock.Anything, sampleRes.URN).
+			Return(&busyRes, nil).Once()
+
+		svc := core.New(resourceRepo, &mocks.ModuleService{}, &mocks.AsyncWorker{}, deadClock, nil)
+
+		_, err := svc.ClearExpiry(context.Background(), sampleRes.URN)
+		assert.ErrorIs(t, err, errors.ErrInvalid)
+	})
+}
+
+func TestService_HandleExpiryJob(t *testing.T) {
+	t.Parallel()
+
+	expiresAt := frozenTime.Add(-1 * time.Minute)
+	payload, _ := json.Marshal(map[string]interface{}{
+		"urn":        "orn:entropy:mock:project:child",
+		"expires_at": expiresAt,
+	})
+	job := worker.Job{Kind: core.JobKindResourceExpiry, Payload: payload}
+
+	t.Run("ResourceNotFound", func(t *testing.T) {
+		t.Parallel()
+
+		resourceRepo := &mocks.ResourceStore{}
+		resourceRepo.EXPECT().
+			GetByURN(mock.Anything, "orn:entropy:mock:project:child").
+			Return(nil, errors.ErrNotFound).Once()
+		svc := core.New(resourceRepo, &mocks.ModuleService{}, &mocks.AsyncWorker{}, deadClock, nil)
+
+		got, err := svc.HandleExpiryJob(context.Background(), job)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"status": "skipped", "reason": "resource not found"}`, string(got))
+	})
+
+	t.Run("ExpiryChanged", func(t *testing.T) {
+		t.Parallel()
+
+		extended := expiresAt.Add(24 * time.Hour)
+		resourceRepo := &mocks.ResourceStore{}
+		resourceRepo.EXPECT().
+			GetByURN(mock.Anything, "orn:entropy:mock:project:child").
+			Return(&resource.Resource{
+				URN:       "orn:entropy:mock:project:child",
+				State:     resource.State{Status: resource.StatusCompleted},
+				ExpiresAt: &extended,
+			}, nil).Once()
+		svc := core.New(resourceRepo, &mocks.ModuleService{}, &mocks.AsyncWorker{}, deadClock, nil)
+
+		got, err := svc.HandleExpiryJob(context.Background(), job)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"status": "skipped", "reason": "expiry changed"}`, string(got))
+	})
+
+	t.Run("ResourceBusy", func(t *testing.T) {
+		t.Parallel()
+
+		pendingRes := resource.Resource{
+			URN:       "orn:entropy:mock:project:child",
+			State:     resource.State{Status: resource.StatusPending},
+			ExpiresAt: &expiresAt,
+		}
+
+		resourceRepo := &mocks.ResourceStore{}
+		resourceRepo.EXPECT().
+			GetByURN(mock.Anything, pendingRes.URN).
+			Return(&pendingRes, nil)
+
+		mod := &mocks.ModuleService{}
+		mod.EXPECT().
+			GetOutput(mock.Anything, mock.Anything).
+			Return(nil, nil)
+		svc := core.New(resourceRepo, mod, &mocks.AsyncWorker{}, deadClock, nil)
+
+		_, err := svc.HandleExpiryJob(context.Background(), job)
+		var retryErr *worker.RetryableError
+		assert.ErrorAs(t, err, &retryErr)
+	})
+}
@@ -22,6 +22,53 @@ func (_m *ModuleRegistry) EXPECT() *ModuleRegistry_Expecter {
 	return &ModuleRegistry_Expecter{mock: &_m.Mock}
 }
 
+// GetDescriptor provides a mock function with given fields: ctx, kind
+func (_m *ModuleRegistry) GetDescriptor(ctx context.Context, kind string) (*module.Descriptor, error) {
+	ret := _m.Called(ctx, kind)
+
+	var r0 *module.Descriptor
+	if rf, ok := ret.Get(0).(func(context.Context, string) *module.Descriptor); ok {
+		r0 = rf(ctx, kind)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*module.Descriptor)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, kind)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ModuleRegistry_GetDescriptor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDescriptor'
+type ModuleRegistry_GetDescriptor_Call struct {
+	*mock.Call
+}
+
+// GetDescriptor is a helper method to define mock.On call
+//  - ctx context.Context
+//  - kind string
+func (_e *ModuleRegistry_Expecter) GetDescriptor(ctx interface{}, kind interface{}) *ModuleRegistry_GetDescriptor_Call {
+	return &ModuleRegistry_GetDescriptor_Call{Call: _e.mock.On("GetDescriptor", ctx, kind)}
+}
+
+func (_c *ModuleRegistry_GetDescriptor_Call) Run(run func(ctx context.Context, kind string)) *ModuleRegistry_GetDescriptor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ModuleRegistry_GetDescriptor_Call) Return(_a0 *module.Descriptor, _a1 error) *ModuleRegistry_GetDescriptor_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
 // GetDriver provides a mock function with given fields: ctx, mod
 func (_m *ModuleRegistry) GetDriver(ctx context.Context, mod module.Module) (module.Driver, module.Descriptor, error) {
 	ret := _m.Called(ctx, mod)
@@ -75,3 +122,49 @@ func (_c *ModuleRegistry_GetDriver_Call) Return(_a0 module.Driver, _a1 module.De
 	_c.Call.Return(_a0, _a1, _a2)
 	return _c
 }
+
+// ListDescriptors provides a mock function with given fields: ctx
+func (_m *ModuleRegistry) ListDescriptors(ctx context.Context) ([]module.Descriptor, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []module.Descriptor
+	if rf, ok := ret.Get(0).(func(context.Context) []module.Descriptor); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]module.Descriptor)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ModuleRegistry_ListDescriptors_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDescriptors'
+type ModuleRegistry_ListDescriptors_Call struct {
+	*mock.Call
+}
+
+// ListDescriptors is a helper method to define mock.On call
+//  - ctx context.Context
+func (_e *ModuleRegistry_Expecter) ListDescriptors(ctx interface{}) *ModuleRegistry_ListDescriptors_Call {
+	return &ModuleRegistry_ListDescriptors_Call{Call: _e.mock.On("ListDescriptors", ctx)}
+}
+
+func (_c *ModuleRegistry_ListDescriptors_Call) Run(run func(ctx context.Context)) *ModuleRegistry_ListDescriptors_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ModuleRegistry_ListDescriptors_Call) Return(_a0 []module.Descriptor, _a1 error) *ModuleRegistry_ListDescriptors_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
@@ -27,6 +27,100 @@ func (_m *ModuleService) EXPECT() *ModuleService_Expecter {
 	return &ModuleService_Expecter{mock: &_m.Mock}
 }
 
+// Autoscale provides a mock function with given fields: ctx, res
+func (_m *ModuleService) Autoscale(ctx context.Context, res module.ExpandedResource) (*module.ScaleDecision, error) {
+	ret := _m.Called(ctx, res)
+
+	var r0 *module.ScaleDecision
+	if rf, ok := ret.Get(0).(func(context.Context, module.ExpandedResource) *module.ScaleDecision); ok {
+		r0 = rf(ctx, res)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*module.ScaleDecision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, module.ExpandedResource) error); ok {
+		r1 = rf(ctx, res)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ModuleService_Autoscale_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Autoscale'
+type ModuleService_Autoscale_Call struct {
+	*mock.Call
+}
+
+// Autoscale is a helper method to define mock.On call
+//  - ctx context.Context
+//  - res module.ExpandedResource
+func (_e *ModuleService_Expecter) Autoscale(ctx interface{}, res interface{}) *ModuleService_Autoscale_Call {
+	return &ModuleService_Autoscale_Call{Call: _e.mock.On("Autoscale", ctx, res)}
+}
+
+func (_c *ModuleService_Autoscale_Call) Run(run func(ctx context.Context, res module.ExpandedResource)) *ModuleService_Autoscale_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(module.ExpandedResource))
+	})
+	return _c
+}
+
+func (_c *ModuleService_Autoscale_Call) Return(_a0 *module.ScaleDecision, _a1 error) *ModuleService_Autoscale_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// GetHealth provides a mock function with given fields: ctx, res
+func (_m *ModuleService) GetHealth(ctx context.Context, res module.ExpandedResource) (*resource.Health, error) {
+	ret := _m.Called(ctx, res)
+
+	var r0 *resource.Health
+	if rf, ok := ret.Get(0).(func(context.Context, module.ExpandedResource) *resource.Health); ok {
+		r0 = rf(ctx, res)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*resource.Health)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, module.ExpandedResource) error); ok {
+		r1 = rf(ctx, res)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ModuleService_GetHealth_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHealth'
+type ModuleService_GetHealth_Call struct {
+	*mock.Call
+}
+
+// GetHealth is a helper method to define mock.On call
+//  - ctx context.Context
+//  - res module.ExpandedResource
+func (_e *ModuleService_Expecter) GetHealth(ctx interface{}, res interface{}) *ModuleService_GetHealth_Call {
+	return &ModuleService_GetHealth_Call{Call: _e.mock.On("GetHealth", ctx, res)}
+}
+
+func (_c *ModuleService_GetHealth_Call) Run(run func(ctx context.Context, res module.ExpandedResource)) *ModuleService_GetHealth_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(module.ExpandedResource))
+	})
+	return _c
+}
+
+func (_c *ModuleService_GetHealth_Call) Return(_a0 *resource.Health, _a1 error) *ModuleService_GetHealth_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
 // GetOutput provides a mock function with given fields: ctx, res
 func (_m *ModuleService) GetOutput(ctx context.Context, res module.ExpandedResource) (json.RawMessage, error) {
 	ret := _m.Called(ctx, res)
@@ -74,6 +168,54 @@ func (_c *ModuleService_GetOutput_Call) Return(_a0 json.RawMessage, _a1 error) *
 	return _c
 }
 
+// GetSecrets provides a mock function with given fields: ctx, kind, project
+func (_m *ModuleService) GetSecrets(ctx context.Context, kind string, project string) (*module.Secrets, error) {
+	ret := _m.Called(ctx, kind, project)
+
+	var r0 *module.Secrets
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *module.Secrets); ok {
+		r0 = rf(ctx, kind, project)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*module.Secrets)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, kind, project)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ModuleService_GetSecrets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSecrets'
+type ModuleService_GetSecrets_Call struct {
+	*mock.Call
+}
+
+// GetSecrets is a helper method to define mock.On call
+//  - ctx context.Context
+//  - kind string
+//  - project string
+func (_e *ModuleService_Expecter) GetSecrets(ctx interface{}, kind interface{}, project interface{}) *ModuleService_GetSecrets_Call {
+	return &ModuleService_GetSecrets_Call{Call: _e.mock.On("GetSecrets", ctx, kind, project)}
+}
+
+func (_c *ModuleService_GetSecrets_Call) Run(run func(ctx context.Context, kind string, project string)) *ModuleService_GetSecrets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ModuleService_GetSecrets_Call) Return(_a0 *module.Secrets, _a1 error) *ModuleService_GetSecrets_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
 // PlanAction provides a mock function with given fields: ctx, res, act
 func (_m *ModuleService) PlanAction(ctx context.Context, res module.ExpandedResource, act module.ActionRequest) (*module.Plan, error) {
 	ret := _m.Called(ctx, res, act)
@@ -145,6 +145,101 @@ func (_c *ModuleStore_GetModule_Call) Return(_a0 *module.Module, _a1 error) *Mod
 	return _c
 }
 
+// GetModuleRevision provides a mock function with given fields: ctx, urn, revision
+func (_m *ModuleStore) GetModuleRevision(ctx context.Context, urn string, revision int64) (*module.Revision, error) {
+	ret := _m.Called(ctx, urn, revision)
+
+	var r0 *module.Revision
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) *module.Revision); ok {
+		r0 = rf(ctx, urn, revision)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*module.Revision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, urn, revision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ModuleStore_GetModuleRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetModuleRevision'
+type ModuleStore_GetModuleRevision_Call struct {
+	*mock.Call
+}
+
+// GetModuleRevision is a helper method to define mock.On call
+//  - ctx context.Context
+//  - urn string
+//  - revision int64
+func (_e *ModuleStore_Expecter) GetModuleRevision(ctx interface{}, urn interface{}, revision interface{}) *ModuleStore_GetModuleRevision_Call {
+	return &ModuleStore_GetModuleRevision_Call{Call: _e.mock.On("GetModuleRevision", ctx, urn, revision)}
+}
+
+func (_c *ModuleStore_GetModuleRevision_Call) Run(run func(ctx context.Context, urn string, revision int64)) *ModuleStore_GetModuleRevision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *ModuleStore_GetModuleRevision_Call) Return(_a0 *module.Revision, _a1 error) *ModuleStore_GetModuleRevision_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// ListModuleRevisions provides a mock function with given fields: ctx, urn
+func (_m *ModuleStore) ListModuleRevisions(ctx context.Context, urn string) ([]module.Revision, error) {
+	ret := _m.Called(ctx, urn)
+
+	var r0 []module.Revision
+	if rf, ok := ret.Get(0).(func(context.Context, string) []module.Revision); ok {
+		r0 = rf(ctx, urn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]module.Revision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, urn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ModuleStore_ListModuleRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListModuleRevisions'
+type ModuleStore_ListModuleRevisions_Call struct {
+	*mock.Call
+}
+
+// ListModuleRevisions is a helper method to define mock.On call
+//  - ctx context.Context
+//  - urn string
+func (_e *ModuleStore_Expecter) ListModuleRevisions(ctx interface{}, urn interface{}) *ModuleStore_ListModuleRevisions_Call {
+	return &ModuleStore_ListModuleRevisions_Call{Call: _e.mock.On("ListModuleRevisions", ctx, urn)}
+}
+
+func (_c *ModuleStore_ListModuleRevisions_Call) Run(run func(ctx context.Context, urn string)) *ModuleStore_ListModuleRevisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ModuleStore_ListModuleRevisions_Call) Return(_a0 []module.Revision, _a1 error) *ModuleStore_ListModuleRevisions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
 // ListModules provides a mock function with given fields: ctx, project
 func (_m *ModuleStore) ListModules(ctx context.Context, project string) ([]module.Module, error) {
 	ret := _m.Called(ctx, project)
@@ -192,13 +287,13 @@ func (_c *ModuleStore_ListModules_Call) Return(_a0 []module.Module, _a1 error) *
 	return _c
 }
 
-// UpdateModule provides a mock function with given fields: ctx, m
-func (_m *ModuleStore) UpdateModule(ctx context.Context, m module.Module) error {
-	ret := _m.Called(ctx, m)
+// UpdateModule provides a mock function with given fields: ctx, m, reason
+func (_m *ModuleStore) UpdateModule(ctx context.Context, m module.Module, reason string) error {
+	ret := _m.Called(ctx, m, reason)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, module.Module) error); ok {
-		r0 = rf(ctx, m)
+	if rf, ok := ret.Get(0).(func(context.Context, module.Module, string) error); ok {
+		r0 = rf(ctx, m, reason)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -214,13 +309,14 @@ type ModuleStore_UpdateModule_Call struct {
 // UpdateModule is a helper method to define mock.On call
 //  - ctx context.Context
 //  - m module.Module
-func (_e *ModuleStore_Expecter) UpdateModule(ctx interface{}, m interface{}) *ModuleStore_UpdateModule_Call {
-	return &ModuleStore_UpdateModule_Call{Call: _e.mock.On("UpdateModule", ctx, m)}
+//  - reason string
+func (_e *ModuleStore_Expecter) UpdateModule(ctx interface{}, m interface{}, reason interface{}) *ModuleStore_UpdateModule_Call {
+	return &ModuleStore_UpdateModule_Call{Call: _e.mock.On("UpdateModule", ctx, m, reason)}
 }
 
-func (_c *ModuleStore_UpdateModule_Call) Run(run func(ctx context.Context, m module.Module)) *ModuleStore_UpdateModule_Call {
+func (_c *ModuleStore_UpdateModule_Call) Run(run func(ctx context.Context, m module.Module, reason string)) *ModuleStore_UpdateModule_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(module.Module))
+		run(args[0].(context.Context), args[1].(module.Module), args[2].(string))
 	})
 	return _c
 }
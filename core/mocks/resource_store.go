@@ -75,6 +75,59 @@ func (_c *ResourceStore_Create_Call) Return(_a0 error) *ResourceStore_Create_Cal
 	return _c
 }
 
+// CreateSchedule provides a mock function with given fields: ctx, sa, hooks
+func (_m *ResourceStore) CreateSchedule(ctx context.Context, sa resource.ScheduledAction, hooks ...resource.MutationHook) error {
+	_va := make([]interface{}, len(hooks))
+	for _i := range hooks {
+		_va[_i] = hooks[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, sa)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, resource.ScheduledAction, ...resource.MutationHook) error); ok {
+		r0 = rf(ctx, sa, hooks...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResourceStore_CreateSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSchedule'
+type ResourceStore_CreateSchedule_Call struct {
+	*mock.Call
+}
+
+// CreateSchedule is a helper method to define mock.On call
+//  - ctx context.Context
+//  - sa resource.ScheduledAction
+//  - hooks ...resource.MutationHook
+func (_e *ResourceStore_Expecter) CreateSchedule(ctx interface{}, sa interface{}, hooks ...interface{}) *ResourceStore_CreateSchedule_Call {
+	return &ResourceStore_CreateSchedule_Call{Call: _e.mock.On("CreateSchedule",
+		append([]interface{}{ctx, sa}, hooks...)...)}
+}
+
+func (_c *ResourceStore_CreateSchedule_Call) Run(run func(ctx context.Context, sa resource.ScheduledAction, hooks ...resource.MutationHook)) *ResourceStore_CreateSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]resource.MutationHook, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(resource.MutationHook)
+			}
+		}
+		run(args[0].(context.Context), args[1].(resource.ScheduledAction), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *ResourceStore_CreateSchedule_Call) Return(_a0 error) *ResourceStore_CreateSchedule_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
 // Delete provides a mock function with given fields: ctx, urn, hooks
 func (_m *ResourceStore) Delete(ctx context.Context, urn string, hooks ...resource.MutationHook) error {
 	_va := make([]interface{}, len(hooks))
@@ -175,6 +228,53 @@ func (_c *ResourceStore_GetByURN_Call) Return(_a0 *resource.Resource, _a1 error)
 	return _c
 }
 
+// GetSchedule provides a mock function with given fields: ctx, id
+func (_m *ResourceStore) GetSchedule(ctx context.Context, id string) (*resource.ScheduledAction, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *resource.ScheduledAction
+	if rf, ok := ret.Get(0).(func(context.Context, string) *resource.ScheduledAction); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*resource.ScheduledAction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResourceStore_GetSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSchedule'
+type ResourceStore_GetSchedule_Call struct {
+	*mock.Call
+}
+
+// GetSchedule is a helper method to define mock.On call
+//  - ctx context.Context
+//  - id string
+func (_e *ResourceStore_Expecter) GetSchedule(ctx interface{}, id interface{}) *ResourceStore_GetSchedule_Call {
+	return &ResourceStore_GetSchedule_Call{Call: _e.mock.On("GetSchedule", ctx, id)}
+}
+
+func (_c *ResourceStore_GetSchedule_Call) Run(run func(ctx context.Context, id string)) *ResourceStore_GetSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ResourceStore_GetSchedule_Call) Return(_a0 *resource.ScheduledAction, _a1 error) *ResourceStore_GetSchedule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
 // List provides a mock function with given fields: ctx, filter
 func (_m *ResourceStore) List(ctx context.Context, filter resource.Filter) ([]resource.Resource, error) {
 	ret := _m.Called(ctx, filter)
@@ -222,6 +322,154 @@ func (_c *ResourceStore_List_Call) Return(_a0 []resource.Resource, _a1 error) *R
 	return _c
 }
 
+// ListScheduleRuns provides a mock function with given fields: ctx, scheduleID
+func (_m *ResourceStore) ListScheduleRuns(ctx context.Context, scheduleID string) ([]resource.ScheduleRun, error) {
+	ret := _m.Called(ctx, scheduleID)
+
+	var r0 []resource.ScheduleRun
+	if rf, ok := ret.Get(0).(func(context.Context, string) []resource.ScheduleRun); ok {
+		r0 = rf(ctx, scheduleID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]resource.ScheduleRun)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, scheduleID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResourceStore_ListScheduleRuns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListScheduleRuns'
+type ResourceStore_ListScheduleRuns_Call struct {
+	*mock.Call
+}
+
+// ListScheduleRuns is a helper method to define mock.On call
+//  - ctx context.Context
+//  - scheduleID string
+func (_e *ResourceStore_Expecter) ListScheduleRuns(ctx interface{}, scheduleID interface{}) *ResourceStore_ListScheduleRuns_Call {
+	return &ResourceStore_ListScheduleRuns_Call{Call: _e.mock.On("ListScheduleRuns", ctx, scheduleID)}
+}
+
+func (_c *ResourceStore_ListScheduleRuns_Call) Run(run func(ctx context.Context, scheduleID string)) *ResourceStore_ListScheduleRuns_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ResourceStore_ListScheduleRuns_Call) Return(_a0 []resource.ScheduleRun, _a1 error) *ResourceStore_ListScheduleRuns_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// ListSchedules provides a mock function with given fields: ctx, selector
+func (_m *ResourceStore) ListSchedules(ctx context.Context, selector resource.ScheduleSelector) ([]resource.ScheduledAction, error) {
+	ret := _m.Called(ctx, selector)
+
+	var r0 []resource.ScheduledAction
+	if rf, ok := ret.Get(0).(func(context.Context, resource.ScheduleSelector) []resource.ScheduledAction); ok {
+		r0 = rf(ctx, selector)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]resource.ScheduledAction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, resource.ScheduleSelector) error); ok {
+		r1 = rf(ctx, selector)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResourceStore_ListSchedules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSchedules'
+type ResourceStore_ListSchedules_Call struct {
+	*mock.Call
+}
+
+// ListSchedules is a helper method to define mock.On call
+//  - ctx context.Context
+//  - selector resource.ScheduleSelector
+func (_e *ResourceStore_Expecter) ListSchedules(ctx interface{}, selector interface{}) *ResourceStore_ListSchedules_Call {
+	return &ResourceStore_ListSchedules_Call{Call: _e.mock.On("ListSchedules", ctx, selector)}
+}
+
+func (_c *ResourceStore_ListSchedules_Call) Run(run func(ctx context.Context, selector resource.ScheduleSelector)) *ResourceStore_ListSchedules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(resource.ScheduleSelector))
+	})
+	return _c
+}
+
+func (_c *ResourceStore_ListSchedules_Call) Return(_a0 []resource.ScheduledAction, _a1 error) *ResourceStore_ListSchedules_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// RecordScheduleRun provides a mock function with given fields: ctx, sa, run, hooks
+func (_m *ResourceStore) RecordScheduleRun(ctx context.Context, sa resource.ScheduledAction, run resource.ScheduleRun, hooks ...resource.MutationHook) error {
+	_va := make([]interface{}, len(hooks))
+	for _i := range hooks {
+		_va[_i] = hooks[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, sa, run)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, resource.ScheduledAction, resource.ScheduleRun, ...resource.MutationHook) error); ok {
+		r0 = rf(ctx, sa, run, hooks...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResourceStore_RecordScheduleRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordScheduleRun'
+type ResourceStore_RecordScheduleRun_Call struct {
+	*mock.Call
+}
+
+// RecordScheduleRun is a helper method to define mock.On call
+//  - ctx context.Context
+//  - sa resource.ScheduledAction
+//  - run resource.ScheduleRun
+//  - hooks ...resource.MutationHook
+func (_e *ResourceStore_Expecter) RecordScheduleRun(ctx interface{}, sa interface{}, run interface{}, hooks ...interface{}) *ResourceStore_RecordScheduleRun_Call {
+	return &ResourceStore_RecordScheduleRun_Call{Call: _e.mock.On("RecordScheduleRun",
+		append([]interface{}{ctx, sa, run}, hooks...)...)}
+}
+
+func (_c *ResourceStore_RecordScheduleRun_Call) Run(run func(ctx context.Context, sa resource.ScheduledAction, run resource.ScheduleRun, hooks ...resource.MutationHook)) *ResourceStore_RecordScheduleRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]resource.MutationHook, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(resource.MutationHook)
+			}
+		}
+		run(args[0].(context.Context), args[1].(resource.ScheduledAction), args[2].(resource.ScheduleRun), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *ResourceStore_RecordScheduleRun_Call) Return(_a0 error) *ResourceStore_RecordScheduleRun_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
 // Revisions provides a mock function with given fields: ctx, selector
 func (_m *ResourceStore) Revisions(ctx context.Context, selector resource.RevisionsSelector) ([]resource.Revision, error) {
 	ret := _m.Called(ctx, selector)
@@ -323,3 +571,41 @@ func (_c *ResourceStore_Update_Call) Return(_a0 error) *ResourceStore_Update_Cal
 	_c.Call.Return(_a0)
 	return _c
 }
+
+// UpdateSchedule provides a mock function with given fields: ctx, sa
+func (_m *ResourceStore) UpdateSchedule(ctx context.Context, sa resource.ScheduledAction) error {
+	ret := _m.Called(ctx, sa)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, resource.ScheduledAction) error); ok {
+		r0 = rf(ctx, sa)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResourceStore_UpdateSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSchedule'
+type ResourceStore_UpdateSchedule_Call struct {
+	*mock.Call
+}
+
+// UpdateSchedule is a helper method to define mock.On call
+//  - ctx context.Context
+//  - sa resource.ScheduledAction
+func (_e *ResourceStore_Expecter) UpdateSchedule(ctx interface{}, sa interface{}) *ResourceStore_UpdateSchedule_Call {
+	return &ResourceStore_UpdateSchedule_Call{Call: _e.mock.On("UpdateSchedule", ctx, sa)}
+}
+
+func (_c *ResourceStore_UpdateSchedule_Call) Run(run func(ctx context.Context, sa resource.ScheduledAction)) *ResourceStore_UpdateSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(resource.ScheduledAction))
+	})
+	return _c
+}
+
+func (_c *ResourceStore_UpdateSchedule_Call) Return(_a0 error) *ResourceStore_UpdateSchedule_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
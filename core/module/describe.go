@@ -0,0 +1,46 @@
+package module
+
+import (
+	"encoding/json"
+)
+
+// Description is the user-facing description of a module kind. Unlike the
+// Descriptor, schemas are represented as JSON documents so that clients can
+// use them directly (e.g., to generate forms).
+type Description struct {
+	Kind         string              `json:"kind"`
+	Actions      []ActionDescription `json:"actions"`
+	Dependencies map[string]string   `json:"dependencies,omitempty"`
+	OutputSchema json.RawMessage     `json:"output_schema,omitempty"`
+}
+
+// ActionDescription is the user-facing description of an action.
+type ActionDescription struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	ParamSchema json.RawMessage `json:"param_schema,omitempty"`
+}
+
+// Describe returns the user-facing description of the module kind.
+func (desc Descriptor) Describe() Description {
+	d := Description{
+		Kind:         desc.Kind,
+		Actions:      make([]ActionDescription, 0, len(desc.Actions)),
+		Dependencies: desc.Dependencies,
+	}
+	if desc.OutputSchema != "" {
+		d.OutputSchema = json.RawMessage(desc.OutputSchema)
+	}
+
+	for _, act := range desc.Actions {
+		ad := ActionDescription{
+			Name:        act.Name,
+			Description: act.Description,
+		}
+		if act.ParamSchema != "" {
+			ad.ParamSchema = json.RawMessage(act.ParamSchema)
+		}
+		d.Actions = append(d.Actions, ad)
+	}
+	return d
+}
@@ -23,8 +23,10 @@ type Driver interface {
 	// a terminal status. Driver implementation is free to execute an action
 	// in a single Sync() call or split into steps for better feedback to the
 	// end-user about the progress.
-	// Sync can return state in resource.StatusDeleted to indicate resource
-	// should be removed from the Entropy storage.
+	// For a resource planned into resource.StatusDeleted, Sync should keep
+	// returning resource.StatusDeleted while the deletion is in progress.
+	// Once Sync returns a terminal status, the resource is removed from the
+	// Entropy storage.
 	Sync(ctx context.Context, res ExpandedResource) (*resource.State, error)
 
 	// Output returns the current external state of the resource
@@ -46,11 +48,44 @@ type Loggable interface {
 	Log(ctx context.Context, res ExpandedResource, filter map[string]string) (<-chan LogChunk, error)
 }
 
+// Autoscalable extension of driver allows Entropy to periodically resize a
+// resource based on its load.
+type Autoscalable interface {
+	Driver
+
+	// Autoscale SHOULD return the action to be applied on the resource to
+	// keep its load within the target, or nil if no change is needed.
+	// Autoscale SHOULD NOT have side effects.
+	Autoscale(ctx context.Context, res ExpandedResource) (*ScaleDecision, error)
+}
+
+// Checkable extension of driver allows Entropy to report the health of the
+// external system managed by a resource.
+type Checkable interface {
+	Driver
+
+	// Health SHOULD evaluate the current health of the resource, and
+	// return resource.HealthUnknown if it cannot be determined.
+	// Health SHOULD NOT have side effects.
+	Health(ctx context.Context, res ExpandedResource) (*resource.Health, error)
+}
+
+// ScaleDecision is an action decided by an autoscaler. Reason is recorded
+// with the revision created by the action.
+type ScaleDecision struct {
+	Action ActionRequest
+	Reason string
+}
+
 // ExpandedResource represents the context for Plan() or Sync() invocations.
 type ExpandedResource struct {
 	resource.Resource `json:"resource"`
 
 	Dependencies map[string]ResolvedDependency `json:"dependencies"`
+
+	// Secrets contains the values of the secrets referenced in the configs
+	// of the resource, by secret name. This is set only for Sync().
+	Secrets map[string]string `json:"-"`
 }
 
 type ResolvedDependency struct {
@@ -2,6 +2,7 @@ package module
 
 //go:generate mockery --name=Store -r --case underscore --with-expecter --structname ModuleStore --filename=module_store.go --output=../mocks
 //go:generate mockery --name=Registry -r --case underscore --with-expecter --structname ModuleRegistry --filename=module_registry.go --output=../mocks
+//go:generate mockery --name=SecretResolver -r --case underscore --with-expecter --structname SecretResolver --filename=secret_resolver.go --output=../mocks
 
 import (
 	"context"
@@ -18,16 +19,28 @@ type Module struct {
 	Name      string          `json:"name"`
 	Project   string          `json:"project"`
 	Configs   json.RawMessage `json:"configs"`
+	Revision  int64           `json:"revision"`
 	CreatedAt time.Time       `json:"created_at"`
 	UpdatedAt time.Time       `json:"updated_at"`
 }
 
+// Revision represents a version of the configs of a module. A new revision
+// is created for every change to the configs, including rollbacks.
+type Revision struct {
+	URN       string          `json:"urn"`
+	Revision  int64           `json:"revision"`
+	Reason    string          `json:"reason"`
+	Configs   json.RawMessage `json:"configs"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
 // Descriptor is a module descriptor that represents supported actions, resource-kind
 // the module can operate on, etc.
 type Descriptor struct {
 	Kind          string                                     `json:"kind"`
 	Actions       []ActionDesc                               `json:"actions"`
 	Dependencies  map[string]string                          `json:"dependencies"`
+	OutputSchema  string                                     `json:"output_schema"`
 	DriverFactory func(conf json.RawMessage) (Driver, error) `json:"-"`
 }
 
@@ -35,6 +48,8 @@ type Descriptor struct {
 // module definitions provided.
 type Registry interface {
 	GetDriver(ctx context.Context, mod Module) (Driver, Descriptor, error)
+	GetDescriptor(ctx context.Context, kind string) (*Descriptor, error)
+	ListDescriptors(ctx context.Context) ([]Descriptor, error)
 }
 
 // Store is responsible for persisting modules defined for each project.
@@ -42,8 +57,17 @@ type Store interface {
 	GetModule(ctx context.Context, urn string) (*Module, error)
 	ListModules(ctx context.Context, project string) ([]Module, error)
 	CreateModule(ctx context.Context, m Module) error
-	UpdateModule(ctx context.Context, m Module) error
+	UpdateModule(ctx context.Context, m Module, reason string) error
 	DeleteModule(ctx context.Context, urn string) error
+
+	GetModuleRevision(ctx context.Context, urn string, revision int64) (*Revision, error)
+	ListModuleRevisions(ctx context.Context, urn string) ([]Revision, error)
+}
+
+// SecretResolver is responsible for resolving the secret references in
+// the resource configs to the secret values.
+type SecretResolver interface {
+	Resolve(ctx context.Context, project string, configs json.RawMessage) (map[string]string, error)
 }
 
 func (mod *Module) sanitise(isCreate bool) error {
@@ -57,6 +81,7 @@ func (mod *Module) sanitise(isCreate bool) error {
 
 	if isCreate {
 		mod.URN = generateURN(mod.Name, mod.Project)
+		mod.Revision = 1
 		mod.CreatedAt = time.Now()
 		mod.UpdatedAt = mod.CreatedAt
 	}
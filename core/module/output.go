@@ -0,0 +1,27 @@
+package module
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// LiveOutputTimeout bounds the time spent on observing the live state of a
+// resource for its output.
+const LiveOutputTimeout = 3 * time.Second
+
+// LiveOutput is the policy followed by drivers whose Output reads the live
+// state of the external system. Output is called on every get of a resource,
+// so observe is given LiveOutputTimeout, and the output stored by the last
+// sync is returned if observe fails. An unreachable (or slow) cluster thus
+// does not prevent reading or deleting the resource.
+func LiveOutput(ctx context.Context, res ExpandedResource, observe func(ctx context.Context) (json.RawMessage, error)) json.RawMessage {
+	ctx, cancel := context.WithTimeout(ctx, LiveOutputTimeout)
+	defer cancel()
+
+	out, err := observe(ctx)
+	if err != nil {
+		return res.Resource.State.Output
+	}
+	return out
+}
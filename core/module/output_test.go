@@ -0,0 +1,55 @@
+package module_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+func TestLiveOutput(t *testing.T) {
+	t.Parallel()
+
+	var res module.ExpandedResource
+	res.Resource = resource.Resource{
+		State: resource.State{Output: json.RawMessage(`{"stored":true}`)},
+	}
+
+	table := []struct {
+		title   string
+		observe func(ctx context.Context) (json.RawMessage, error)
+		want    string
+	}{
+		{
+			title: "Observed",
+			observe: func(ctx context.Context) (json.RawMessage, error) {
+				_, hasDeadline := ctx.Deadline()
+				assert.True(t, hasDeadline)
+				return json.RawMessage(`{"stored":false}`), nil
+			},
+			want: `{"stored":false}`,
+		},
+		{
+			title: "ObserveFailed",
+			observe: func(ctx context.Context) (json.RawMessage, error) {
+				return nil, errors.ErrInternal
+			},
+			want: `{"stored":true}`,
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			got := module.LiveOutput(context.Background(), res, tt.observe)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
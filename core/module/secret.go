@@ -0,0 +1,245 @@
+package module
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/odpf/entropy/core/secret"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+const (
+	// secretKeyword is the JSON-schema keyword used by modules to mark the
+	// properties holding sensitive values. For example:
+	// 	{"type": "string", "secret": true}
+	secretKeyword = "secret"
+
+	pathSeparator = "."
+	pathWildcard  = "*"
+
+	// RedactedValue replaces the values of secret fields when rendering.
+	RedactedValue = "********"
+)
+
+// Secrets lists the paths of fields holding sensitive values in the configs
+// and outputs of resources of a kind. Each path is a dot-separated list of
+// object keys, where "*" matches every element of an array.
+type Secrets struct {
+	Configs []string `json:"configs"`
+	Output  []string `json:"output"`
+}
+
+// Secrets returns the secret fields declared by the create/update action
+// param-schemas (i.e., the config schemas) and the output schema.
+func (desc Descriptor) Secrets() (*Secrets, error) {
+	var configSchemas []string
+	for _, act := range desc.Actions {
+		if act.Name == CreateAction || act.Name == UpdateAction {
+			configSchemas = append(configSchemas, act.ParamSchema)
+		}
+	}
+
+	configPaths, err := secretPaths(configSchemas...)
+	if err != nil {
+		return nil, err
+	}
+
+	outputPaths, err := secretPaths(desc.OutputSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Secrets{
+		Configs: configPaths,
+		Output:  outputPaths,
+	}, nil
+}
+
+// Redact returns a copy of the given JSON document with the values at all
+// the given paths replaced by RedactedValue. Paths that do not exist in the
+// document are ignored.
+func Redact(doc json.RawMessage, paths []string) (json.RawMessage, error) {
+	if len(doc) == 0 || len(paths) == 0 {
+		return doc, nil
+	}
+
+	v, err := decodeJSON(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range paths {
+		v = redactPath(v, strings.Split(path, pathSeparator))
+	}
+	return json.Marshal(v)
+}
+
+func redactPath(v interface{}, path []string) interface{} {
+	if len(path) == 0 {
+		if _, isRef := secret.AsRef(v); isRef || v == nil || v == "" {
+			// secret references do not hold the value itself.
+			return v
+		}
+		return RedactedValue
+	}
+
+	switch val := v.(type) {
+	case map[string]interface{}:
+		if child, found := val[path[0]]; found {
+			val[path[0]] = redactPath(child, path[1:])
+		}
+
+	case []interface{}:
+		if path[0] == pathWildcard {
+			for i := range val {
+				val[i] = redactPath(val[i], path[1:])
+			}
+		}
+	}
+	return v
+}
+
+// Unredact returns a copy of the given JSON document where the values at
+// the given paths that are RedactedValue are replaced by the values at the
+// same paths in prev. This allows configs read with secrets masked to be
+// sent back as they are. Redacted values with nothing in prev to restore
+// from are rejected.
+func Unredact(doc, prev json.RawMessage, paths []string) (json.RawMessage, error) {
+	if len(doc) == 0 || len(paths) == 0 {
+		return doc, nil
+	}
+
+	v, err := decodeJSON(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var prevVal interface{}
+	if len(prev) > 0 {
+		if prevVal, err = decodeJSON(prev); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, path := range paths {
+		if v, err = unredactPath(v, prevVal, strings.Split(path, pathSeparator), path); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(v)
+}
+
+func unredactPath(v, prev interface{}, path []string, fullPath string) (interface{}, error) {
+	if len(path) == 0 {
+		if v != RedactedValue {
+			return v, nil
+		} else if prev == nil || prev == RedactedValue {
+			return nil, errors.ErrInvalid.WithMsgf("value of '%s' is redacted, and has no stored value to keep", fullPath)
+		}
+		return prev, nil
+	}
+
+	switch val := v.(type) {
+	case map[string]interface{}:
+		if child, found := val[path[0]]; found {
+			prevMap, _ := prev.(map[string]interface{})
+			restored, err := unredactPath(child, prevMap[path[0]], path[1:], fullPath)
+			if err != nil {
+				return nil, err
+			}
+			val[path[0]] = restored
+		}
+
+	case []interface{}:
+		if path[0] == pathWildcard {
+			prevList, _ := prev.([]interface{})
+			for i := range val {
+				var prevItem interface{}
+				if i < len(prevList) {
+					prevItem = prevList[i]
+				}
+
+				restored, err := unredactPath(val[i], prevItem, path[1:], fullPath)
+				if err != nil {
+					return nil, err
+				}
+				val[i] = restored
+			}
+		}
+	}
+	return v, nil
+}
+
+func decodeJSON(doc json.RawMessage) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func secretPaths(schemas ...string) ([]string, error) {
+	found := map[string]bool{}
+	for _, schema := range schemas {
+		if schema == "" {
+			continue
+		}
+
+		var root map[string]interface{}
+		if err := json.Unmarshal([]byte(schema), &root); err != nil {
+			return nil, err
+		}
+		collectSecretPaths(root, nil, found)
+	}
+
+	var paths []string
+	for path := range found {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+func collectSecretPaths(node map[string]interface{}, path []string, into map[string]bool) {
+	if isSecret, _ := node[secretKeyword].(bool); isSecret && len(path) > 0 {
+		into[strings.Join(path, pathSeparator)] = true
+		return
+	}
+
+	withKey := func(key string) []string {
+		return append(append([]string(nil), path...), key)
+	}
+
+	if props, ok := node["properties"].(map[string]interface{}); ok {
+		for key, sub := range props {
+			if subNode, ok := sub.(map[string]interface{}); ok {
+				collectSecretPaths(subNode, withKey(key), into)
+			}
+		}
+	}
+
+	if items, ok := node["items"].(map[string]interface{}); ok {
+		collectSecretPaths(items, withKey(pathWildcard), into)
+	}
+
+	// sub-schemas that apply to the same instance location.
+	for _, key := range []string{"if", "then", "else"} {
+		if subNode, ok := node[key].(map[string]interface{}); ok {
+			collectSecretPaths(subNode, path, into)
+		}
+	}
+
+	for _, key := range []string{"allOf", "anyOf", "oneOf"} {
+		subs, _ := node[key].([]interface{})
+		for _, sub := range subs {
+			if subNode, ok := sub.(map[string]interface{}); ok {
+				collectSecretPaths(subNode, path, into)
+			}
+		}
+	}
+}
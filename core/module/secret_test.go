@@ -0,0 +1,157 @@
+package module_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+func TestDescriptor_Secrets(t *testing.T) {
+	t.Parallel()
+
+	configSchema := `{
+		"type": "object",
+		"properties": {
+			"host": {"type": "string"},
+			"token": {"type": "string", "secret": true},
+			"users": {
+				"type": "array",
+				"items": {
+					"type": "object",
+					"properties": {"password": {"type": "string", "secret": true}}
+				}
+			},
+			"env": {
+				"type": "object",
+				"allOf": [
+					{"then": {"properties": {"CLIENT_SECRET": {"type": "string", "secret": true}}}}
+				]
+			}
+		}
+	}`
+
+	desc := module.Descriptor{
+		Kind: "foo",
+		Actions: []module.ActionDesc{
+			{Name: module.CreateAction, ParamSchema: configSchema},
+			{Name: module.UpdateAction, ParamSchema: configSchema},
+			{Name: "reset", ParamSchema: `{"properties": {"key": {"secret": true}}}`},
+		},
+		OutputSchema: `{"properties": {"conf": {"properties": {"key": {"secret": true}}}}}`,
+	}
+
+	got, err := desc.Secrets()
+	require.NoError(t, err)
+	assert.Equal(t, &module.Secrets{
+		Configs: []string{"env.CLIENT_SECRET", "token", "users.*.password"},
+		Output:  []string{"conf.key"},
+	}, got)
+}
+
+func TestRedact(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		title string
+		doc   string
+		paths []string
+		want  string
+	}{
+		{
+			title: "NoPaths",
+			doc:   `{"token": "foo"}`,
+			paths: nil,
+			want:  `{"token": "foo"}`,
+		},
+		{
+			title: "NestedAndArrayPaths",
+			doc:   `{"token":"foo","count":10,"users":[{"name":"a","password":"x"},{"name":"b"}]}`,
+			paths: []string{"token", "users.*.password", "missing.path"},
+			want:  `{"count":10,"token":"********","users":[{"name":"a","password":"********"},{"name":"b"}]}`,
+		},
+		{
+			title: "SecretRefsUntouched",
+			doc:   `{"env":{"TOKEN":{"secret_ref":"my-token"},"KEY":"raw"}}`,
+			paths: []string{"env.TOKEN", "env.KEY"},
+			want:  `{"env":{"KEY":"********","TOKEN":{"secret_ref":"my-token"}}}`,
+		},
+		{
+			title: "EmptyValuesUntouched",
+			doc:   `{"token":"","key":null}`,
+			paths: []string{"token", "key"},
+			want:  `{"key":null,"token":""}`,
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := module.Redact([]byte(tt.doc), tt.paths)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestUnredact(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		title   string
+		doc     string
+		prev    string
+		paths   []string
+		want    string
+		wantErr error
+	}{
+		{
+			title: "RedactedKept",
+			doc:   `{"token":"********","count":11,"users":[{"name":"a","password":"********"},{"name":"b","password":"new"}]}`,
+			prev:  `{"token":"foo","count":10,"users":[{"name":"a","password":"x"},{"name":"b","password":"y"}]}`,
+			paths: []string{"token", "users.*.password"},
+			want:  `{"token":"foo","count":11,"users":[{"name":"a","password":"x"},{"name":"b","password":"new"}]}`,
+		},
+		{
+			title: "ChangedValues",
+			doc:   `{"token":"bar","env":{"TOKEN":{"secret_ref":"my-token"}}}`,
+			prev:  `{"token":"foo","env":{"TOKEN":"raw"}}`,
+			paths: []string{"token", "env.TOKEN"},
+			want:  `{"token":"bar","env":{"TOKEN":{"secret_ref":"my-token"}}}`,
+		},
+		{
+			title: "NotSecretPath",
+			doc:   `{"name":"********"}`,
+			prev:  `{"name":"foo"}`,
+			paths: []string{"token"},
+			want:  `{"name":"********"}`,
+		},
+		{
+			title:   "NothingStored",
+			doc:     `{"users":[{"password":"x"},{"password":"********"}]}`,
+			prev:    `{"users":[{"password":"x"}]}`,
+			paths:   []string{"users.*.password"},
+			wantErr: errors.ErrInvalid,
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := module.Unredact([]byte(tt.doc), []byte(tt.prev), tt.paths)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.JSONEq(t, tt.want, string(got))
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/odpf/entropy/core/resource"
 	"github.com/odpf/entropy/pkg/errors"
@@ -12,12 +13,14 @@ import (
 type Service struct {
 	store    Store
 	registry Registry
+	secrets  SecretResolver
 }
 
-func NewService(registry Registry, store Store) *Service {
+func NewService(registry Registry, store Store, secrets SecretResolver) *Service {
 	return &Service{
 		store:    store,
 		registry: registry,
+		secrets:  secrets,
 	}
 }
 
@@ -36,7 +39,19 @@ func (mr *Service) PlanAction(ctx context.Context, res ExpandedResource, act Act
 		return nil, err
 	}
 
-	return driver.Plan(ctx, res, act)
+	// only ensure the referenced secrets exist. secret values must never
+	// end up in the planned resource.
+	if _, err := mr.secrets.Resolve(ctx, res.Project, act.Params); err != nil {
+		return nil, err
+	}
+
+	plan, err := driver.Plan(ctx, res, act)
+	if err != nil {
+		return nil, err
+	}
+	// module revision is updated only by a sync.
+	plan.Resource.State.ModuleRevision = res.State.ModuleRevision
+	return plan, nil
 }
 
 func (mr *Service) SyncState(ctx context.Context, res ExpandedResource) (*resource.State, error) {
@@ -52,7 +67,17 @@ func (mr *Service) SyncState(ctx context.Context, res ExpandedResource) (*resour
 		return nil, err
 	}
 
-	return driver.Sync(ctx, res)
+	res.Secrets, err = mr.secrets.Resolve(ctx, res.Project, res.Spec.Configs)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := driver.Sync(ctx, res)
+	if err != nil {
+		return nil, err
+	}
+	state.ModuleRevision = mod.Revision
+	return state, nil
 }
 
 func (mr *Service) StreamLogs(ctx context.Context, res ExpandedResource, filter map[string]string) (<-chan LogChunk, error) {
@@ -74,6 +99,51 @@ func (mr *Service) StreamLogs(ctx context.Context, res ExpandedResource, filter
 	return lg.Log(ctx, res, filter)
 }
 
+// Autoscale returns the scaling action decided by the module for the
+// resource, if any. ErrUnsupported is returned if the module does not
+// support autoscaling.
+func (mr *Service) Autoscale(ctx context.Context, res ExpandedResource) (*ScaleDecision, error) {
+	mod, err := mr.discoverModule(ctx, res.Kind, res.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	driver, desc, err := mr.initDriver(ctx, *mod)
+	if err != nil {
+		return nil, err
+	} else if err := desc.validateDependencies(res.Dependencies); err != nil {
+		return nil, err
+	}
+
+	as, supported := driver.(Autoscalable)
+	if !supported {
+		return nil, errors.ErrUnsupported.WithMsgf("autoscaling not supported for kind '%s'", res.Kind)
+	}
+
+	return as.Autoscale(ctx, res)
+}
+
+// GetHealth returns the health of the resource as evaluated by the module.
+// ErrUnsupported is returned if the module does not report health.
+func (mr *Service) GetHealth(ctx context.Context, res ExpandedResource) (*resource.Health, error) {
+	mod, err := mr.discoverModule(ctx, res.Kind, res.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	driver, _, err := mr.initDriver(ctx, *mod)
+	if err != nil {
+		return nil, err
+	}
+
+	hc, supported := driver.(Checkable)
+	if !supported {
+		return nil, errors.ErrUnsupported.WithMsgf("health not supported for kind '%s'", res.Kind)
+	}
+
+	return hc.Health(ctx, res)
+}
+
 func (mr *Service) GetOutput(ctx context.Context, res ExpandedResource) (json.RawMessage, error) {
 	mod, err := mr.discoverModule(ctx, res.Kind, res.Project)
 	if err != nil {
@@ -88,6 +158,53 @@ func (mr *Service) GetOutput(ctx context.Context, res ExpandedResource) (json.Ra
 	return driver.Output(ctx, res)
 }
 
+func (mr *Service) GetSecrets(ctx context.Context, kind, project string) (*Secrets, error) {
+	mod, err := mr.discoverModule(ctx, kind, project)
+	if err != nil {
+		return nil, err
+	}
+
+	_, desc, err := mr.initDriver(ctx, *mod)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets, err := desc.Secrets()
+	if err != nil {
+		return nil, errors.ErrInternal.WithMsgf("failed to read secret fields of kind '%s'", kind).WithCausef(err.Error())
+	}
+	return secrets, nil
+}
+
+// ListModuleKinds returns the descriptions of all the module kinds supported.
+func (mr *Service) ListModuleKinds(ctx context.Context) ([]Description, error) {
+	descs, err := mr.registry.ListDescriptors(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]Description, 0, len(descs))
+	for _, desc := range descs {
+		res = append(res, desc.Describe())
+	}
+	return res, nil
+}
+
+// GetModuleDescriptor returns the description of the given module kind,
+// including the schemas for the params of each action.
+func (mr *Service) GetModuleDescriptor(ctx context.Context, kind string) (*Description, error) {
+	desc, err := mr.registry.GetDescriptor(ctx, kind)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return nil, errors.ErrNotFound.WithMsgf("module kind '%s' is not supported", kind)
+		}
+		return nil, err
+	}
+
+	d := desc.Describe()
+	return &d, nil
+}
+
 func (mr *Service) GetModule(ctx context.Context, urn string) (*Module, error) {
 	return mr.store.GetModule(ctx, urn)
 }
@@ -101,14 +218,7 @@ func (mr *Service) CreateModule(ctx context.Context, mod Module) (*Module, error
 		return nil, err
 	}
 
-	if _, _, err := mr.registry.GetDriver(ctx, mod); err != nil {
-		if errors.Is(err, errors.ErrNotFound) {
-			return nil, errors.ErrInvalid.WithMsgf("driver not found for kind '%s'", mod.Name)
-		} else if errors.Is(err, errors.ErrInvalid) {
-			return nil, errors.ErrInvalid.
-				WithMsgf("failed to init driver with given configs").
-				WithCausef(err.Error())
-		}
+	if _, _, err := mr.initDriver(ctx, mod); err != nil {
 		return nil, err
 	}
 
@@ -124,6 +234,31 @@ func (mr *Service) CreateModule(ctx context.Context, mod Module) (*Module, error
 }
 
 func (mr *Service) UpdateModule(ctx context.Context, urn string, newConfigs json.RawMessage) (*Module, error) {
+	return mr.updateConfigs(ctx, urn, newConfigs, "update")
+}
+
+// RollbackModule restores the configs of the module to those of the given
+// revision. Rollback creates a new revision.
+func (mr *Service) RollbackModule(ctx context.Context, urn string, revision int64) (*Module, error) {
+	rev, err := mr.store.GetModuleRevision(ctx, urn, revision)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return nil, errors.ErrNotFound.WithMsgf("revision %d of module '%s' not found", revision, urn)
+		}
+		return nil, err
+	}
+
+	return mr.updateConfigs(ctx, urn, rev.Configs, fmt.Sprintf("rollback to revision %d", revision))
+}
+
+func (mr *Service) ListModuleRevisions(ctx context.Context, urn string) ([]Revision, error) {
+	if _, err := mr.store.GetModule(ctx, urn); err != nil {
+		return nil, err
+	}
+	return mr.store.ListModuleRevisions(ctx, urn)
+}
+
+func (mr *Service) updateConfigs(ctx context.Context, urn string, newConfigs json.RawMessage, reason string) (*Module, error) {
 	mod, err := mr.store.GetModule(ctx, urn)
 	if err != nil {
 		return nil, err
@@ -134,7 +269,20 @@ func (mr *Service) UpdateModule(ctx context.Context, urn string, newConfigs json
 		return nil, err
 	}
 
-	if err := mr.store.UpdateModule(ctx, *mod); err != nil {
+	// validate the configs the same way as create, so that a bad config
+	// does not break all the resources of this kind at the next sync.
+	if _, _, err := mr.initDriver(ctx, *mod); err != nil {
+		return nil, err
+	}
+
+	mod.Revision++
+	mod.UpdatedAt = time.Now()
+	if err := mr.store.UpdateModule(ctx, *mod, reason); err != nil {
+		if errors.Is(err, errors.ErrConflict) {
+			return nil, errors.ErrConflict.
+				WithMsgf("module was updated concurrently, retry the update").
+				WithCausef(err.Error())
+		}
 		return nil, err
 	}
 	return mod, nil
@@ -1 +1,172 @@
 package module_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/odpf/entropy/core/mocks"
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+func TestService_UpdateModule(t *testing.T) {
+	t.Parallel()
+
+	sampleMod := module.Module{
+		URN:      "orn:entropy:module:project:firehose",
+		Name:     "firehose",
+		Project:  "project",
+		Configs:  json.RawMessage(`{"replicas": 1}`),
+		Revision: 3,
+	}
+
+	tests := []struct {
+		name    string
+		setup   func(t *testing.T) *module.Service
+		configs json.RawMessage
+		want    *module.Module
+		wantErr error
+	}{
+		{
+			name: "ModuleNotFound",
+			setup: func(t *testing.T) *module.Service {
+				t.Helper()
+				store := &mocks.ModuleStore{}
+				store.EXPECT().
+					GetModule(mock.Anything, sampleMod.URN).
+					Return(nil, errors.ErrNotFound).Once()
+				return module.NewService(&mocks.ModuleRegistry{}, store, &mocks.SecretResolver{})
+			},
+			configs: json.RawMessage(`{}`),
+			wantErr: errors.ErrNotFound,
+		},
+		{
+			name: "InvalidConfigs",
+			setup: func(t *testing.T) *module.Service {
+				t.Helper()
+				mod := sampleMod
+				store := &mocks.ModuleStore{}
+				store.EXPECT().
+					GetModule(mock.Anything, sampleMod.URN).
+					Return(&mod, nil).Once()
+
+				registry := &mocks.ModuleRegistry{}
+				registry.EXPECT().
+					GetDriver(mock.Anything, mock.Anything).
+					Return(nil, module.Descriptor{}, errors.ErrInvalid).Once()
+				return module.NewService(registry, store, &mocks.SecretResolver{})
+			},
+			configs: json.RawMessage(`{"replicas": "foo"}`),
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			name: "Success",
+			setup: func(t *testing.T) *module.Service {
+				t.Helper()
+				mod := sampleMod
+				store := &mocks.ModuleStore{}
+				store.EXPECT().
+					GetModule(mock.Anything, sampleMod.URN).
+					Return(&mod, nil).Once()
+				store.EXPECT().
+					UpdateModule(mock.Anything, mock.Anything, "update").
+					Run(func(ctx context.Context, m module.Module, reason string) {
+						assert.Equal(t, int64(4), m.Revision)
+					}).
+					Return(nil).Once()
+
+				registry := &mocks.ModuleRegistry{}
+				registry.EXPECT().
+					GetDriver(mock.Anything, mock.Anything).
+					Return(&mocks.ModuleDriver{}, module.Descriptor{}, nil).Once()
+				return module.NewService(registry, store, &mocks.SecretResolver{})
+			},
+			configs: json.RawMessage(`{"replicas": 2}`),
+			want: &module.Module{
+				URN:      sampleMod.URN,
+				Name:     sampleMod.Name,
+				Project:  sampleMod.Project,
+				Configs:  json.RawMessage(`{"replicas": 2}`),
+				Revision: 4,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			svc := tt.setup(t)
+
+			got, err := svc.UpdateModule(context.Background(), sampleMod.URN, tt.configs)
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				assert.Truef(t, errors.Is(err, tt.wantErr), "'%s' != '%s'", tt.wantErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			got.UpdatedAt = tt.want.UpdatedAt
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_RollbackModule(t *testing.T) {
+	t.Parallel()
+
+	sampleMod := module.Module{
+		URN:      "orn:entropy:module:project:firehose",
+		Name:     "firehose",
+		Project:  "project",
+		Configs:  json.RawMessage(`{"replicas": 2}`),
+		Revision: 2,
+	}
+
+	t.Run("RevisionNotFound", func(t *testing.T) {
+		t.Parallel()
+
+		store := &mocks.ModuleStore{}
+		store.EXPECT().
+			GetModuleRevision(mock.Anything, sampleMod.URN, int64(5)).
+			Return(nil, errors.ErrNotFound).Once()
+		svc := module.NewService(&mocks.ModuleRegistry{}, store, &mocks.SecretResolver{})
+
+		_, err := svc.RollbackModule(context.Background(), sampleMod.URN, 5)
+		assert.True(t, errors.Is(err, errors.ErrNotFound))
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		mod := sampleMod
+		store := &mocks.ModuleStore{}
+		store.EXPECT().
+			GetModuleRevision(mock.Anything, sampleMod.URN, int64(1)).
+			Return(&module.Revision{
+				URN:      sampleMod.URN,
+				Revision: 1,
+				Configs:  json.RawMessage(`{"replicas": 1}`),
+			}, nil).Once()
+		store.EXPECT().
+			GetModule(mock.Anything, sampleMod.URN).
+			Return(&mod, nil).Once()
+		store.EXPECT().
+			UpdateModule(mock.Anything, mock.Anything, "rollback to revision 1").
+			Return(nil).Once()
+
+		registry := &mocks.ModuleRegistry{}
+		registry.EXPECT().
+			GetDriver(mock.Anything, mock.Anything).
+			Return(&mocks.ModuleDriver{}, module.Descriptor{}, nil).Once()
+		svc := module.NewService(registry, store, &mocks.SecretResolver{})
+
+		got, err := svc.RollbackModule(context.Background(), sampleMod.URN, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), got.Revision)
+		assert.JSONEq(t, `{"replicas": 1}`, string(got.Configs))
+	})
+}
@@ -2,12 +2,21 @@ package core
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/odpf/entropy/core/module"
 	"github.com/odpf/entropy/core/resource"
 	"github.com/odpf/entropy/pkg/errors"
 )
 
+const (
+	// healthCheckTimeout & healthCheckConcurrency bound the health checks
+	// made by ListResources to filter resources by health.
+	healthCheckTimeout     = 5 * time.Second
+	healthCheckConcurrency = 10
+)
+
 func (s *Service) GetResource(ctx context.Context, urn string) (*resource.Resource, error) {
 	res, err := s.store.GetByURN(ctx, urn)
 	if err != nil {
@@ -37,7 +46,64 @@ func (s *Service) ListResources(ctx context.Context, filter resource.Filter) ([]
 	if err != nil {
 		return nil, errors.ErrInternal.WithCausef(err.Error())
 	}
-	return filter.Apply(resources), nil
+	resources = filter.Apply(resources)
+
+	if filter.Health == "" {
+		return resources, nil
+	}
+
+	healths := s.healths(ctx, resources)
+
+	var res []resource.Resource
+	for i, r := range resources {
+		if healths[i].Status == filter.Health {
+			res = append(res, r)
+		}
+	}
+	return res, nil
+}
+
+// healths evaluates the health of the resources, running at most
+// healthCheckConcurrency checks at a time.
+func (s *Service) healths(ctx context.Context, resources []resource.Resource) []resource.Health {
+	healths := make([]resource.Health, len(resources))
+	sem := make(chan struct{}, healthCheckConcurrency)
+
+	wg := &sync.WaitGroup{}
+	for i, r := range resources {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, r resource.Resource) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			healths[i] = s.health(ctx, r)
+		}(i, r)
+	}
+	wg.Wait()
+	return healths
+}
+
+// health returns the health of the resource as evaluated by its module. The
+// health is unknown if the module does not report health, or if it cannot
+// be evaluated within healthCheckTimeout.
+func (s *Service) health(ctx context.Context, res resource.Resource) resource.Health {
+	unknown := resource.Health{Status: resource.HealthUnknown}
+
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	modSpec, err := s.generateModuleSpec(ctx, res)
+	if err != nil {
+		return unknown
+	}
+
+	h, err := s.moduleSvc.GetHealth(ctx, *modSpec)
+	if err != nil || h == nil {
+		return unknown
+	}
+	return *h
 }
 
 func (s *Service) GetLog(ctx context.Context, urn string, filter map[string]string) (<-chan module.LogChunk, error) {
@@ -61,6 +127,12 @@ func (s *Service) GetLog(ctx context.Context, urn string, filter map[string]stri
 	return logCh, nil
 }
 
+// GetSecrets returns the fields holding sensitive values in the configs and
+// outputs of resources of the given kind.
+func (s *Service) GetSecrets(ctx context.Context, kind, project string) (*module.Secrets, error) {
+	return s.moduleSvc.GetSecrets(ctx, kind, project)
+}
+
 func (s *Service) GetRevisions(ctx context.Context, selector resource.RevisionsSelector) ([]resource.Revision, error) {
 	revs, err := s.store.Revisions(ctx, selector)
 	if err != nil {
@@ -2,13 +2,16 @@ package core_test
 
 import (
 	"context"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
 	"github.com/odpf/entropy/core"
 	"github.com/odpf/entropy/core/mocks"
+	"github.com/odpf/entropy/core/module"
 	"github.com/odpf/entropy/core/resource"
 	"github.com/odpf/entropy/pkg/errors"
 )
@@ -83,6 +86,9 @@ func TestService_ListResources(t *testing.T) {
 
 	errStoreFailure := errors.New("some store error")
 
+	degradedResource := resource.Resource{URN: "foo:bar:degraded", Kind: "foo", Name: "degraded", Project: "bar"}
+	unsupportedResource := resource.Resource{URN: "foo:bar:unsupported", Kind: "foo", Name: "unsupported", Project: "bar"}
+
 	tests := []struct {
 		name    string
 		setup   func(t *testing.T) *core.Service
@@ -132,6 +138,84 @@ func TestService_ListResources(t *testing.T) {
 			want:    []resource.Resource{sampleResource},
 			wantErr: nil,
 		},
+		{
+			name: "FilterByHealth",
+			setup: func(t *testing.T) *core.Service {
+				t.Helper()
+				repo := &mocks.ResourceStore{}
+				repo.EXPECT().
+					List(mock.Anything, mock.Anything).
+					Return([]resource.Resource{sampleResource, degradedResource, unsupportedResource}, nil).
+					Once()
+
+				withURN := func(urn string) interface{} {
+					return mock.MatchedBy(func(res module.ExpandedResource) bool { return res.URN == urn })
+				}
+
+				// every check gets a deadline of its own.
+				withDeadline := mock.MatchedBy(func(ctx context.Context) bool {
+					_, ok := ctx.Deadline()
+					return ok
+				})
+
+				mod := &mocks.ModuleService{}
+				mod.EXPECT().
+					GetHealth(withDeadline, withURN(sampleResource.URN)).
+					Return(&resource.Health{Status: resource.HealthHealthy}, nil).
+					Once()
+				mod.EXPECT().
+					GetHealth(withDeadline, withURN(degradedResource.URN)).
+					Return(&resource.Health{Status: resource.HealthDegraded, Reasons: []string{"pod is crash-looping"}}, nil).
+					Once()
+				mod.EXPECT().
+					GetHealth(withDeadline, withURN(unsupportedResource.URN)).
+					Return(nil, errors.ErrUnsupported).
+					Once()
+				return core.New(repo, mod, &mocks.AsyncWorker{}, deadClock, nil)
+			},
+			filter:  resource.Filter{Health: resource.HealthDegraded},
+			want:    []resource.Resource{degradedResource},
+			wantErr: nil,
+		},
+		{
+			name: "FilterByHealthInParallel",
+			setup: func(t *testing.T) *core.Service {
+				t.Helper()
+				repo := &mocks.ResourceStore{}
+				repo.EXPECT().
+					List(mock.Anything, mock.Anything).
+					Return([]resource.Resource{sampleResource, degradedResource}, nil).
+					Once()
+
+				// each check waits for the other one to start, which never
+				// happens if the checks are made one after the other.
+				started := &sync.WaitGroup{}
+				started.Add(2)
+				bothStarted := make(chan struct{})
+				go func() {
+					started.Wait()
+					close(bothStarted)
+				}()
+
+				mod := &mocks.ModuleService{}
+				mod.EXPECT().
+					GetHealth(mock.Anything, mock.Anything).
+					Run(func(ctx context.Context, res module.ExpandedResource) {
+						started.Done()
+						select {
+						case <-bothStarted:
+						case <-time.After(time.Second):
+							t.Errorf("health checks are not made in parallel")
+						}
+					}).
+					Return(&resource.Health{Status: resource.HealthDegraded}, nil).
+					Twice()
+				return core.New(repo, mod, &mocks.AsyncWorker{}, deadClock, nil)
+			},
+			filter:  resource.Filter{Health: resource.HealthDegraded},
+			want:    []resource.Resource{sampleResource, degradedResource},
+			wantErr: nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -25,6 +25,13 @@ type Store interface {
 	Delete(ctx context.Context, urn string, hooks ...MutationHook) error
 
 	Revisions(ctx context.Context, selector RevisionsSelector) ([]Revision, error)
+
+	GetSchedule(ctx context.Context, id string) (*ScheduledAction, error)
+	ListSchedules(ctx context.Context, selector ScheduleSelector) ([]ScheduledAction, error)
+	CreateSchedule(ctx context.Context, sa ScheduledAction, hooks ...MutationHook) error
+	UpdateSchedule(ctx context.Context, sa ScheduledAction) error
+	RecordScheduleRun(ctx context.Context, sa ScheduledAction, run ScheduleRun, hooks ...MutationHook) error
+	ListScheduleRuns(ctx context.Context, scheduleID string) ([]ScheduleRun, error)
 }
 
 // MutationHook values are passed to mutation operations of resource storage
@@ -43,6 +50,10 @@ type Resource struct {
 	UpdatedAt time.Time         `json:"updated_at"`
 	Spec      Spec              `json:"spec"`
 	State     State             `json:"state"`
+
+	// ExpiresAt, if set, is the time at which the resource is deleted
+	// automatically.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
 }
 
 type Spec struct {
@@ -54,11 +65,17 @@ type Filter struct {
 	Kind    string            `json:"kind"`
 	Project string            `json:"project"`
 	Labels  map[string]string `json:"labels"`
+
+	// Health, if set, matches only the resources with the given health
+	// status. It is not applied by the store or by Apply, since health
+	// is evaluated by the modules.
+	Health string `json:"health,omitempty"`
 }
 
 type UpdateRequest struct {
-	Spec   Spec              `json:"spec"`
-	Labels map[string]string `json:"labels"`
+	Spec      Spec              `json:"spec"`
+	Labels    map[string]string `json:"labels"`
+	ExpiresAt *time.Time        `json:"expires_at,omitempty"`
 }
 
 type RevisionsSelector struct {
@@ -126,6 +143,17 @@ func (f Filter) isMatch(r Resource) bool {
 	return true
 }
 
+// ParseURN returns the kind, project and name encoded in the resource URN.
+func ParseURN(urn string) (kind, project, name string, err error) {
+	const urnParts = 5
+
+	parts := strings.Split(urn, urnSeparator)
+	if len(parts) != urnParts || parts[0] != "orn" || parts[1] != "entropy" {
+		return "", "", "", errors.ErrInvalid.WithMsgf("'%s' is not a valid resource urn", urn)
+	}
+	return parts[2], parts[3], parts[4], nil
+}
+
 func generateURN(res Resource) string {
 	parts := []string{"orn", "entropy", res.Kind, res.Project, res.Name}
 	return strings.Join(parts, urnSeparator)
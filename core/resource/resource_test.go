@@ -65,3 +65,21 @@ func TestResource_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestParseURN(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Valid", func(t *testing.T) {
+		kind, project, name, err := resource.ParseURN("orn:entropy:firehose:odpf:foo")
+		assert.NoError(t, err)
+		assert.Equal(t, "firehose", kind)
+		assert.Equal(t, "odpf", project)
+		assert.Equal(t, "foo", name)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, _, _, err := resource.ParseURN("orn:entropy:firehose:foo")
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrInvalid))
+	})
+}
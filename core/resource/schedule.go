@@ -0,0 +1,55 @@
+package resource
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	ScheduleStatusPending   = "PENDING"
+	ScheduleStatusDone      = "DONE"
+	ScheduleStatusFailed    = "FAILED"
+	ScheduleStatusCancelled = "CANCELLED"
+)
+
+// ScheduledAction represents an action to be applied on a resource at a
+// future time. If Cron is set, the action recurs as per the cron expression
+// and RunAt is the time of the next run.
+type ScheduledAction struct {
+	ID        string            `json:"id"`
+	URN       string            `json:"urn"`
+	Action    string            `json:"action"`
+	Params    json.RawMessage   `json:"params"`
+	Labels    map[string]string `json:"labels"`
+	Cron      string            `json:"cron,omitempty"`
+	RunAt     time.Time         `json:"run_at"`
+	Status    string            `json:"status"`
+	Result    string            `json:"result,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// ScheduleRun records the outcome of one run of a scheduled action.
+type ScheduleRun struct {
+	ScheduleID string    `json:"schedule_id"`
+	RunAt      time.Time `json:"run_at"`
+	Status     string    `json:"status"`
+	Result     string    `json:"result,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type ScheduleSelector struct {
+	URN    string `json:"urn"`
+	Status string `json:"status"`
+}
+
+// IsPending returns true if the scheduled action is yet to be executed.
+// Recurring actions remain pending until cancelled.
+func (sa ScheduledAction) IsPending() bool {
+	return sa.Status == ScheduleStatusPending
+}
+
+// IsRecurring returns true if the action recurs as per a cron expression.
+func (sa ScheduledAction) IsRecurring() bool {
+	return sa.Cron != ""
+}
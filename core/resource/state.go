@@ -12,10 +12,28 @@ const (
 	StatusCompleted   = "STATUS_COMPLETED"   // terminal
 )
 
+// Health of a resource, as evaluated by its module.
+const (
+	HealthHealthy  = "HEALTHY"
+	HealthDegraded = "DEGRADED"
+	HealthUnknown  = "UNKNOWN"
+)
+
+// Health is the current condition of the external system managed by a
+// resource. It is evaluated on every read, and is never stored.
+type Health struct {
+	Status  string   `json:"status"`
+	Reasons []string `json:"reasons,omitempty"`
+}
+
 type State struct {
 	Status     string          `json:"status"`
 	Output     json.RawMessage `json:"output"`
 	ModuleData json.RawMessage `json:"module_data,omitempty"`
+
+	// ModuleRevision is the revision of the module configs that the
+	// resource was last synced with.
+	ModuleRevision int64 `json:"module_revision,omitempty"`
 }
 
 // IsTerminal returns true if state is terminal. A terminal state is
@@ -33,9 +51,10 @@ func (s State) Clone() State {
 	copy(output, s.Output)
 
 	newState := State{
-		Status:     s.Status,
-		Output:     output,
-		ModuleData: make([]byte, len(s.ModuleData)),
+		Status:         s.Status,
+		Output:         output,
+		ModuleData:     make([]byte, len(s.ModuleData)),
+		ModuleRevision: s.ModuleRevision,
 	}
 	copy(newState.ModuleData, s.ModuleData)
 	copy(newState.Output, s.Output)
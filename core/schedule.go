@@ -0,0 +1,288 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/rs/xid"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/worker"
+)
+
+const JobKindScheduledAction = "scheduled_action"
+
+// maxScheduledActionAttempts limits the number of times a scheduled action
+// is retried while the resource is busy with another change.
+const maxScheduledActionAttempts = 20
+
+type scheduledActionPayload struct {
+	ScheduleID string    `json:"schedule_id"`
+	RunAt      time.Time `json:"run_at"`
+}
+
+// ScheduleAction schedules the action to be applied on the resource at the
+// given time. The action is validated by planning it against the current
+// version of the resource. Note that the action is planned again when it
+// is executed.
+func (s *Service) ScheduleAction(ctx context.Context, urn string, act module.ActionRequest, runAt time.Time) (*resource.ScheduledAction, error) {
+	if !runAt.After(s.clock()) {
+		return nil, errors.ErrInvalid.WithMsgf("run_at must be a time in the future")
+	}
+	return s.createSchedule(ctx, urn, act, "", runAt)
+}
+
+// ScheduleRecurringAction schedules the action to be applied on the resource
+// repeatedly as per the given cron expression. Standard 5-field expressions
+// and descriptors (e.g., '@daily') are supported. Time zone can be set using
+// the 'CRON_TZ=<zone>' prefix, default is UTC.
+func (s *Service) ScheduleRecurringAction(ctx context.Context, urn string, act module.ActionRequest, cronExpr string) (*resource.ScheduledAction, error) {
+	sched, err := cron.ParseStandard(cronExpr)
+	if err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("cron expression '%s' is not valid", cronExpr).WithCausef(err.Error())
+	}
+
+	runAt := sched.Next(s.clock().UTC())
+	if runAt.IsZero() {
+		return nil, errors.ErrInvalid.WithMsgf("cron expression '%s' never fires", cronExpr)
+	}
+	return s.createSchedule(ctx, urn, act, cronExpr, runAt)
+}
+
+// ListScheduledActions returns the scheduled actions matching the selector.
+func (s *Service) ListScheduledActions(ctx context.Context, selector resource.ScheduleSelector) ([]resource.ScheduledAction, error) {
+	schedules, err := s.store.ListSchedules(ctx, selector)
+	if err != nil {
+		return nil, errors.ErrInternal.WithCausef(err.Error())
+	}
+	return schedules, nil
+}
+
+// ListScheduleRuns returns the outcomes of all the runs of a scheduled
+// action so far.
+func (s *Service) ListScheduleRuns(ctx context.Context, id string) ([]resource.ScheduleRun, error) {
+	if _, err := s.getSchedule(ctx, id); err != nil {
+		return nil, err
+	}
+
+	runs, err := s.store.ListScheduleRuns(ctx, id)
+	if err != nil {
+		return nil, errors.ErrInternal.WithCausef(err.Error())
+	}
+	return runs, nil
+}
+
+// CancelScheduledAction cancels a scheduled action that is yet to be
+// executed. Cancelling a recurring action stops all its future runs.
+func (s *Service) CancelScheduledAction(ctx context.Context, id string) (*resource.ScheduledAction, error) {
+	sa, err := s.getSchedule(ctx, id)
+	if err != nil {
+		return nil, err
+	} else if !sa.IsPending() {
+		return nil, errors.ErrInvalid.
+			WithMsgf("cannot cancel scheduled action in '%s' status", sa.Status)
+	}
+
+	sa.Status = resource.ScheduleStatusCancelled
+	sa.UpdatedAt = s.clock()
+	if err := s.store.UpdateSchedule(ctx, *sa); err != nil {
+		return nil, errors.ErrInternal.WithCausef(err.Error())
+	}
+	return sa, nil
+}
+
+// HandleScheduledActionJob is meant to be invoked by asyncWorker when the
+// time for a scheduled action is reached.
+func (s *Service) HandleScheduledActionJob(ctx context.Context, job worker.Job) ([]byte, error) {
+	const busyRetryBackoff = 30 * time.Second
+
+	var data scheduledActionPayload
+	if err := json.Unmarshal(job.Payload, &data); err != nil {
+		return nil, err
+	}
+
+	sa, err := s.getSchedule(ctx, data.ScheduleID)
+	if err != nil {
+		return nil, errors.Verbose(err)
+	} else if !sa.IsPending() || sa.RunAt.Unix() != data.RunAt.Unix() {
+		// cancelled or already handled after the job was enqueued.
+		return json.Marshal(map[string]interface{}{"status": sa.Status})
+	}
+
+	act := module.ActionRequest{
+		Name:   sa.Action,
+		Params: sa.Params,
+		Labels: sa.Labels,
+	}
+
+	run := resource.ScheduleRun{
+		ScheduleID: sa.ID,
+		RunAt:      sa.RunAt,
+		Status:     resource.ScheduleStatusDone,
+	}
+
+	_, applyErr := s.ApplyAction(ctx, sa.URN, act)
+	if errors.Is(applyErr, errors.ErrNotFound) {
+		// resource is gone. the schedule is cancelled instead of being
+		// enqueued again.
+		now := s.clock()
+		run.Status = resource.ScheduleStatusCancelled
+		run.Result = errors.Verbose(applyErr).Error()
+		run.CreatedAt = now
+
+		sa.Status = resource.ScheduleStatusCancelled
+		sa.Result = run.Result
+		sa.UpdatedAt = now
+		if err := s.store.RecordScheduleRun(ctx, *sa, run); err != nil {
+			return nil, err
+		}
+		return json.Marshal(map[string]interface{}{"status": run.Status})
+	} else if applyErr != nil {
+		// resource may be busy with another change or the failure may be
+		// transient. both cases are worth retrying.
+		isRetryable := errors.Is(applyErr, errors.ErrInternal) ||
+			(errors.Is(applyErr, errors.ErrInvalid) && s.isResourceBusy(ctx, sa.URN))
+		if isRetryable && job.AttemptsDone+1 < maxScheduledActionAttempts {
+			return nil, &worker.RetryableError{
+				Cause:      errors.Verbose(applyErr),
+				RetryAfter: busyRetryBackoff,
+			}
+		}
+
+		run.Status = resource.ScheduleStatusFailed
+		run.Result = errors.Verbose(applyErr).Error()
+	}
+
+	now := s.clock()
+	run.CreatedAt = now
+	sa.Result = run.Result
+	sa.UpdatedAt = now
+
+	var hooks []resource.MutationHook
+	if sa.IsRecurring() && !(act.Name == module.DeleteAction && applyErr == nil) {
+		// recurring actions remain pending, with the next run enqueued.
+		// a delete that went through ends the schedule instead.
+		sched, err := cron.ParseStandard(sa.Cron)
+		if err != nil {
+			return nil, err
+		}
+		sa.RunAt = sched.Next(now.UTC())
+
+		next := *sa
+		hooks = append(hooks, func(ctx context.Context) error {
+			return s.enqueueScheduledActionJob(ctx, next)
+		})
+	} else {
+		sa.Status = run.Status
+	}
+
+	if err := s.store.RecordScheduleRun(ctx, *sa, run, hooks...); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(map[string]interface{}{"status": run.Status})
+}
+
+func (s *Service) createSchedule(ctx context.Context, urn string, act module.ActionRequest, cronExpr string, runAt time.Time) (*resource.ScheduledAction, error) {
+	res, err := s.store.GetByURN(ctx, urn)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return nil, errors.ErrNotFound.WithMsgf("resource with urn '%s' not found", urn)
+		}
+		return nil, errors.ErrInternal.WithCausef(err.Error())
+	}
+
+	if isCreate(act.Name) {
+		return nil, errors.ErrInvalid.WithMsgf("action '%s' cannot be scheduled", act.Name)
+	} else if _, err := s.planChange(ctx, *res, act); err != nil {
+		return nil, err
+	}
+
+	now := s.clock()
+	sa := resource.ScheduledAction{
+		ID:        xid.New().String(),
+		URN:       urn,
+		Action:    act.Name,
+		Params:    act.Params,
+		Labels:    act.Labels,
+		Cron:      cronExpr,
+		RunAt:     runAt,
+		Status:    resource.ScheduleStatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	enqueueJob := func(ctx context.Context) error {
+		return s.enqueueScheduledActionJob(ctx, sa)
+	}
+
+	if err := s.store.CreateSchedule(ctx, sa, enqueueJob); err != nil {
+		return nil, errors.ErrInternal.WithCausef(err.Error())
+	}
+	return &sa, nil
+}
+
+// cancelSchedules cancels all the pending scheduled actions (including
+// recurring ones) of the resource.
+func (s *Service) cancelSchedules(ctx context.Context, urn, reason string) error {
+	schedules, err := s.store.ListSchedules(ctx, resource.ScheduleSelector{
+		URN:    urn,
+		Status: resource.ScheduleStatusPending,
+	})
+	if err != nil {
+		return errors.ErrInternal.WithCausef(err.Error())
+	}
+
+	for _, sa := range schedules {
+		sa.Status = resource.ScheduleStatusCancelled
+		sa.Result = reason
+		sa.UpdatedAt = s.clock()
+		if err := s.store.UpdateSchedule(ctx, sa); err != nil {
+			return errors.ErrInternal.WithCausef(err.Error())
+		}
+	}
+	return nil
+}
+
+func (s *Service) getSchedule(ctx context.Context, id string) (*resource.ScheduledAction, error) {
+	sa, err := s.store.GetSchedule(ctx, id)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return nil, errors.ErrNotFound.WithMsgf("scheduled action with id '%s' not found", id)
+		}
+		return nil, errors.ErrInternal.WithCausef(err.Error())
+	}
+	return sa, nil
+}
+
+func (s *Service) isResourceBusy(ctx context.Context, urn string) bool {
+	res, err := s.store.GetByURN(ctx, urn)
+	return err == nil && !res.State.IsTerminal()
+}
+
+func (s *Service) enqueueScheduledActionJob(ctx context.Context, sa resource.ScheduledAction) error {
+	payload, err := json.Marshal(scheduledActionPayload{
+		ScheduleID: sa.ID,
+		RunAt:      sa.RunAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	job := worker.Job{
+		ID:      fmt.Sprintf(JobKindScheduledAction+"-%s-%d", sa.ID, sa.RunAt.Unix()),
+		Kind:    JobKindScheduledAction,
+		RunAt:   sa.RunAt,
+		Payload: payload,
+	}
+
+	if err := s.worker.Enqueue(ctx, job); err != nil && !errors.Is(err, worker.ErrJobExists) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,506 @@
+package core_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/odpf/entropy/core"
+	"github.com/odpf/entropy/core/mocks"
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/worker"
+)
+
+func TestService_ScheduleAction(t *testing.T) {
+	t.Parallel()
+
+	sampleRes := resource.Resource{
+		URN:     "orn:entropy:mock:project:child",
+		Kind:    "mock",
+		Name:    "child",
+		Project: "project",
+		State:   resource.State{Status: resource.StatusCompleted},
+	}
+
+	tests := []struct {
+		name    string
+		setup   func(t *testing.T) *core.Service
+		act     module.ActionRequest
+		runAt   time.Time
+		wantErr error
+	}{
+		{
+			name: "PastRunAt",
+			setup: func(t *testing.T) *core.Service {
+				t.Helper()
+				return core.New(&mocks.ResourceStore{}, &mocks.ModuleService{}, &mocks.AsyncWorker{}, deadClock, nil)
+			},
+			act:     module.ActionRequest{Name: "scale"},
+			runAt:   frozenTime.Add(-1 * time.Minute),
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			name: "ResourceNotFound",
+			setup: func(t *testing.T) *core.Service {
+				t.Helper()
+				resourceRepo := &mocks.ResourceStore{}
+				resourceRepo.EXPECT().
+					GetByURN(mock.Anything, sampleRes.URN).
+					Return(nil, errors.ErrNotFound).Once()
+
+				return core.New(resourceRepo, &mocks.ModuleService{}, &mocks.AsyncWorker{}, deadClock, nil)
+			},
+			act:     module.ActionRequest{Name: "scale"},
+			runAt:   frozenTime.Add(1 * time.Hour),
+			wantErr: errors.ErrNotFound,
+		},
+		{
+			name: "InvalidAction",
+			setup: func(t *testing.T) *core.Service {
+				t.Helper()
+				resourceRepo := &mocks.ResourceStore{}
+				resourceRepo.EXPECT().
+					GetByURN(mock.Anything, sampleRes.URN).
+					Return(&sampleRes, nil).Once()
+
+				mod := &mocks.ModuleService{}
+				mod.EXPECT().
+					PlanAction(mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, errors.ErrInvalid).Once()
+
+				return core.New(resourceRepo, mod, &mocks.AsyncWorker{}, deadClock, nil)
+			},
+			act:     module.ActionRequest{Name: "unknown"},
+			runAt:   frozenTime.Add(1 * time.Hour),
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			name: "Success",
+			setup: func(t *testing.T) *core.Service {
+				t.Helper()
+				resourceRepo := &mocks.ResourceStore{}
+				resourceRepo.EXPECT().
+					GetByURN(mock.Anything, sampleRes.URN).
+					Return(&sampleRes, nil).Once()
+				resourceRepo.EXPECT().
+					CreateSchedule(mock.Anything, mock.Anything, mock.Anything).
+					Run(func(ctx context.Context, sa resource.ScheduledAction, hooks ...resource.MutationHook) {
+						assert.Equal(t, resource.ScheduleStatusPending, sa.Status)
+						assert.Equal(t, "scale", sa.Action)
+						assert.Len(t, hooks, 1)
+						assert.NoError(t, hooks[0](ctx))
+					}).
+					Return(nil).Once()
+
+				mod := &mocks.ModuleService{}
+				mod.EXPECT().
+					PlanAction(mock.Anything, mock.Anything, mock.Anything).
+					Return(&module.Plan{Resource: sampleRes}, nil).Once()
+
+				mockWorker := &mocks.AsyncWorker{}
+				mockWorker.EXPECT().
+					Enqueue(mock.Anything, mock.Anything).
+					Run(func(ctx context.Context, jobs ...worker.Job) {
+						assert.Len(t, jobs, 1)
+						assert.Equal(t, core.JobKindScheduledAction, jobs[0].Kind)
+						assert.Equal(t, frozenTime.Add(1*time.Hour), jobs[0].RunAt)
+					}).
+					Return(nil).Once()
+
+				return core.New(resourceRepo, mod, mockWorker, deadClock, nil)
+			},
+			act: module.ActionRequest{
+				Name:   "scale",
+				Params: []byte(`{"replicas": 0}`),
+			},
+			runAt: frozenTime.Add(1 * time.Hour),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			svc := tt.setup(t)
+
+			got, err := svc.ScheduleAction(context.Background(), sampleRes.URN, tt.act, tt.runAt)
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				assert.Truef(t, errors.Is(err, tt.wantErr), "'%s' != '%s'", tt.wantErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotEmpty(t, got.ID)
+			assert.Equal(t, tt.runAt, got.RunAt)
+		})
+	}
+}
+
+func TestService_CancelScheduledAction(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		setup   func(t *testing.T) *core.Service
+		wantErr error
+	}{
+		{
+			name: "NotFound",
+			setup: func(t *testing.T) *core.Service {
+				t.Helper()
+				resourceRepo := &mocks.ResourceStore{}
+				resourceRepo.EXPECT().
+					GetSchedule(mock.Anything, "sched-1").
+					Return(nil, errors.ErrNotFound).Once()
+
+				return core.New(resourceRepo, &mocks.ModuleService{}, &mocks.AsyncWorker{}, deadClock, nil)
+			},
+			wantErr: errors.ErrNotFound,
+		},
+		{
+			name: "AlreadyDone",
+			setup: func(t *testing.T) *core.Service {
+				t.Helper()
+				resourceRepo := &mocks.ResourceStore{}
+				resourceRepo.EXPECT().
+					GetSchedule(mock.Anything, "sched-1").
+					Return(&resource.ScheduledAction{ID: "sched-1", Status: resource.ScheduleStatusDone}, nil).Once()
+
+				return core.New(resourceRepo, &mocks.ModuleService{}, &mocks.AsyncWorker{}, deadClock, nil)
+			},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			name: "Success",
+			setup: func(t *testing.T) *core.Service {
+				t.Helper()
+				resourceRepo := &mocks.ResourceStore{}
+				resourceRepo.EXPECT().
+					GetSchedule(mock.Anything, "sched-1").
+					Return(&resource.ScheduledAction{ID: "sched-1", Status: resource.ScheduleStatusPending}, nil).Once()
+				resourceRepo.EXPECT().
+					UpdateSchedule(mock.Anything, resource.ScheduledAction{
+						ID:        "sched-1",
+						Status:    resource.ScheduleStatusCancelled,
+						UpdatedAt: frozenTime,
+					}).
+					Return(nil).Once()
+
+				return core.New(resourceRepo, &mocks.ModuleService{}, &mocks.AsyncWorker{}, deadClock, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			svc := tt.setup(t)
+
+			got, err := svc.CancelScheduledAction(context.Background(), "sched-1")
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				assert.Truef(t, errors.Is(err, tt.wantErr), "'%s' != '%s'", tt.wantErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, resource.ScheduleStatusCancelled, got.Status)
+		})
+	}
+}
+
+func TestService_HandleScheduledActionJob(t *testing.T) {
+	t.Parallel()
+
+	payload, _ := json.Marshal(map[string]string{"schedule_id": "sched-1"})
+	job := worker.Job{Kind: core.JobKindScheduledAction, Payload: payload}
+
+	t.Run("Cancelled", func(t *testing.T) {
+		t.Parallel()
+
+		resourceRepo := &mocks.ResourceStore{}
+		resourceRepo.EXPECT().
+			GetSchedule(mock.Anything, "sched-1").
+			Return(&resource.ScheduledAction{ID: "sched-1", Status: resource.ScheduleStatusCancelled}, nil).Once()
+		svc := core.New(resourceRepo, &mocks.ModuleService{}, &mocks.AsyncWorker{}, deadClock, nil)
+
+		got, err := svc.HandleScheduledActionJob(context.Background(), job)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"status": "CANCELLED"}`, string(got))
+	})
+
+	t.Run("ResourceBusy", func(t *testing.T) {
+		t.Parallel()
+
+		pendingRes := resource.Resource{
+			URN:   "orn:entropy:mock:project:child",
+			State: resource.State{Status: resource.StatusPending},
+		}
+
+		resourceRepo := &mocks.ResourceStore{}
+		resourceRepo.EXPECT().
+			GetSchedule(mock.Anything, "sched-1").
+			Return(&resource.ScheduledAction{
+				ID:     "sched-1",
+				URN:    pendingRes.URN,
+				Action: "scale",
+				Status: resource.ScheduleStatusPending,
+			}, nil).Once()
+		resourceRepo.EXPECT().
+			GetByURN(mock.Anything, pendingRes.URN).
+			Return(&pendingRes, nil)
+
+		mod := &mocks.ModuleService{}
+		mod.EXPECT().
+			GetOutput(mock.Anything, mock.Anything).
+			Return(nil, nil)
+		svc := core.New(resourceRepo, mod, &mocks.AsyncWorker{}, deadClock, nil)
+
+		_, err := svc.HandleScheduledActionJob(context.Background(), job)
+		var retryErr *worker.RetryableError
+		assert.ErrorAs(t, err, &retryErr)
+	})
+}
+
+func TestService_HandleScheduledActionJob_ResourceDeleted(t *testing.T) {
+	t.Parallel()
+
+	runAt := frozenTime.Add(-1 * time.Minute)
+	payload, _ := json.Marshal(map[string]interface{}{"schedule_id": "sched-1", "run_at": runAt})
+	job := worker.Job{Kind: core.JobKindScheduledAction, Payload: payload}
+
+	resourceRepo := &mocks.ResourceStore{}
+	resourceRepo.EXPECT().
+		GetSchedule(mock.Anything, "sched-1").
+		Return(&resource.ScheduledAction{
+			ID:     "sched-1",
+			URN:    "orn:entropy:mock:project:child",
+			Action: "scale",
+			Cron:   "@hourly",
+			RunAt:  runAt,
+			Status: resource.ScheduleStatusPending,
+		}, nil).Once()
+	resourceRepo.EXPECT().
+		GetByURN(mock.Anything, "orn:entropy:mock:project:child").
+		Return(nil, errors.ErrNotFound).Once()
+	resourceRepo.EXPECT().
+		RecordScheduleRun(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, sa resource.ScheduledAction, run resource.ScheduleRun, hooks ...resource.MutationHook) {
+			assert.Equal(t, resource.ScheduleStatusCancelled, sa.Status)
+			assert.Equal(t, runAt, sa.RunAt)
+			assert.Equal(t, resource.ScheduleStatusCancelled, run.Status)
+			assert.Empty(t, hooks)
+		}).
+		Return(nil).Once()
+
+	// no job must be enqueued for the next run.
+	svc := core.New(resourceRepo, &mocks.ModuleService{}, &mocks.AsyncWorker{}, deadClock, nil)
+
+	got, err := svc.HandleScheduledActionJob(context.Background(), job)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"status": "CANCELLED"}`, string(got))
+}
+
+func TestService_ScheduleRecurringAction(t *testing.T) {
+	t.Parallel()
+
+	sampleRes := resource.Resource{
+		URN:     "orn:entropy:mock:project:child",
+		Kind:    "mock",
+		Name:    "child",
+		Project: "project",
+		State:   resource.State{Status: resource.StatusCompleted},
+	}
+
+	t.Run("InvalidCron", func(t *testing.T) {
+		t.Parallel()
+		svc := core.New(&mocks.ResourceStore{}, &mocks.ModuleService{}, &mocks.AsyncWorker{}, deadClock, nil)
+
+		_, err := svc.ScheduleRecurringAction(context.Background(), sampleRes.URN, module.ActionRequest{Name: "scale"}, "0 25 * * *")
+		assert.True(t, errors.Is(err, errors.ErrInvalid))
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		// frozenTime is 2022-04-21T10:29:15Z (Thursday).
+		wantRunAt := time.Date(2022, 4, 21, 22, 0, 0, 0, time.UTC)
+
+		resourceRepo := &mocks.ResourceStore{}
+		resourceRepo.EXPECT().
+			GetByURN(mock.Anything, sampleRes.URN).
+			Return(&sampleRes, nil).Once()
+		resourceRepo.EXPECT().
+			CreateSchedule(mock.Anything, mock.Anything, mock.Anything).
+			Run(func(ctx context.Context, sa resource.ScheduledAction, hooks ...resource.MutationHook) {
+				assert.Equal(t, "0 22 * * 1-5", sa.Cron)
+				assert.Len(t, hooks, 1)
+				assert.NoError(t, hooks[0](ctx))
+			}).
+			Return(nil).Once()
+
+		mod := &mocks.ModuleService{}
+		mod.EXPECT().
+			PlanAction(mock.Anything, mock.Anything, mock.Anything).
+			Return(&module.Plan{Resource: sampleRes}, nil).Once()
+
+		mockWorker := &mocks.AsyncWorker{}
+		mockWorker.EXPECT().
+			Enqueue(mock.Anything, mock.Anything).
+			Run(func(ctx context.Context, jobs ...worker.Job) {
+				assert.Len(t, jobs, 1)
+				assert.Equal(t, wantRunAt, jobs[0].RunAt)
+			}).
+			Return(nil).Once()
+
+		svc := core.New(resourceRepo, mod, mockWorker, deadClock, nil)
+
+		got, err := svc.ScheduleRecurringAction(context.Background(), sampleRes.URN, module.ActionRequest{Name: "scale"}, "0 22 * * 1-5")
+		assert.NoError(t, err)
+		assert.Equal(t, wantRunAt, got.RunAt)
+		assert.Equal(t, resource.ScheduleStatusPending, got.Status)
+	})
+}
+
+func TestService_HandleScheduledActionJob_Recurring(t *testing.T) {
+	t.Parallel()
+
+	runAt := frozenTime.Add(-1 * time.Minute)
+	payload, _ := json.Marshal(map[string]interface{}{"schedule_id": "sched-1", "run_at": runAt})
+	job := worker.Job{Kind: core.JobKindScheduledAction, Payload: payload}
+
+	res := resource.Resource{
+		URN:     "orn:entropy:mock:project:child",
+		Kind:    "mock",
+		Name:    "child",
+		Project: "project",
+		State:   resource.State{Status: resource.StatusCompleted},
+	}
+
+	resourceRepo := &mocks.ResourceStore{}
+	resourceRepo.EXPECT().
+		GetSchedule(mock.Anything, "sched-1").
+		Return(&resource.ScheduledAction{
+			ID:     "sched-1",
+			URN:    res.URN,
+			Action: "scale",
+			Cron:   "@hourly",
+			RunAt:  runAt,
+			Status: resource.ScheduleStatusPending,
+		}, nil).Once()
+	resourceRepo.EXPECT().
+		GetByURN(mock.Anything, res.URN).
+		Return(&res, nil)
+	resourceRepo.EXPECT().
+		Update(mock.Anything, mock.Anything, true, mock.Anything, mock.Anything).
+		Return(nil).Once()
+	resourceRepo.EXPECT().
+		RecordScheduleRun(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, sa resource.ScheduledAction, run resource.ScheduleRun, hooks ...resource.MutationHook) {
+			assert.Equal(t, resource.ScheduleStatusPending, sa.Status)
+			assert.Equal(t, time.Date(2022, 4, 21, 11, 0, 0, 0, time.UTC), sa.RunAt)
+			assert.Equal(t, resource.ScheduleStatusDone, run.Status)
+			assert.Equal(t, runAt, run.RunAt)
+			assert.Len(t, hooks, 1)
+			assert.NoError(t, hooks[0](ctx))
+		}).
+		Return(nil).Once()
+
+	mod := &mocks.ModuleService{}
+	mod.EXPECT().
+		GetOutput(mock.Anything, mock.Anything).
+		Return(nil, nil)
+	mod.EXPECT().
+		PlanAction(mock.Anything, mock.Anything, mock.Anything).
+		Return(&module.Plan{Resource: res}, nil).Once()
+
+	mockWorker := &mocks.AsyncWorker{}
+	mockWorker.EXPECT().
+		Enqueue(mock.Anything, mock.Anything).
+		Return(nil).Once()
+
+	svc := core.New(resourceRepo, mod, mockWorker, deadClock, nil)
+
+	got, err := svc.HandleScheduledActionJob(context.Background(), job)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"status": "DONE"}`, string(got))
+}
+
+func TestService_HandleScheduledActionJob_Delete(t *testing.T) {
+	t.Parallel()
+
+	runAt := frozenTime.Add(-1 * time.Minute)
+	payload, _ := json.Marshal(map[string]interface{}{"schedule_id": "sched-1", "run_at": runAt})
+	job := worker.Job{Kind: core.JobKindScheduledAction, Payload: payload}
+
+	res := resource.Resource{
+		URN:     "orn:entropy:mock:project:child",
+		Kind:    "mock",
+		Name:    "child",
+		Project: "project",
+		State:   resource.State{Status: resource.StatusCompleted},
+	}
+
+	resourceRepo := &mocks.ResourceStore{}
+	resourceRepo.EXPECT().
+		GetSchedule(mock.Anything, "sched-1").
+		Return(&resource.ScheduledAction{
+			ID:     "sched-1",
+			URN:    res.URN,
+			Action: module.DeleteAction,
+			Cron:   "@hourly",
+			RunAt:  runAt,
+			Status: resource.ScheduleStatusPending,
+		}, nil).Once()
+	resourceRepo.EXPECT().
+		GetByURN(mock.Anything, res.URN).
+		Return(&res, nil)
+	resourceRepo.EXPECT().
+		Update(mock.Anything, mock.Anything, true, mock.Anything, mock.Anything).
+		Return(nil).Once()
+	resourceRepo.EXPECT().
+		ListSchedules(mock.Anything, resource.ScheduleSelector{
+			URN:    res.URN,
+			Status: resource.ScheduleStatusPending,
+		}).
+		Return([]resource.ScheduledAction{
+			{ID: "sched-1", URN: res.URN, Action: module.DeleteAction, Status: resource.ScheduleStatusPending},
+			{ID: "sched-2", URN: res.URN, Action: "scale", Status: resource.ScheduleStatusPending},
+		}, nil).Once()
+	resourceRepo.EXPECT().
+		UpdateSchedule(mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, sa resource.ScheduledAction) {
+			assert.Equal(t, resource.ScheduleStatusCancelled, sa.Status)
+			assert.Equal(t, "resource deleted", sa.Result)
+		}).
+		Return(nil).Twice()
+	resourceRepo.EXPECT().
+		RecordScheduleRun(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, sa resource.ScheduledAction, run resource.ScheduleRun, hooks ...resource.MutationHook) {
+			assert.Equal(t, resource.ScheduleStatusDone, sa.Status)
+			assert.Equal(t, resource.ScheduleStatusDone, run.Status)
+			assert.Empty(t, hooks)
+		}).
+		Return(nil).Once()
+
+	mod := &mocks.ModuleService{}
+	mod.EXPECT().
+		GetOutput(mock.Anything, mock.Anything).
+		Return(nil, nil)
+	mod.EXPECT().
+		PlanAction(mock.Anything, mock.Anything, mock.Anything).
+		Return(&module.Plan{Resource: res}, nil).Once()
+
+	svc := core.New(resourceRepo, mod, &mocks.AsyncWorker{}, deadClock, nil)
+
+	got, err := svc.HandleScheduledActionJob(context.Background(), job)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"status": "DONE"}`, string(got))
+	resourceRepo.AssertExpectations(t)
+}
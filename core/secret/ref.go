@@ -0,0 +1,73 @@
+package secret
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+)
+
+// RefKey is the key used in resource configs to refer to a secret.
+// For example: {"SINK_HTTP_OAUTH2_CLIENT_SECRET": {"secret_ref": "my-secret"}}
+const RefKey = "secret_ref"
+
+// Ref represents a reference to a secret from the resource configs.
+type Ref struct {
+	Name string `json:"secret_ref"`
+}
+
+// AsRef returns the secret name if the given JSON value is a secret
+// reference (i.e., an object with 'secret_ref' as its only key).
+func AsRef(v interface{}) (string, bool) {
+	obj, isObj := v.(map[string]interface{})
+	if !isObj || len(obj) != 1 {
+		return "", false
+	}
+
+	name, isStr := obj[RefKey].(string)
+	return name, isStr
+}
+
+// FindRefs returns the names of all the secrets referenced anywhere in the
+// given JSON document.
+func FindRefs(doc json.RawMessage) ([]string, error) {
+	if len(doc) == 0 {
+		return nil, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	found := map[string]bool{}
+	collectRefs(v, found)
+
+	var names []string
+	for name := range found {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func collectRefs(v interface{}, into map[string]bool) {
+	if name, isRef := AsRef(v); isRef {
+		into[name] = true
+		return
+	}
+
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for _, child := range val {
+			collectRefs(child, into)
+		}
+
+	case []interface{}:
+		for _, child := range val {
+			collectRefs(child, into)
+		}
+	}
+}
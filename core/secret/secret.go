@@ -0,0 +1,47 @@
+package secret
+
+//go:generate mockery --name=Store -r --case underscore --with-expecter --structname SecretStore --filename=secret_store.go --output=../mocks
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Secret represents a sensitive value stored in Entropy for a project.
+// Resource configs can refer to a secret by its name instead of embedding
+// the value directly. See Ref.
+type Secret struct {
+	Name      string    `json:"name"`
+	Project   string    `json:"project"`
+	Value     string    `json:"value,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Store is responsible for persisting secrets defined for each project.
+type Store interface {
+	GetSecret(ctx context.Context, project, name string) (*Secret, error)
+	ListSecrets(ctx context.Context, project string) ([]Secret, error)
+	UpsertSecret(ctx context.Context, sec Secret) error
+	DeleteSecret(ctx context.Context, project, name string) error
+}
+
+func (sec *Secret) sanitise() error {
+	sec.Name = strings.TrimSpace(sec.Name)
+	sec.Project = strings.TrimSpace(sec.Project)
+
+	if sec.Project == "" {
+		return errors.ErrInvalid.WithMsgf("project must be set")
+	} else if !namePattern.MatchString(sec.Name) {
+		return errors.ErrInvalid.WithMsgf("name must match pattern '%s'", namePattern)
+	} else if sec.Value == "" {
+		return errors.ErrInvalid.WithMsgf("value must be set")
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package secret
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+type Service struct {
+	store Store
+}
+
+func NewService(store Store) *Service {
+	return &Service{store: store}
+}
+
+// PutSecret creates the secret or replaces the value of the existing one.
+// The returned secret does not contain the value.
+func (svc *Service) PutSecret(ctx context.Context, sec Secret) (*Secret, error) {
+	if err := sec.sanitise(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	sec.CreatedAt = now
+	sec.UpdatedAt = now
+	if err := svc.store.UpsertSecret(ctx, sec); err != nil {
+		return nil, err
+	}
+
+	sec.Value = ""
+	return &sec, nil
+}
+
+// ListSecrets returns all secrets of the project without the values.
+func (svc *Service) ListSecrets(ctx context.Context, project string) ([]Secret, error) {
+	secrets, err := svc.store.ListSecrets(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range secrets {
+		secrets[i].Value = ""
+	}
+	return secrets, nil
+}
+
+func (svc *Service) DeleteSecret(ctx context.Context, project, name string) error {
+	return svc.store.DeleteSecret(ctx, project, name)
+}
+
+// Resolve finds all the secret references in the given configs and returns
+// the values of the referenced secrets by their names.
+func (svc *Service) Resolve(ctx context.Context, project string, configs json.RawMessage) (map[string]string, error) {
+	names, err := FindRefs(configs)
+	if err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid config json").WithCausef(err.Error())
+	} else if len(names) == 0 {
+		return nil, nil
+	}
+
+	values := map[string]string{}
+	for _, name := range names {
+		sec, err := svc.store.GetSecret(ctx, project, name)
+		if err != nil {
+			if errors.Is(err, errors.ErrNotFound) {
+				return nil, errors.ErrInvalid.
+					WithMsgf("secret '%s' not found in project '%s'", name, project)
+			}
+			return nil, err
+		}
+		values[name] = sec.Value
+	}
+	return values, nil
+}
@@ -0,0 +1,156 @@
+package secret_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/odpf/entropy/core/mocks"
+	"github.com/odpf/entropy/core/secret"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+func TestService_PutSecret(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		title   string
+		setup   func(t *testing.T) *secret.Service
+		sec     secret.Secret
+		want    *secret.Secret
+		wantErr error
+	}{
+		{
+			title: "InvalidName",
+			setup: func(t *testing.T) *secret.Service {
+				t.Helper()
+				return secret.NewService(&mocks.SecretStore{})
+			},
+			sec:     secret.Secret{Name: "foo bar", Project: "proj", Value: "s3cr3t"},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title: "EmptyValue",
+			setup: func(t *testing.T) *secret.Service {
+				t.Helper()
+				return secret.NewService(&mocks.SecretStore{})
+			},
+			sec:     secret.Secret{Name: "foo", Project: "proj"},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title: "Success",
+			setup: func(t *testing.T) *secret.Service {
+				t.Helper()
+				store := &mocks.SecretStore{}
+				store.EXPECT().
+					UpsertSecret(mock.Anything, mock.MatchedBy(func(sec secret.Secret) bool {
+						return sec.Name == "foo" && sec.Project == "proj" && sec.Value == "s3cr3t"
+					})).
+					Return(nil).Once()
+				return secret.NewService(store)
+			},
+			sec:  secret.Secret{Name: " foo ", Project: "proj", Value: "s3cr3t"},
+			want: &secret.Secret{Name: "foo", Project: "proj"},
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+			svc := tt.setup(t)
+
+			got, err := svc.PutSecret(context.Background(), tt.sec)
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				assert.Truef(t, errors.Is(err, tt.wantErr), "'%s' != '%s'", tt.wantErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want.Name, got.Name)
+			assert.Equal(t, tt.want.Project, got.Project)
+			assert.Empty(t, got.Value)
+			assert.False(t, got.CreatedAt.IsZero())
+		})
+	}
+}
+
+func TestService_Resolve(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		title   string
+		setup   func(t *testing.T) *secret.Service
+		configs string
+		want    map[string]string
+		wantErr error
+	}{
+		{
+			title: "NoRefs",
+			setup: func(t *testing.T) *secret.Service {
+				t.Helper()
+				return secret.NewService(&mocks.SecretStore{})
+			},
+			configs: `{"env": {"FOO": "bar"}}`,
+			want:    nil,
+		},
+		{
+			title: "InvalidConfigs",
+			setup: func(t *testing.T) *secret.Service {
+				t.Helper()
+				return secret.NewService(&mocks.SecretStore{})
+			},
+			configs: `{`,
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title: "SecretNotFound",
+			setup: func(t *testing.T) *secret.Service {
+				t.Helper()
+				store := &mocks.SecretStore{}
+				store.EXPECT().
+					GetSecret(mock.Anything, "proj", "missing").
+					Return(nil, errors.ErrNotFound).Once()
+				return secret.NewService(store)
+			},
+			configs: `{"env": {"FOO": {"secret_ref": "missing"}}}`,
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title: "Success",
+			setup: func(t *testing.T) *secret.Service {
+				t.Helper()
+				store := &mocks.SecretStore{}
+				store.EXPECT().
+					GetSecret(mock.Anything, "proj", "a").
+					Return(&secret.Secret{Name: "a", Project: "proj", Value: "value-a"}, nil).Once()
+				store.EXPECT().
+					GetSecret(mock.Anything, "proj", "b").
+					Return(&secret.Secret{Name: "b", Project: "proj", Value: "value-b"}, nil).Once()
+				return secret.NewService(store)
+			},
+			configs: `{"env": {"FOO": {"secret_ref": "a"}, "BAR": "x"}, "list": [{"secret_ref": "b"}, {"secret_ref": "a"}]}`,
+			want:    map[string]string{"a": "value-a", "b": "value-b"},
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+			svc := tt.setup(t)
+
+			got, err := svc.Resolve(context.Background(), "proj", []byte(tt.configs))
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				assert.Truef(t, errors.Is(err, tt.wantErr), "'%s' != '%s'", tt.wantErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -59,7 +59,11 @@ func (s *Service) HandleSyncJob(ctx context.Context, job worker.Job) ([]byte, er
 
 	syncedRes, err := s.syncChange(ctx, data.ResourceURN)
 	if err != nil {
-		if errors.Is(err, errors.ErrInternal) {
+		var retryErr *worker.RetryableError
+		if errors.As(err, &retryErr) {
+			// the module knows best when the sync is worth retrying.
+			return nil, retryErr
+		} else if errors.Is(err, errors.ErrInternal) {
 			return nil, &worker.RetryableError{
 				Cause:      errors.Verbose(err),
 				RetryAfter: retryBackoff,
@@ -88,7 +92,8 @@ func (s *Service) syncChange(ctx context.Context, urn string) (*resource.Resourc
 	oldState := res.State.Clone()
 	newState, err := s.moduleSvc.SyncState(ctx, *modSpec)
 	if err != nil {
-		if errors.Is(err, errors.ErrInvalid) {
+		var retryErr *worker.RetryableError
+		if errors.Is(err, errors.ErrInvalid) || errors.As(err, &retryErr) {
 			return nil, err
 		}
 		return nil, errors.ErrInternal.WithMsgf("sync() failed").WithCausef(err.Error())
@@ -99,8 +104,12 @@ func (s *Service) syncChange(ctx context.Context, urn string) (*resource.Resourc
 	// TODO: clarify on behaviour when resource schedule for deletion reaches error.
 	shouldDelete := oldState.InDeletion() && newState.IsTerminal()
 	if shouldDelete {
-		if err := s.DeleteResource(ctx, urn); err != nil {
-			return nil, err
+		// the module has finished tearing down the resource. so it can be
+		// removed from the store. DeleteResource must not be used here: it
+		// plans the delete action again, which puts the resource back in
+		// deletion and leaves it in the store forever.
+		if err := s.store.Delete(ctx, urn); err != nil && !errors.Is(err, errors.ErrNotFound) {
+			return nil, errors.ErrInternal.WithMsgf("failed to delete resource").WithCausef(err.Error())
 		}
 	} else {
 		if err := s.upsert(ctx, module.Plan{Resource: *res}, false, false, ""); err != nil {
@@ -1 +1,186 @@
 package core_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/odpf/entropy/core"
+	"github.com/odpf/entropy/core/mocks"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/worker"
+)
+
+func TestService_HandleSyncJob(t *testing.T) {
+	t.Parallel()
+
+	payload, _ := json.Marshal(map[string]interface{}{
+		"resource_urn": "orn:entropy:mock:project:child",
+	})
+	job := worker.Job{Kind: core.JobKindSyncResource, Payload: payload}
+
+	t.Run("DeletionCompleted", func(t *testing.T) {
+		t.Parallel()
+
+		resourceRepo := &mocks.ResourceStore{}
+		resourceRepo.EXPECT().
+			GetByURN(mock.Anything, "orn:entropy:mock:project:child").
+			Return(&resource.Resource{
+				URN:   "orn:entropy:mock:project:child",
+				Kind:  "mock",
+				State: resource.State{Status: resource.StatusDeleted},
+			}, nil).Once()
+		resourceRepo.EXPECT().
+			Delete(mock.Anything, "orn:entropy:mock:project:child").
+			Return(nil).Once()
+
+		mod := &mocks.ModuleService{}
+		mod.EXPECT().
+			GetOutput(mock.Anything, mock.Anything).
+			Return(nil, nil).Once()
+		mod.EXPECT().
+			SyncState(mock.Anything, mock.Anything).
+			Return(&resource.State{Status: resource.StatusCompleted}, nil).Once()
+
+		svc := core.New(resourceRepo, mod, &mocks.AsyncWorker{}, deadClock, nil)
+
+		got, err := svc.HandleSyncJob(context.Background(), job)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"status": "STATUS_COMPLETED"}`, string(got))
+		resourceRepo.AssertExpectations(t)
+	})
+
+	t.Run("DeletionCompleted_AlreadyRemoved", func(t *testing.T) {
+		t.Parallel()
+
+		resourceRepo := &mocks.ResourceStore{}
+		resourceRepo.EXPECT().
+			GetByURN(mock.Anything, "orn:entropy:mock:project:child").
+			Return(&resource.Resource{
+				URN:   "orn:entropy:mock:project:child",
+				Kind:  "mock",
+				State: resource.State{Status: resource.StatusDeleted},
+			}, nil).Once()
+		resourceRepo.EXPECT().
+			Delete(mock.Anything, "orn:entropy:mock:project:child").
+			Return(errors.ErrNotFound).Once()
+
+		mod := &mocks.ModuleService{}
+		mod.EXPECT().
+			GetOutput(mock.Anything, mock.Anything).
+			Return(nil, nil).Once()
+		mod.EXPECT().
+			SyncState(mock.Anything, mock.Anything).
+			Return(&resource.State{Status: resource.StatusCompleted}, nil).Once()
+
+		// the delete action must not be planned again.
+		svc := core.New(resourceRepo, mod, &mocks.AsyncWorker{}, deadClock, nil)
+
+		got, err := svc.HandleSyncJob(context.Background(), job)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"status": "STATUS_COMPLETED"}`, string(got))
+		mod.AssertExpectations(t)
+	})
+
+	t.Run("DeletionCompleted_StoreFailure", func(t *testing.T) {
+		t.Parallel()
+
+		resourceRepo := &mocks.ResourceStore{}
+		resourceRepo.EXPECT().
+			GetByURN(mock.Anything, "orn:entropy:mock:project:child").
+			Return(&resource.Resource{
+				URN:   "orn:entropy:mock:project:child",
+				Kind:  "mock",
+				State: resource.State{Status: resource.StatusDeleted},
+			}, nil).Once()
+		resourceRepo.EXPECT().
+			Delete(mock.Anything, "orn:entropy:mock:project:child").
+			Return(errors.New("connection reset")).Once()
+
+		mod := &mocks.ModuleService{}
+		mod.EXPECT().
+			GetOutput(mock.Anything, mock.Anything).
+			Return(nil, nil).Once()
+		mod.EXPECT().
+			SyncState(mock.Anything, mock.Anything).
+			Return(&resource.State{Status: resource.StatusCompleted}, nil).Once()
+
+		svc := core.New(resourceRepo, mod, &mocks.AsyncWorker{}, deadClock, nil)
+
+		_, err := svc.HandleSyncJob(context.Background(), job)
+		var retryErr *worker.RetryableError
+		assert.ErrorAs(t, err, &retryErr)
+	})
+
+	t.Run("ModuleRetryBackoff", func(t *testing.T) {
+		t.Parallel()
+
+		resourceRepo := &mocks.ResourceStore{}
+		resourceRepo.EXPECT().
+			GetByURN(mock.Anything, "orn:entropy:mock:project:child").
+			Return(&resource.Resource{
+				URN:   "orn:entropy:mock:project:child",
+				Kind:  "mock",
+				State: resource.State{Status: resource.StatusPending},
+			}, nil).Once()
+
+		mod := &mocks.ModuleService{}
+		mod.EXPECT().
+			GetOutput(mock.Anything, mock.Anything).
+			Return(nil, nil).Once()
+		mod.EXPECT().
+			SyncState(mock.Anything, mock.Anything).
+			Return(nil, &worker.RetryableError{Cause: errors.New("rollout in progress"), RetryAfter: time.Minute}).Once()
+
+		svc := core.New(resourceRepo, mod, &mocks.AsyncWorker{}, deadClock, nil)
+
+		_, err := svc.HandleSyncJob(context.Background(), job)
+		var retryErr *worker.RetryableError
+		assert.ErrorAs(t, err, &retryErr)
+		assert.Equal(t, time.Minute, retryErr.RetryAfter)
+		assert.EqualError(t, retryErr.Cause, "rollout in progress")
+	})
+
+	t.Run("DeletionPending", func(t *testing.T) {
+		t.Parallel()
+
+		res := resource.Resource{
+			URN:   "orn:entropy:mock:project:child",
+			Kind:  "mock",
+			State: resource.State{Status: resource.StatusDeleted},
+		}
+
+		resourceRepo := &mocks.ResourceStore{}
+		resourceRepo.EXPECT().
+			GetByURN(mock.Anything, res.URN).
+			Return(&res, nil).Once()
+		resourceRepo.EXPECT().
+			Update(mock.Anything, mock.Anything, false, "", mock.Anything).
+			Return(nil).Once()
+
+		mod := &mocks.ModuleService{}
+		mod.EXPECT().
+			GetOutput(mock.Anything, mock.Anything).
+			Return(nil, nil).Once()
+		mod.EXPECT().
+			SyncState(mock.Anything, mock.Anything).
+			Return(&resource.State{Status: resource.StatusDeleted}, nil).Once()
+
+		asyncWorker := &mocks.AsyncWorker{}
+		asyncWorker.EXPECT().
+			Enqueue(mock.Anything, mock.Anything).
+			Return(nil).Once()
+
+		svc := core.New(resourceRepo, mod, asyncWorker, deadClock, nil)
+
+		got, err := svc.HandleSyncJob(context.Background(), job)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"status": "STATUS_DELETED"}`, string(got))
+		resourceRepo.AssertExpectations(t)
+	})
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"bytes"
 	"context"
 
 	"github.com/odpf/entropy/core/module"
@@ -11,6 +12,8 @@ import (
 func (s *Service) CreateResource(ctx context.Context, res resource.Resource) (*resource.Resource, error) {
 	if err := res.Validate(true); err != nil {
 		return nil, err
+	} else if err := s.validateExpiry(res.ExpiresAt); err != nil {
+		return nil, err
 	}
 
 	act := module.ActionRequest{
@@ -27,13 +30,39 @@ func (s *Service) UpdateResource(ctx context.Context, urn string, req resource.U
 		return nil, errors.ErrUnsupported.WithMsgf("updating dependencies is not supported")
 	} else if len(req.Spec.Configs) == 0 {
 		return nil, errors.ErrInvalid.WithMsgf("no config is being updated, nothing to do")
+	} else if err := s.validateExpiry(req.ExpiresAt); err != nil {
+		return nil, err
 	}
 
-	return s.ApplyAction(ctx, urn, module.ActionRequest{
+	act := module.ActionRequest{
 		Name:   module.UpdateAction,
 		Params: req.Spec.Configs,
 		Labels: req.Labels,
-	})
+	}
+
+	res, err := s.getIdleResource(ctx, urn, act.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	// configs read with the secrets masked can be sent back as they are.
+	// the masked values must not replace the stored ones.
+	if bytes.Contains(act.Params, []byte(module.RedactedValue)) {
+		secrets, err := s.GetSecrets(ctx, res.Kind, res.Project)
+		if err != nil {
+			return nil, err
+		}
+
+		act.Params, err = module.Unredact(act.Params, res.Spec.Configs, secrets.Configs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if req.ExpiresAt != nil {
+		res.ExpiresAt = req.ExpiresAt
+	}
+	return s.execAction(ctx, *res, act)
 }
 
 func (s *Service) DeleteResource(ctx context.Context, urn string) error {
@@ -44,15 +73,36 @@ func (s *Service) DeleteResource(ctx context.Context, urn string) error {
 }
 
 func (s *Service) ApplyAction(ctx context.Context, urn string, act module.ActionRequest) (*resource.Resource, error) {
+	res, err := s.getIdleResource(ctx, urn, act.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	planned, err := s.execAction(ctx, *res, act)
+	if err != nil {
+		return nil, err
+	}
+
+	if act.Name == module.DeleteAction {
+		// actions scheduled on the resource can never run once it is gone.
+		if err := s.cancelSchedules(ctx, urn, "resource deleted"); err != nil {
+			return nil, err
+		}
+	}
+	return planned, nil
+}
+
+// getIdleResource returns the resource only if it is in a terminal state
+// and can accept the given operation.
+func (s *Service) getIdleResource(ctx context.Context, urn, operation string) (*resource.Resource, error) {
 	res, err := s.GetResource(ctx, urn)
 	if err != nil {
 		return nil, err
 	} else if !res.State.IsTerminal() {
 		return nil, errors.ErrInvalid.
-			WithMsgf("cannot perform '%s' on resource in '%s'", act.Name, res.State.Status)
+			WithMsgf("cannot perform '%s' on resource in '%s'", operation, res.State.Status)
 	}
-
-	return s.execAction(ctx, *res, act)
+	return res, nil
 }
 
 func (s *Service) execAction(ctx context.Context, res resource.Resource, act module.ActionRequest) (*resource.Resource, error) {
@@ -68,6 +118,7 @@ func (s *Service) execAction(ctx context.Context, res resource.Resource, act mod
 		planned.Resource.CreatedAt = res.CreatedAt
 		planned.Resource.UpdatedAt = s.clock()
 	}
+	planned.Resource.ExpiresAt = res.ExpiresAt
 
 	if err := s.upsert(ctx, *planned, isCreate(act.Name), true, planned.Reason); err != nil {
 		return nil, err
@@ -118,6 +169,12 @@ func (s *Service) upsert(ctx context.Context, plan module.Plan, isCreate bool, s
 		})
 	}
 
+	if saveRevision && plan.Resource.ExpiresAt != nil && !plan.Resource.State.InDeletion() {
+		hooks = append(hooks, func(ctx context.Context) error {
+			return s.enqueueExpiryJobs(ctx, plan.Resource)
+		})
+	}
+
 	var err error
 	if isCreate {
 		err = s.store.Create(ctx, plan.Resource, hooks...)
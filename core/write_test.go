@@ -2,11 +2,13 @@ package core_test
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/odpf/entropy/core"
 	"github.com/odpf/entropy/core/mocks"
@@ -514,6 +516,86 @@ func TestService_UpdateResource(t *testing.T) {
 	}
 }
 
+func TestService_UpdateResource_RedactedSecrets(t *testing.T) {
+	t.Parallel()
+
+	stored := resource.Resource{
+		URN:     "orn:entropy:mock:project:child",
+		Kind:    "mock",
+		Name:    "child",
+		Project: "project",
+		Spec:    resource.Spec{Configs: []byte(`{"user":"admin","password":"s3cr3t"}`)},
+		State:   resource.State{Status: resource.StatusCompleted},
+	}
+	secrets := &module.Secrets{Configs: []string{"password"}}
+
+	// configs as returned by GetResource, with only the user changed.
+	masked, err := module.Redact(stored.Spec.Configs, secrets.Configs)
+	require.NoError(t, err)
+	updated := strings.Replace(string(masked), "admin", "reader", 1)
+
+	t.Run("StoredValueKept", func(t *testing.T) {
+		t.Parallel()
+
+		mod := &mocks.ModuleService{}
+		mod.EXPECT().
+			GetOutput(mock.Anything, mock.Anything).
+			Return(nil, nil).Once()
+		mod.EXPECT().
+			GetSecrets(mock.Anything, "mock", "project").
+			Return(secrets, nil).Once()
+		mod.EXPECT().
+			PlanAction(mock.Anything, mock.Anything, mock.Anything).
+			Run(func(ctx context.Context, res module.ExpandedResource, act module.ActionRequest) {
+				assert.JSONEq(t, `{"user":"reader","password":"s3cr3t"}`, string(act.Params))
+			}).
+			Return(&module.Plan{Resource: stored}, nil).Once()
+
+		resourceRepo := &mocks.ResourceStore{}
+		resourceRepo.EXPECT().
+			GetByURN(mock.Anything, stored.URN).
+			Return(&stored, nil).Once()
+		resourceRepo.EXPECT().
+			Update(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil).Once()
+
+		svc := core.New(resourceRepo, mod, &mocks.AsyncWorker{}, deadClock, nil)
+
+		_, err := svc.UpdateResource(context.Background(), stored.URN, resource.UpdateRequest{
+			Spec: resource.Spec{Configs: []byte(updated)},
+		})
+		assert.NoError(t, err)
+		mod.AssertExpectations(t)
+	})
+
+	t.Run("NothingStored", func(t *testing.T) {
+		t.Parallel()
+
+		noPassword := stored
+		noPassword.Spec.Configs = []byte(`{"user":"admin"}`)
+
+		mod := &mocks.ModuleService{}
+		mod.EXPECT().
+			GetOutput(mock.Anything, mock.Anything).
+			Return(nil, nil).Once()
+		mod.EXPECT().
+			GetSecrets(mock.Anything, "mock", "project").
+			Return(secrets, nil).Once()
+
+		resourceRepo := &mocks.ResourceStore{}
+		resourceRepo.EXPECT().
+			GetByURN(mock.Anything, stored.URN).
+			Return(&noPassword, nil).Once()
+
+		svc := core.New(resourceRepo, mod, &mocks.AsyncWorker{}, deadClock, nil)
+
+		_, err := svc.UpdateResource(context.Background(), stored.URN, resource.UpdateRequest{
+			Spec: resource.Spec{Configs: []byte(updated)},
+		})
+		assert.ErrorIs(t, err, errors.ErrInvalid)
+	})
+}
+
 func TestService_DeleteResource(t *testing.T) {
 	t.Parallel()
 
@@ -628,6 +710,24 @@ func TestService_DeleteResource(t *testing.T) {
 					Update(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 					Return(nil).
 					Once()
+				resourceRepo.EXPECT().
+					ListSchedules(mock.Anything, resource.ScheduleSelector{
+						URN:    "orn:entropy:mock:foo:bar",
+						Status: resource.ScheduleStatusPending,
+					}).
+					Return([]resource.ScheduledAction{
+						{ID: "sched-1", URN: "orn:entropy:mock:foo:bar", Status: resource.ScheduleStatusPending},
+						{ID: "sched-2", URN: "orn:entropy:mock:foo:bar", Cron: "@hourly", Status: resource.ScheduleStatusPending},
+					}, nil).
+					Once()
+				resourceRepo.EXPECT().
+					UpdateSchedule(mock.Anything, mock.Anything).
+					Run(func(ctx context.Context, sa resource.ScheduledAction) {
+						assert.Equal(t, resource.ScheduleStatusCancelled, sa.Status)
+						assert.Equal(t, "resource deleted", sa.Result)
+					}).
+					Return(nil).
+					Twice()
 
 				return core.New(resourceRepo, mod, &mocks.AsyncWorker{}, deadClock, nil)
 			},
@@ -25,17 +25,23 @@ import (
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/protobuf/encoding/protojson"
 
+	"github.com/odpf/entropy/internal/server/serverutils"
+	"github.com/odpf/entropy/internal/server/v1/kinds"
 	modulesv1 "github.com/odpf/entropy/internal/server/v1/modules"
 	resourcesv1 "github.com/odpf/entropy/internal/server/v1/resources"
+	"github.com/odpf/entropy/internal/server/v1/schedules"
+	"github.com/odpf/entropy/internal/server/v1/secrets"
 	"github.com/odpf/entropy/pkg/version"
 )
 
 const defaultGracePeriod = 5 * time.Second
 
 // Serve initialises all the gRPC+HTTP API routes, starts listening for requests at addr, and blocks until server exits.
-// Server exits gracefully when context is cancelled.
+// Server exits gracefully when context is cancelled. Secret fields of resources are revealed only to the callers
+// presenting revealKey.
 func Serve(ctx context.Context, addr string, nrApp *newrelic.Application, logger *zap.Logger,
-	resourceSvc resourcesv1.ResourceService, moduleSvc modulesv1.ModuleService,
+	resourceSvc resourcesv1.ResourceService, moduleSvc modulesv1.ModuleService, kindSvc kinds.KindService,
+	secretSvc secrets.SecretService, scheduleSvc schedules.ScheduleService, revealKey string,
 ) error {
 	grpcOpts := []grpc.ServerOption{
 		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
@@ -47,15 +53,18 @@ func Serve(ctx context.Context, addr string, nrApp *newrelic.Application, logger
 		grpc.StatsHandler(&ocgrpc.ServerHandler{}),
 	}
 	grpcServer := grpc.NewServer(grpcOpts...)
-	rpcHTTPGateway := runtime.NewServeMux(runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
-		MarshalOptions: protojson.MarshalOptions{
-			UseProtoNames:   true,
-			EmitUnpopulated: true,
-		},
-		UnmarshalOptions: protojson.UnmarshalOptions{
-			DiscardUnknown: true,
-		},
-	}))
+	rpcHTTPGateway := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions: protojson.MarshalOptions{
+				UseProtoNames:   true,
+				EmitUnpopulated: true,
+			},
+			UnmarshalOptions: protojson.UnmarshalOptions{
+				DiscardUnknown: true,
+			},
+		}),
+		runtime.WithMetadata(resourcesv1.GatewayMetadata),
+	)
 
 	reflection.Register(grpcServer)
 
@@ -65,7 +74,7 @@ func Serve(ctx context.Context, addr string, nrApp *newrelic.Application, logger
 		return err
 	}
 
-	resourceServiceRPC := resourcesv1.NewAPIServer(resourceSvc)
+	resourceServiceRPC := resourcesv1.NewAPIServer(resourceSvc, serverutils.RevealPolicy(revealKey))
 	grpcServer.RegisterService(&entropyv1beta1.ResourceService_ServiceDesc, resourceServiceRPC)
 	if err := entropyv1beta1.RegisterResourceServiceHandlerServer(ctx, rpcHTTPGateway, resourceServiceRPC); err != nil {
 		return err
@@ -79,6 +88,11 @@ func Serve(ctx context.Context, addr string, nrApp *newrelic.Application, logger
 
 	httpRouter := gorillamux.NewRouter()
 	httpRouter.Use(nrgorilla.Middleware(nrApp))
+	kinds.Register(httpRouter, kindSvc)
+	resourcesv1.Register(httpRouter, resourceSvc)
+	modulesv1.Register(httpRouter, moduleSvc)
+	secrets.Register(httpRouter, secretSvc)
+	schedules.Register(httpRouter, scheduleSvc)
 	httpRouter.PathPrefix("/api/").Handler(http.StripPrefix("/api", rpcHTTPGateway))
 	httpRouter.Handle("/ping", http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
 		_, _ = fmt.Fprintf(wr, "pong")
@@ -0,0 +1,41 @@
+package serverutils
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+// WriteHTTPError writes the error as JSON with the HTTP status equivalent
+// to the given error value.
+func WriteHTTPError(wr http.ResponseWriter, e error) {
+	err := errors.E(e)
+
+	var status int
+	switch {
+	case errors.Is(err, errors.ErrNotFound):
+		status = http.StatusNotFound
+
+	case errors.Is(err, errors.ErrConflict):
+		status = http.StatusConflict
+
+	case errors.Is(err, errors.ErrInvalid):
+		status = http.StatusBadRequest
+
+	default:
+		status = http.StatusInternalServerError
+	}
+
+	WriteJSON(wr, status, map[string]string{
+		"code":    err.Code,
+		"message": err.Error(),
+	})
+}
+
+// WriteJSON writes the value as JSON response with the given status.
+func WriteJSON(wr http.ResponseWriter, status int, v interface{}) {
+	wr.Header().Set("Content-Type", "application/json")
+	wr.WriteHeader(status)
+	_ = json.NewEncoder(wr).Encode(v)
+}
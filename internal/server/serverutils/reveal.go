@@ -0,0 +1,36 @@
+package serverutils
+
+import (
+	"context"
+	"crypto/subtle"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// HeaderRevealKey is the request metadata key for presenting the reveal key.
+// HTTP clients can set it using 'Grpc-Metadata-X-Entropy-Reveal-Key' header.
+const HeaderRevealKey = "x-entropy-reveal-key"
+
+// RevealPolicy returns a function that reports whether the caller is allowed
+// to see the secret values of resources. A caller is allowed only when it
+// presents the given key via request metadata. If the key is empty, nobody
+// is allowed.
+func RevealPolicy(key string) func(ctx context.Context) bool {
+	return func(ctx context.Context) bool {
+		if key == "" {
+			return false
+		}
+
+		md, ok := metadata.FromIncomingContext(ctx)
+		if !ok {
+			return false
+		}
+
+		for _, v := range md.Get(HeaderRevealKey) {
+			if subtle.ConstantTimeCompare([]byte(v), []byte(key)) == 1 {
+				return true
+			}
+		}
+		return false
+	}
+}
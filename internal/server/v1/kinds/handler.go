@@ -0,0 +1,56 @@
+package kinds
+
+//go:generate mockery --name=KindService -r --case underscore --with-expecter --structname KindService --filename=kind_service.go --output=../mocks
+
+import (
+	"context"
+	"net/http"
+
+	gorillamux "github.com/gorilla/mux"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/internal/server/serverutils"
+)
+
+// KindService provides the descriptions of the supported module kinds.
+type KindService interface {
+	ListModuleKinds(ctx context.Context) ([]module.Description, error)
+	GetModuleDescriptor(ctx context.Context, kind string) (*module.Description, error)
+}
+
+// Register adds the routes for module kinds to the router:
+//
+//	GET /api/v1beta1/module-kinds         - list all supported kinds.
+//	GET /api/v1beta1/module-kinds/{kind}  - describe a kind.
+func Register(router *gorillamux.Router, svc KindService) {
+	router.Handle("/api/v1beta1/module-kinds", listKinds(svc)).Methods(http.MethodGet)
+	router.Handle("/api/v1beta1/module-kinds/{kind}", getKind(svc)).Methods(http.MethodGet)
+}
+
+func listKinds(svc KindService) http.HandlerFunc {
+	return func(wr http.ResponseWriter, req *http.Request) {
+		kinds, err := svc.ListModuleKinds(req.Context())
+		if err != nil {
+			serverutils.WriteHTTPError(wr, err)
+			return
+		}
+
+		serverutils.WriteJSON(wr, http.StatusOK, map[string]interface{}{
+			"kinds": kinds,
+		})
+	}
+}
+
+func getKind(svc KindService) http.HandlerFunc {
+	return func(wr http.ResponseWriter, req *http.Request) {
+		desc, err := svc.GetModuleDescriptor(req.Context(), gorillamux.Vars(req)["kind"])
+		if err != nil {
+			serverutils.WriteHTTPError(wr, err)
+			return
+		}
+
+		serverutils.WriteJSON(wr, http.StatusOK, map[string]interface{}{
+			"kind": desc,
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package kinds_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gorillamux "github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/internal/server/v1/kinds"
+	"github.com/odpf/entropy/internal/server/v1/mocks"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+
+	firehose := module.Description{
+		Kind: "firehose",
+		Actions: []module.ActionDescription{
+			{Name: "scale", ParamSchema: json.RawMessage(`{"type":"object"}`)},
+		},
+		Dependencies: map[string]string{"kube_cluster": "kubernetes"},
+	}
+
+	table := []struct {
+		title      string
+		path       string
+		setup      func(t *testing.T) kinds.KindService
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			title: "ListKinds",
+			path:  "/api/v1beta1/module-kinds",
+			setup: func(t *testing.T) kinds.KindService {
+				t.Helper()
+				svc := &mocks.KindService{}
+				svc.EXPECT().
+					ListModuleKinds(mock.Anything).
+					Return([]module.Description{firehose}, nil).Once()
+				return svc
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `{"kinds": [{
+				"kind": "firehose",
+				"actions": [{"name": "scale", "description": "", "param_schema": {"type":"object"}}],
+				"dependencies": {"kube_cluster": "kubernetes"}
+			}]}`,
+		},
+		{
+			title: "GetKind_NotFound",
+			path:  "/api/v1beta1/module-kinds/foo",
+			setup: func(t *testing.T) kinds.KindService {
+				t.Helper()
+				svc := &mocks.KindService{}
+				svc.EXPECT().
+					GetModuleDescriptor(mock.Anything, "foo").
+					Return(nil, errors.ErrNotFound.WithMsgf("module kind 'foo' is not supported")).Once()
+				return svc
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody:   `{"code": "not_found", "message": "module kind 'foo' is not supported"}`,
+		},
+		{
+			title: "GetKind_Success",
+			path:  "/api/v1beta1/module-kinds/firehose",
+			setup: func(t *testing.T) kinds.KindService {
+				t.Helper()
+				svc := &mocks.KindService{}
+				svc.EXPECT().
+					GetModuleDescriptor(mock.Anything, "firehose").
+					Return(&firehose, nil).Once()
+				return svc
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `{"kind": {
+				"kind": "firehose",
+				"actions": [{"name": "scale", "description": "", "param_schema": {"type":"object"}}],
+				"dependencies": {"kube_cluster": "kubernetes"}
+			}}`,
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			router := gorillamux.NewRouter()
+			kinds.Register(router, tt.setup(t))
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
@@ -156,6 +156,53 @@ func (_c *ModuleService_GetModule_Call) Return(_a0 *module.Module, _a1 error) *M
 	return _c
 }
 
+// ListModuleRevisions provides a mock function with given fields: ctx, urn
+func (_m *ModuleService) ListModuleRevisions(ctx context.Context, urn string) ([]module.Revision, error) {
+	ret := _m.Called(ctx, urn)
+
+	var r0 []module.Revision
+	if rf, ok := ret.Get(0).(func(context.Context, string) []module.Revision); ok {
+		r0 = rf(ctx, urn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]module.Revision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, urn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ModuleService_ListModuleRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListModuleRevisions'
+type ModuleService_ListModuleRevisions_Call struct {
+	*mock.Call
+}
+
+// ListModuleRevisions is a helper method to define mock.On call
+//  - ctx context.Context
+//  - urn string
+func (_e *ModuleService_Expecter) ListModuleRevisions(ctx interface{}, urn interface{}) *ModuleService_ListModuleRevisions_Call {
+	return &ModuleService_ListModuleRevisions_Call{Call: _e.mock.On("ListModuleRevisions", ctx, urn)}
+}
+
+func (_c *ModuleService_ListModuleRevisions_Call) Run(run func(ctx context.Context, urn string)) *ModuleService_ListModuleRevisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ModuleService_ListModuleRevisions_Call) Return(_a0 []module.Revision, _a1 error) *ModuleService_ListModuleRevisions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
 // ListModules provides a mock function with given fields: ctx, project
 func (_m *ModuleService) ListModules(ctx context.Context, project string) ([]module.Module, error) {
 	ret := _m.Called(ctx, project)
@@ -203,6 +250,54 @@ func (_c *ModuleService_ListModules_Call) Return(_a0 []module.Module, _a1 error)
 	return _c
 }
 
+// RollbackModule provides a mock function with given fields: ctx, urn, revision
+func (_m *ModuleService) RollbackModule(ctx context.Context, urn string, revision int64) (*module.Module, error) {
+	ret := _m.Called(ctx, urn, revision)
+
+	var r0 *module.Module
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) *module.Module); ok {
+		r0 = rf(ctx, urn, revision)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*module.Module)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, urn, revision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ModuleService_RollbackModule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RollbackModule'
+type ModuleService_RollbackModule_Call struct {
+	*mock.Call
+}
+
+// RollbackModule is a helper method to define mock.On call
+//  - ctx context.Context
+//  - urn string
+//  - revision int64
+func (_e *ModuleService_Expecter) RollbackModule(ctx interface{}, urn interface{}, revision interface{}) *ModuleService_RollbackModule_Call {
+	return &ModuleService_RollbackModule_Call{Call: _e.mock.On("RollbackModule", ctx, urn, revision)}
+}
+
+func (_c *ModuleService_RollbackModule_Call) Run(run func(ctx context.Context, urn string, revision int64)) *ModuleService_RollbackModule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *ModuleService_RollbackModule_Call) Return(_a0 *module.Module, _a1 error) *ModuleService_RollbackModule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
 // UpdateModule provides a mock function with given fields: ctx, urn, newConfigs
 func (_m *ModuleService) UpdateModule(ctx context.Context, urn string, newConfigs json.RawMessage) (*module.Module, error) {
 	ret := _m.Called(ctx, urn, newConfigs)
@@ -9,6 +9,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	resource "github.com/odpf/entropy/core/resource"
+
+	time "time"
 )
 
 // ResourceService is an autogenerated mock type for the ResourceService type
@@ -72,6 +74,53 @@ func (_c *ResourceService_ApplyAction_Call) Return(_a0 *resource.Resource, _a1 e
 	return _c
 }
 
+// ClearExpiry provides a mock function with given fields: ctx, urn
+func (_m *ResourceService) ClearExpiry(ctx context.Context, urn string) (*resource.Resource, error) {
+	ret := _m.Called(ctx, urn)
+
+	var r0 *resource.Resource
+	if rf, ok := ret.Get(0).(func(context.Context, string) *resource.Resource); ok {
+		r0 = rf(ctx, urn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*resource.Resource)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, urn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResourceService_ClearExpiry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClearExpiry'
+type ResourceService_ClearExpiry_Call struct {
+	*mock.Call
+}
+
+// ClearExpiry is a helper method to define mock.On call
+//  - ctx context.Context
+//  - urn string
+func (_e *ResourceService_Expecter) ClearExpiry(ctx interface{}, urn interface{}) *ResourceService_ClearExpiry_Call {
+	return &ResourceService_ClearExpiry_Call{Call: _e.mock.On("ClearExpiry", ctx, urn)}
+}
+
+func (_c *ResourceService_ClearExpiry_Call) Run(run func(ctx context.Context, urn string)) *ResourceService_ClearExpiry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ResourceService_ClearExpiry_Call) Return(_a0 *resource.Resource, _a1 error) *ResourceService_ClearExpiry_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
 // CreateResource provides a mock function with given fields: ctx, res
 func (_m *ResourceService) CreateResource(ctx context.Context, res resource.Resource) (*resource.Resource, error) {
 	ret := _m.Called(ctx, res)
@@ -157,6 +206,54 @@ func (_c *ResourceService_DeleteResource_Call) Return(_a0 error) *ResourceServic
 	return _c
 }
 
+// ExtendExpiry provides a mock function with given fields: ctx, urn, expiresAt
+func (_m *ResourceService) ExtendExpiry(ctx context.Context, urn string, expiresAt time.Time) (*resource.Resource, error) {
+	ret := _m.Called(ctx, urn, expiresAt)
+
+	var r0 *resource.Resource
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) *resource.Resource); ok {
+		r0 = rf(ctx, urn, expiresAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*resource.Resource)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, urn, expiresAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResourceService_ExtendExpiry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExtendExpiry'
+type ResourceService_ExtendExpiry_Call struct {
+	*mock.Call
+}
+
+// ExtendExpiry is a helper method to define mock.On call
+//  - ctx context.Context
+//  - urn string
+//  - expiresAt time.Time
+func (_e *ResourceService_Expecter) ExtendExpiry(ctx interface{}, urn interface{}, expiresAt interface{}) *ResourceService_ExtendExpiry_Call {
+	return &ResourceService_ExtendExpiry_Call{Call: _e.mock.On("ExtendExpiry", ctx, urn, expiresAt)}
+}
+
+func (_c *ResourceService_ExtendExpiry_Call) Run(run func(ctx context.Context, urn string, expiresAt time.Time)) *ResourceService_ExtendExpiry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ResourceService_ExtendExpiry_Call) Return(_a0 *resource.Resource, _a1 error) *ResourceService_ExtendExpiry_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
 // GetLog provides a mock function with given fields: ctx, urn, filter
 func (_m *ResourceService) GetLog(ctx context.Context, urn string, filter map[string]string) (<-chan module.LogChunk, error) {
 	ret := _m.Called(ctx, urn, filter)
@@ -299,6 +396,54 @@ func (_c *ResourceService_GetRevisions_Call) Return(_a0 []resource.Revision, _a1
 	return _c
 }
 
+// GetSecrets provides a mock function with given fields: ctx, kind, project
+func (_m *ResourceService) GetSecrets(ctx context.Context, kind string, project string) (*module.Secrets, error) {
+	ret := _m.Called(ctx, kind, project)
+
+	var r0 *module.Secrets
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *module.Secrets); ok {
+		r0 = rf(ctx, kind, project)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*module.Secrets)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, kind, project)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResourceService_GetSecrets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSecrets'
+type ResourceService_GetSecrets_Call struct {
+	*mock.Call
+}
+
+// GetSecrets is a helper method to define mock.On call
+//  - ctx context.Context
+//  - kind string
+//  - project string
+func (_e *ResourceService_Expecter) GetSecrets(ctx interface{}, kind interface{}, project interface{}) *ResourceService_GetSecrets_Call {
+	return &ResourceService_GetSecrets_Call{Call: _e.mock.On("GetSecrets", ctx, kind, project)}
+}
+
+func (_c *ResourceService_GetSecrets_Call) Run(run func(ctx context.Context, kind string, project string)) *ResourceService_GetSecrets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ResourceService_GetSecrets_Call) Return(_a0 *module.Secrets, _a1 error) *ResourceService_GetSecrets_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
 // ListResources provides a mock function with given fields: ctx, filter
 func (_m *ResourceService) ListResources(ctx context.Context, filter resource.Filter) ([]resource.Resource, error) {
 	ret := _m.Called(ctx, filter)
@@ -0,0 +1,60 @@
+package modules
+
+import (
+	"encoding/json"
+	"net/http"
+
+	gorillamux "github.com/gorilla/mux"
+
+	"github.com/odpf/entropy/internal/server/serverutils"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+// Register adds the routes for operations on modules that are not part of
+// the gRPC API to the router:
+//
+//	GET  /api/v1beta1/modules/{urn}/revisions  - list revisions of the configs of a module.
+//	POST /api/v1beta1/modules/{urn}/rollback   - restore the configs of a module to a revision.
+func Register(router *gorillamux.Router, svc ModuleService) {
+	router.Handle("/api/v1beta1/modules/{urn}/revisions", listRevisions(svc)).Methods(http.MethodGet)
+	router.Handle("/api/v1beta1/modules/{urn}/rollback", rollback(svc)).Methods(http.MethodPost)
+}
+
+func listRevisions(svc ModuleService) http.HandlerFunc {
+	return func(wr http.ResponseWriter, req *http.Request) {
+		revs, err := svc.ListModuleRevisions(req.Context(), gorillamux.Vars(req)["urn"])
+		if err != nil {
+			serverutils.WriteHTTPError(wr, err)
+			return
+		}
+
+		serverutils.WriteJSON(wr, http.StatusOK, map[string]interface{}{
+			"revisions": revs,
+		})
+	}
+}
+
+func rollback(svc ModuleService) http.HandlerFunc {
+	return func(wr http.ResponseWriter, req *http.Request) {
+		var body struct {
+			Revision int64 `json:"revision"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			serverutils.WriteHTTPError(wr, errors.ErrInvalid.WithMsgf("invalid request body: %v", err))
+			return
+		} else if body.Revision <= 0 {
+			serverutils.WriteHTTPError(wr, errors.ErrInvalid.WithMsgf("revision must be a positive number"))
+			return
+		}
+
+		mod, err := svc.RollbackModule(req.Context(), gorillamux.Vars(req)["urn"], body.Revision)
+		if err != nil {
+			serverutils.WriteHTTPError(wr, err)
+			return
+		}
+
+		serverutils.WriteJSON(wr, http.StatusOK, map[string]interface{}{
+			"module": mod,
+		})
+	}
+}
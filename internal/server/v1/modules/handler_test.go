@@ -0,0 +1,140 @@
+package modules_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	gorillamux "github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/internal/server/v1/mocks"
+	"github.com/odpf/entropy/internal/server/v1/modules"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2022, 4, 28, 10, 0, 0, 0, time.UTC)
+
+	table := []struct {
+		title      string
+		method     string
+		path       string
+		body       string
+		setup      func(t *testing.T) modules.ModuleService
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			title:  "ListRevisions",
+			method: http.MethodGet,
+			path:   "/api/v1beta1/modules/orn:entropy:module:bar:firehose/revisions",
+			setup: func(t *testing.T) modules.ModuleService {
+				t.Helper()
+				svc := &mocks.ModuleService{}
+				svc.EXPECT().
+					ListModuleRevisions(mock.Anything, "orn:entropy:module:bar:firehose").
+					Return([]module.Revision{
+						{
+							URN:       "orn:entropy:module:bar:firehose",
+							Revision:  1,
+							Reason:    "create",
+							Configs:   json.RawMessage(`{}`),
+							CreatedAt: createdAt,
+						},
+					}, nil).Once()
+				return svc
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `{"revisions": [{
+				"urn": "orn:entropy:module:bar:firehose",
+				"revision": 1,
+				"reason": "create",
+				"configs": {},
+				"created_at": "2022-04-28T10:00:00Z"
+			}]}`,
+		},
+		{
+			title:  "ListRevisions_NotFound",
+			method: http.MethodGet,
+			path:   "/api/v1beta1/modules/orn:entropy:module:bar:firehose/revisions",
+			setup: func(t *testing.T) modules.ModuleService {
+				t.Helper()
+				svc := &mocks.ModuleService{}
+				svc.EXPECT().
+					ListModuleRevisions(mock.Anything, "orn:entropy:module:bar:firehose").
+					Return(nil, errors.ErrNotFound).Once()
+				return svc
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody:   `{"code": "not_found", "message": "requested entity not found"}`,
+		},
+		{
+			title:  "Rollback",
+			method: http.MethodPost,
+			path:   "/api/v1beta1/modules/orn:entropy:module:bar:firehose/rollback",
+			body:   `{"revision": 1}`,
+			setup: func(t *testing.T) modules.ModuleService {
+				t.Helper()
+				svc := &mocks.ModuleService{}
+				svc.EXPECT().
+					RollbackModule(mock.Anything, "orn:entropy:module:bar:firehose", int64(1)).
+					Return(&module.Module{
+						URN:       "orn:entropy:module:bar:firehose",
+						Name:      "firehose",
+						Project:   "bar",
+						Configs:   json.RawMessage(`{}`),
+						Revision:  3,
+						CreatedAt: createdAt,
+						UpdatedAt: createdAt,
+					}, nil).Once()
+				return svc
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `{"module": {
+				"urn": "orn:entropy:module:bar:firehose",
+				"name": "firehose",
+				"project": "bar",
+				"configs": {},
+				"revision": 3,
+				"created_at": "2022-04-28T10:00:00Z",
+				"updated_at": "2022-04-28T10:00:00Z"
+			}}`,
+		},
+		{
+			title:  "Rollback_InvalidRevision",
+			method: http.MethodPost,
+			path:   "/api/v1beta1/modules/orn:entropy:module:bar:firehose/rollback",
+			body:   `{}`,
+			setup: func(t *testing.T) modules.ModuleService {
+				t.Helper()
+				return &mocks.ModuleService{}
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"code": "bad_request", "message": "revision must be a positive number"}`,
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			router := gorillamux.NewRouter()
+			modules.Register(router, tt.setup(t))
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
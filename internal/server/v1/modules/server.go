@@ -18,6 +18,9 @@ type ModuleService interface {
 	CreateModule(ctx context.Context, mod module.Module) (*module.Module, error)
 	UpdateModule(ctx context.Context, urn string, newConfigs json.RawMessage) (*module.Module, error)
 	DeleteModule(ctx context.Context, urn string) error
+
+	ListModuleRevisions(ctx context.Context, urn string) ([]module.Revision, error)
+	RollbackModule(ctx context.Context, urn string, revision int64) (*module.Module, error)
 }
 
 type APIServer struct {
@@ -0,0 +1,60 @@
+package resources
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	gorillamux "github.com/gorilla/mux"
+
+	"github.com/odpf/entropy/internal/server/serverutils"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+// Register adds the routes for operations on resources that are not part
+// of the gRPC API to the router:
+//
+//	PUT    /api/v1beta1/resources/{urn}/expiry  - extend the expiry of a resource.
+//	DELETE /api/v1beta1/resources/{urn}/expiry  - clear the expiry of a resource.
+func Register(router *gorillamux.Router, svc ResourceService) {
+	router.Handle("/api/v1beta1/resources/{urn}/expiry", extendExpiry(svc)).Methods(http.MethodPut)
+	router.Handle("/api/v1beta1/resources/{urn}/expiry", clearExpiry(svc)).Methods(http.MethodDelete)
+}
+
+func extendExpiry(svc ResourceService) http.HandlerFunc {
+	return func(wr http.ResponseWriter, req *http.Request) {
+		var body struct {
+			ExpiresAt time.Time `json:"expires_at"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			serverutils.WriteHTTPError(wr, errors.ErrInvalid.WithMsgf("invalid request body: %v", err))
+			return
+		}
+
+		res, err := svc.ExtendExpiry(req.Context(), gorillamux.Vars(req)["urn"], body.ExpiresAt)
+		if err != nil {
+			serverutils.WriteHTTPError(wr, err)
+			return
+		}
+
+		serverutils.WriteJSON(wr, http.StatusOK, map[string]interface{}{
+			"urn":        res.URN,
+			"expires_at": res.ExpiresAt,
+		})
+	}
+}
+
+func clearExpiry(svc ResourceService) http.HandlerFunc {
+	return func(wr http.ResponseWriter, req *http.Request) {
+		res, err := svc.ClearExpiry(req.Context(), gorillamux.Vars(req)["urn"])
+		if err != nil {
+			serverutils.WriteHTTPError(wr, err)
+			return
+		}
+
+		serverutils.WriteJSON(wr, http.StatusOK, map[string]interface{}{
+			"urn":        res.URN,
+			"expires_at": res.ExpiresAt,
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package resources
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	gorillamux "github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/internal/server/v1/mocks"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+
+	expiresAt := time.Date(2022, 4, 28, 10, 0, 0, 0, time.UTC)
+
+	table := []struct {
+		title      string
+		method     string
+		path       string
+		body       string
+		setup      func(t *testing.T) ResourceService
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			title:  "ExtendExpiry",
+			method: http.MethodPut,
+			path:   "/api/v1beta1/resources/orn:entropy:firehose:demo:orders/expiry",
+			body:   `{"expires_at": "2022-04-28T10:00:00Z"}`,
+			setup: func(t *testing.T) ResourceService {
+				t.Helper()
+				svc := &mocks.ResourceService{}
+				svc.EXPECT().
+					ExtendExpiry(mock.Anything, "orn:entropy:firehose:demo:orders", expiresAt).
+					Return(&resource.Resource{URN: "orn:entropy:firehose:demo:orders", ExpiresAt: &expiresAt}, nil).Once()
+				return svc
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"urn": "orn:entropy:firehose:demo:orders", "expires_at": "2022-04-28T10:00:00Z"}`,
+		},
+		{
+			title:  "ExtendExpiry_InvalidBody",
+			method: http.MethodPut,
+			path:   "/api/v1beta1/resources/orn:entropy:firehose:demo:orders/expiry",
+			body:   `{"expires_at": `,
+			setup: func(t *testing.T) ResourceService {
+				t.Helper()
+				return &mocks.ResourceService{}
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"code": "bad_request", "message": "invalid request body: unexpected eof"}`,
+		},
+		{
+			title:  "ExtendExpiry_EarlierThanCurrent",
+			method: http.MethodPut,
+			path:   "/api/v1beta1/resources/orn:entropy:firehose:demo:orders/expiry",
+			body:   `{"expires_at": "2022-04-28T10:00:00Z"}`,
+			setup: func(t *testing.T) ResourceService {
+				t.Helper()
+				svc := &mocks.ResourceService{}
+				svc.EXPECT().
+					ExtendExpiry(mock.Anything, "orn:entropy:firehose:demo:orders", expiresAt).
+					Return(nil, errors.ErrInvalid.WithMsgf("expires_at must be later than the current expiry")).Once()
+				return svc
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"code": "bad_request", "message": "expires_at must be later than the current expiry"}`,
+		},
+		{
+			title:  "ClearExpiry",
+			method: http.MethodDelete,
+			path:   "/api/v1beta1/resources/orn:entropy:firehose:demo:orders/expiry",
+			setup: func(t *testing.T) ResourceService {
+				t.Helper()
+				svc := &mocks.ResourceService{}
+				svc.EXPECT().
+					ClearExpiry(mock.Anything, "orn:entropy:firehose:demo:orders").
+					Return(&resource.Resource{URN: "orn:entropy:firehose:demo:orders"}, nil).Once()
+				return svc
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"urn": "orn:entropy:firehose:demo:orders", "expires_at": null}`,
+		},
+		{
+			title:  "ClearExpiry_NotFound",
+			method: http.MethodDelete,
+			path:   "/api/v1beta1/resources/orn:entropy:firehose:demo:orders/expiry",
+			setup: func(t *testing.T) ResourceService {
+				t.Helper()
+				svc := &mocks.ResourceService{}
+				svc.EXPECT().
+					ClearExpiry(mock.Anything, "orn:entropy:firehose:demo:orders").
+					Return(nil, errors.ErrNotFound).Once()
+				return svc
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody:   `{"code": "not_found", "message": "requested entity not found"}`,
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			router := gorillamux.NewRouter()
+			Register(router, tt.setup(t))
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
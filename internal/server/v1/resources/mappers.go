@@ -3,17 +3,37 @@ package resources
 import (
 	"encoding/json"
 	"strconv"
+	"strings"
+	"time"
 
 	entropyv1beta1 "go.buf.build/odpf/gwv/odpf/proton/odpf/entropy/v1beta1"
 	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/odpf/entropy/core/module"
 	"github.com/odpf/entropy/core/resource"
 	"github.com/odpf/entropy/pkg/errors"
 )
 
 const decimalBase = 10
 
+// Labels with reservedLabelPrefix carry the fields of resources that the
+// proto messages have no place for. Clients cannot store such labels.
+const (
+	reservedLabelPrefix = "entropy.odpf.io/"
+
+	// LabelExpiresAt carries the expiry (RFC3339) of the resource. It can
+	// be set on create and update.
+	LabelExpiresAt = reservedLabelPrefix + "expires-at"
+
+	// LabelModuleRevision carries the revision of the module configs the
+	// resource was last synced with. It is read-only.
+	LabelModuleRevision = reservedLabelPrefix + "module-revision"
+)
+
+// withheldJSON replaces configs that cannot be safely rendered.
+var withheldJSON = json.RawMessage("null")
+
 func resourceToProto(res resource.Resource) (*entropyv1beta1.Resource, error) {
 	protoState, err := resourceStateToProto(res.State)
 	if err != nil {
@@ -30,7 +50,7 @@ func resourceToProto(res resource.Resource) (*entropyv1beta1.Resource, error) {
 		Kind:      res.Kind,
 		Project:   res.Project,
 		Name:      res.Name,
-		Labels:    res.Labels,
+		Labels:    labelsToProto(res),
 		CreatedAt: timestamppb.New(res.CreatedAt),
 		UpdatedAt: timestamppb.New(res.UpdatedAt),
 		Spec:      spec,
@@ -90,11 +110,17 @@ func resourceFromProto(res *entropyv1beta1.Resource) (*resource.Resource, error)
 		return nil, err
 	}
 
+	labels, expiresAt, err := labelsFromProto(res.GetLabels())
+	if err != nil {
+		return nil, err
+	}
+
 	return &resource.Resource{
 		URN:       res.GetUrn(),
 		Kind:      res.GetKind(),
 		Name:      res.GetName(),
-		Labels:    res.GetLabels(),
+		Labels:    labels,
+		ExpiresAt: expiresAt,
 		Project:   res.GetProject(),
 		CreatedAt: res.GetCreatedAt().AsTime(),
 		UpdatedAt: res.GetUpdatedAt().AsTime(),
@@ -107,6 +133,56 @@ func resourceFromProto(res *entropyv1beta1.Resource) (*resource.Resource, error)
 	}, nil
 }
 
+// labelsToProto returns the labels of the resource along with the reserved
+// labels for its fields that are not part of the proto message.
+func labelsToProto(res resource.Resource) map[string]string {
+	if res.ExpiresAt == nil && res.State.ModuleRevision == 0 {
+		return res.Labels
+	}
+
+	labels := map[string]string{}
+	for k, v := range res.Labels {
+		labels[k] = v
+	}
+	if res.ExpiresAt != nil {
+		labels[LabelExpiresAt] = res.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	if res.State.ModuleRevision > 0 {
+		labels[LabelModuleRevision] = strconv.FormatInt(res.State.ModuleRevision, decimalBase)
+	}
+	return labels
+}
+
+// labelsFromProto strips the reserved labels from the given labels and
+// returns the expiry, if it is set. Read-only reserved labels are rejected.
+func labelsFromProto(labels map[string]string) (map[string]string, *time.Time, error) {
+	var expiresAt *time.Time
+	userLabels := map[string]string{}
+	for k, v := range labels {
+		if !strings.HasPrefix(k, reservedLabelPrefix) {
+			userLabels[k] = v
+			continue
+		}
+
+		switch k {
+		case LabelExpiresAt:
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return nil, nil, errors.ErrInvalid.WithMsgf("label '%s' must be an RFC3339 time", LabelExpiresAt)
+			}
+			expiresAt = &t
+
+		case LabelModuleRevision:
+			return nil, nil, errors.ErrInvalid.WithMsgf("label '%s' is read-only", LabelModuleRevision)
+		}
+	}
+
+	if labels == nil {
+		userLabels = nil
+	}
+	return userLabels, expiresAt, nil
+}
+
 func resourceSpecFromProto(spec *entropyv1beta1.ResourceSpec) (*resource.Spec, error) {
 	deps := map[string]string{}
 
@@ -129,7 +205,13 @@ func resourceSpecFromProto(spec *entropyv1beta1.ResourceSpec) (*resource.Spec, e
 	}, nil
 }
 
-func revisionToProto(revision resource.Revision) (*entropyv1beta1.ResourceRevision, error) {
+func revisionToProto(revision resource.Revision, secrets module.Secrets) (*entropyv1beta1.ResourceRevision, error) {
+	maskedConfigs, err := module.Redact(revision.Spec.Configs, secrets.Configs)
+	if err != nil {
+		return nil, err
+	}
+	revision.Spec.Configs = maskedConfigs
+
 	spec, err := resourceSpecToProto(revision.Spec)
 	if err != nil {
 		return nil, err
@@ -144,3 +226,26 @@ func revisionToProto(revision resource.Revision) (*entropyv1beta1.ResourceRevisi
 		Spec:      spec,
 	}, nil
 }
+
+func redactedProto(res resource.Resource, secrets module.Secrets) (*entropyv1beta1.Resource, error) {
+	if err := redactResource(&res, secrets); err != nil {
+		return nil, err
+	}
+	return resourceToProto(res)
+}
+
+func redactResource(res *resource.Resource, secrets module.Secrets) error {
+	maskedConfigs, err := module.Redact(res.Spec.Configs, secrets.Configs)
+	if err != nil {
+		return err
+	}
+
+	maskedOutput, err := module.Redact(res.State.Output, secrets.Output)
+	if err != nil {
+		return err
+	}
+
+	res.Spec.Configs = maskedConfigs
+	res.State.Output = maskedOutput
+	return nil
+}
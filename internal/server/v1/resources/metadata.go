@@ -0,0 +1,48 @@
+package resources
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+// HealthFilterKey is the gRPC metadata key to filter resources by health
+// in ListResources. The HTTP gateway sets it from the 'health' query param
+// (see GatewayMetadata).
+//
+// It is a stopgap until ListResourcesRequest has a field for the health,
+// and will be removed then. Clients should prefer the 'health' query param
+// (or the '--health' flag of the CLI), which will keep working.
+const HealthFilterKey = "x-entropy-health"
+
+// GatewayMetadata maps the query params of HTTP gateway requests that the
+// proto requests have no fields for, into gRPC metadata.
+func GatewayMetadata(_ context.Context, req *http.Request) metadata.MD {
+	if health := req.URL.Query().Get("health"); health != "" {
+		return metadata.Pairs(HealthFilterKey, health)
+	}
+	return nil
+}
+
+func healthFilter(ctx context.Context) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(HealthFilterKey)
+	if len(values) == 0 {
+		return "", nil
+	}
+
+	health := strings.ToUpper(strings.TrimSpace(values[0]))
+	switch health {
+	case resource.HealthHealthy, resource.HealthDegraded, resource.HealthUnknown:
+		return health, nil
+
+	default:
+		return "", errors.ErrInvalid.WithMsgf("health must be one of %s, %s or %s",
+			resource.HealthHealthy, resource.HealthDegraded, resource.HealthUnknown)
+	}
+}
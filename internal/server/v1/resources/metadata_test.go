@@ -0,0 +1,42 @@
+package resources
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestGatewayMetadata(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		title string
+		path  string
+		want  metadata.MD
+	}{
+		{
+			title: "HealthFilter",
+			path:  "/v1beta1/resources?project=demo&health=DEGRADED",
+			want:  metadata.Pairs(HealthFilterKey, "DEGRADED"),
+		},
+		{
+			title: "NoFilter",
+			path:  "/v1beta1/resources?project=demo",
+			want:  nil,
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			assert.Equal(t, tt.want, GatewayMetadata(context.Background(), req))
+		})
+	}
+}
@@ -4,6 +4,7 @@ package resources
 
 import (
 	"context"
+	"time"
 
 	entropyv1beta1 "go.buf.build/odpf/gwv/odpf/proton/odpf/entropy/v1beta1"
 
@@ -18,22 +19,34 @@ type ResourceService interface {
 	CreateResource(ctx context.Context, res resource.Resource) (*resource.Resource, error)
 	UpdateResource(ctx context.Context, urn string, req resource.UpdateRequest) (*resource.Resource, error)
 	DeleteResource(ctx context.Context, urn string) error
+	ExtendExpiry(ctx context.Context, urn string, expiresAt time.Time) (*resource.Resource, error)
+	ClearExpiry(ctx context.Context, urn string) (*resource.Resource, error)
 
 	ApplyAction(ctx context.Context, urn string, action module.ActionRequest) (*resource.Resource, error)
 	GetLog(ctx context.Context, urn string, filter map[string]string) (<-chan module.LogChunk, error)
 
 	GetRevisions(ctx context.Context, selector resource.RevisionsSelector) ([]resource.Revision, error)
+	GetSecrets(ctx context.Context, kind, project string) (*module.Secrets, error)
 }
 
 type APIServer struct {
 	entropyv1beta1.UnimplementedResourceServiceServer
 
 	resourceService ResourceService
+	canReveal       func(ctx context.Context) bool
 }
 
-func NewAPIServer(resourceService ResourceService) *APIServer {
+// NewAPIServer returns the gRPC server for resources. Secret fields in
+// resources are masked in all responses unless canReveal returns true for
+// the request. If canReveal is nil, secrets are always masked.
+func NewAPIServer(resourceService ResourceService, canReveal func(ctx context.Context) bool) *APIServer {
+	if canReveal == nil {
+		canReveal = func(ctx context.Context) bool { return false }
+	}
+
 	return &APIServer{
 		resourceService: resourceService,
+		canReveal:       canReveal,
 	}
 }
 
@@ -48,7 +61,7 @@ func (server APIServer) CreateResource(ctx context.Context, request *entropyv1be
 		return nil, serverutils.ToRPCError(err)
 	}
 
-	responseResource, err := resourceToProto(*result)
+	responseResource, err := server.toProto(ctx, *result)
 	if err != nil {
 		return nil, serverutils.ToRPCError(err)
 	}
@@ -64,9 +77,15 @@ func (server APIServer) UpdateResource(ctx context.Context, request *entropyv1be
 		return nil, serverutils.ToRPCError(err)
 	}
 
+	labels, expiresAt, err := labelsFromProto(request.GetLabels())
+	if err != nil {
+		return nil, serverutils.ToRPCError(err)
+	}
+
 	updateRequest := resource.UpdateRequest{
-		Spec:   *newSpec,
-		Labels: request.Labels,
+		Spec:      *newSpec,
+		Labels:    labels,
+		ExpiresAt: expiresAt,
 	}
 
 	res, err := server.resourceService.UpdateResource(ctx, request.GetUrn(), updateRequest)
@@ -74,7 +93,7 @@ func (server APIServer) UpdateResource(ctx context.Context, request *entropyv1be
 		return nil, serverutils.ToRPCError(err)
 	}
 
-	responseResource, err := resourceToProto(*res)
+	responseResource, err := server.toProto(ctx, *res)
 	if err != nil {
 		return nil, serverutils.ToRPCError(err)
 	}
@@ -90,7 +109,7 @@ func (server APIServer) GetResource(ctx context.Context, request *entropyv1beta1
 		return nil, serverutils.ToRPCError(err)
 	}
 
-	responseResource, err := resourceToProto(*res)
+	responseResource, err := server.toProto(ctx, *res)
 	if err != nil {
 		return nil, serverutils.ToRPCError(err)
 	}
@@ -101,10 +120,16 @@ func (server APIServer) GetResource(ctx context.Context, request *entropyv1beta1
 }
 
 func (server APIServer) ListResources(ctx context.Context, request *entropyv1beta1.ListResourcesRequest) (*entropyv1beta1.ListResourcesResponse, error) {
+	health, err := healthFilter(ctx)
+	if err != nil {
+		return nil, serverutils.ToRPCError(err)
+	}
+
 	filter := resource.Filter{
 		Kind:    request.GetKind(),
 		Project: request.GetProject(),
 		Labels:  nil,
+		Health:  health,
 	}
 
 	resources, err := server.resourceService.ListResources(ctx, filter)
@@ -112,9 +137,20 @@ func (server APIServer) ListResources(ctx context.Context, request *entropyv1bet
 		return nil, serverutils.ToRPCError(err)
 	}
 
+	secretsOf := server.secretsLookup(ctx)
+
 	var responseResources []*entropyv1beta1.Resource
 	for _, res := range resources {
-		responseResource, err := resourceToProto(res)
+		secrets, err := secretsOf(res.Kind, res.Project)
+		if err != nil {
+			// secret fields of this kind are unknown. withhold its configs
+			// and outputs instead of failing the whole listing.
+			res.Spec.Configs = withheldJSON
+			res.State.Output = nil
+			secrets = &module.Secrets{}
+		}
+
+		responseResource, err := redactedProto(res, *secrets)
 		if err != nil {
 			return nil, serverutils.ToRPCError(err)
 		}
@@ -152,7 +188,7 @@ func (server APIServer) ApplyAction(ctx context.Context, request *entropyv1beta1
 		return nil, serverutils.ToRPCError(err)
 	}
 
-	responseResource, err := resourceToProto(*updatedRes)
+	responseResource, err := server.toProto(ctx, *updatedRes)
 	if err != nil {
 		return nil, serverutils.ToRPCError(err)
 	}
@@ -200,9 +236,14 @@ func (server APIServer) GetResourceRevisions(ctx context.Context, request *entro
 		return nil, serverutils.ToRPCError(err)
 	}
 
+	secrets, err := server.revisionSecrets(ctx, request.GetUrn())
+	if err != nil {
+		return nil, serverutils.ToRPCError(err)
+	}
+
 	var responseRevisions []*entropyv1beta1.ResourceRevision
 	for _, res := range revisions {
-		responseRevision, err := revisionToProto(res)
+		responseRevision, err := revisionToProto(res, secrets)
 		if err != nil {
 			return nil, serverutils.ToRPCError(err)
 		}
@@ -214,3 +255,56 @@ func (server APIServer) GetResourceRevisions(ctx context.Context, request *entro
 		Revisions: responseRevisions,
 	}, nil
 }
+
+// toProto maps the resource to its proto form, masking the secret fields
+// unless the caller is allowed to see them.
+func (server APIServer) toProto(ctx context.Context, res resource.Resource) (*entropyv1beta1.Resource, error) {
+	secrets, err := server.secretsLookup(ctx)(res.Kind, res.Project)
+	if err != nil {
+		return nil, err
+	}
+	return redactedProto(res, *secrets)
+}
+
+// secretsLookup returns a function that resolves the secret fields of a
+// (kind, project) pair at most once per request. If the caller is allowed
+// to see secrets, no fields are resolved.
+func (server APIServer) secretsLookup(ctx context.Context) func(kind, project string) (*module.Secrets, error) {
+	if server.canReveal(ctx) {
+		return func(_, _ string) (*module.Secrets, error) { return &module.Secrets{}, nil }
+	}
+
+	type lookup struct {
+		secrets *module.Secrets
+		err     error
+	}
+	resolved := map[string]lookup{}
+
+	return func(kind, project string) (*module.Secrets, error) {
+		key := kind + "/" + project
+		if l, found := resolved[key]; found {
+			return l.secrets, l.err
+		}
+
+		secrets, err := server.resourceService.GetSecrets(ctx, kind, project)
+		resolved[key] = lookup{secrets: secrets, err: err}
+		return secrets, err
+	}
+}
+
+func (server APIServer) revisionSecrets(ctx context.Context, urn string) (module.Secrets, error) {
+	if server.canReveal(ctx) {
+		return module.Secrets{}, nil
+	}
+
+	kind, project, _, err := resource.ParseURN(urn)
+	if err != nil {
+		return module.Secrets{}, err
+	}
+
+	secrets, err := server.resourceService.GetSecrets(ctx, kind, project)
+	if err != nil {
+		return module.Secrets{}, err
+	}
+	return *secrets, nil
+}
@@ -12,11 +12,13 @@ import (
 	"github.com/stretchr/testify/require"
 	entropyv1beta1 "go.buf.build/odpf/gwv/odpf/proton/odpf/entropy/v1beta1"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/testing/protocmp"
 	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/odpf/entropy/core/module"
 	"github.com/odpf/entropy/core/resource"
 	"github.com/odpf/entropy/internal/server/v1/mocks"
 	"github.com/odpf/entropy/pkg/errors"
@@ -26,6 +28,7 @@ func TestAPIServer_CreateResource(t *testing.T) {
 	t.Parallel()
 
 	createdAt := time.Now()
+	expiresAt := time.Date(2022, 4, 28, 10, 0, 0, 0, time.UTC)
 
 	configsStructValue := &structpb.Value{}
 	require.NoError(t, json.Unmarshal([]byte(`{"replicas": "10"}`), &configsStructValue))
@@ -45,7 +48,7 @@ func TestAPIServer_CreateResource(t *testing.T) {
 				resourceService.EXPECT().
 					CreateResource(mock.Anything, mock.Anything).
 					Return(nil, errors.ErrConflict).Once()
-				return NewAPIServer(resourceService)
+				return NewAPIServer(resourceService, nil)
 			},
 			request: &entropyv1beta1.CreateResourceRequest{
 				Resource: &entropyv1beta1.Resource{
@@ -70,7 +73,7 @@ func TestAPIServer_CreateResource(t *testing.T) {
 					CreateResource(mock.Anything, mock.Anything).
 					Return(nil, errors.ErrInvalid).Once()
 
-				return NewAPIServer(resourceService)
+				return NewAPIServer(resourceService, nil)
 			},
 			request: &entropyv1beta1.CreateResourceRequest{
 				Resource: &entropyv1beta1.Resource{
@@ -86,6 +89,114 @@ func TestAPIServer_CreateResource(t *testing.T) {
 			want:    nil,
 			wantErr: status.Errorf(codes.InvalidArgument, "request is not valid"),
 		},
+		{
+			name: "InvalidExpiryLabel",
+			setup: func(t *testing.T) *APIServer {
+				t.Helper()
+				return NewAPIServer(&mocks.ResourceService{}, nil)
+			},
+			request: &entropyv1beta1.CreateResourceRequest{
+				Resource: &entropyv1beta1.Resource{
+					Name:    "testname",
+					Project: "p-testdata-gl",
+					Kind:    "log",
+					Spec: &entropyv1beta1.ResourceSpec{
+						Configs: configsStructValue,
+					},
+					Labels: map[string]string{LabelExpiresAt: "tomorrow"},
+				},
+			},
+			want:    nil,
+			wantErr: status.Error(codes.InvalidArgument, "label 'entropy.odpf.io/expires-at' must be an rfc3339 time"),
+		},
+		{
+			name: "ModuleRevisionLabel",
+			setup: func(t *testing.T) *APIServer {
+				t.Helper()
+				return NewAPIServer(&mocks.ResourceService{}, nil)
+			},
+			request: &entropyv1beta1.CreateResourceRequest{
+				Resource: &entropyv1beta1.Resource{
+					Name:    "testname",
+					Project: "p-testdata-gl",
+					Kind:    "log",
+					Spec: &entropyv1beta1.ResourceSpec{
+						Configs: configsStructValue,
+					},
+					Labels: map[string]string{LabelModuleRevision: "2"},
+				},
+			},
+			want:    nil,
+			wantErr: status.Error(codes.InvalidArgument, "label 'entropy.odpf.io/module-revision' is read-only"),
+		},
+		{
+			name: "WithExpiry",
+			setup: func(t *testing.T) *APIServer {
+				t.Helper()
+				resourceService := &mocks.ResourceService{}
+				resourceService.EXPECT().
+					CreateResource(mock.Anything, mock.Anything).
+					Run(func(ctx context.Context, res resource.Resource) {
+						assert.Equal(t, map[string]string{"team": "data"}, res.Labels)
+						assert.True(t, expiresAt.Equal(*res.ExpiresAt))
+					}).
+					Return(&resource.Resource{
+						URN:       "p-testdata-gl-testname-log",
+						Kind:      "log",
+						Name:      "testname",
+						Project:   "p-testdata-gl",
+						Labels:    map[string]string{"team": "data"},
+						CreatedAt: createdAt,
+						UpdatedAt: createdAt,
+						ExpiresAt: &expiresAt,
+						Spec: resource.Spec{
+							Configs: []byte(`{"replicas": "10"}`),
+						},
+						State: resource.State{
+							Status: resource.StatusPending,
+						},
+					}, nil).Once()
+				resourceService.EXPECT().
+					GetSecrets(mock.Anything, "log", "p-testdata-gl").
+					Return(&module.Secrets{}, nil).Once()
+
+				return NewAPIServer(resourceService, nil)
+			},
+			request: &entropyv1beta1.CreateResourceRequest{
+				Resource: &entropyv1beta1.Resource{
+					Name:    "testname",
+					Project: "p-testdata-gl",
+					Kind:    "log",
+					Spec: &entropyv1beta1.ResourceSpec{
+						Configs: configsStructValue,
+					},
+					Labels: map[string]string{
+						"team":         "data",
+						LabelExpiresAt: "2022-04-28T10:00:00Z",
+					},
+				},
+			},
+			want: &entropyv1beta1.CreateResourceResponse{
+				Resource: &entropyv1beta1.Resource{
+					Urn:     "p-testdata-gl-testname-log",
+					Kind:    "log",
+					Name:    "testname",
+					Project: "p-testdata-gl",
+					Labels: map[string]string{
+						"team":         "data",
+						LabelExpiresAt: "2022-04-28T10:00:00Z",
+					},
+					CreatedAt: timestamppb.New(createdAt),
+					UpdatedAt: timestamppb.New(createdAt),
+					Spec: &entropyv1beta1.ResourceSpec{
+						Configs: configsStructValue,
+					},
+					State: &entropyv1beta1.ResourceState{
+						Status: entropyv1beta1.ResourceState_STATUS_PENDING,
+					},
+				},
+			},
+		},
 		{
 			name: "Success",
 			setup: func(t *testing.T) *APIServer {
@@ -108,8 +219,11 @@ func TestAPIServer_CreateResource(t *testing.T) {
 							Status: resource.StatusPending,
 						},
 					}, nil).Once()
+				resourceService.EXPECT().
+					GetSecrets(mock.Anything, "log", "p-testdata-gl").
+					Return(&module.Secrets{}, nil).Once()
 
-				return NewAPIServer(resourceService)
+				return NewAPIServer(resourceService, nil)
 			},
 			request: &entropyv1beta1.CreateResourceRequest{
 				Resource: &entropyv1beta1.Resource{
@@ -186,7 +300,7 @@ func TestAPIServer_UpdateResource(t *testing.T) {
 				resourceService.EXPECT().
 					UpdateResource(mock.Anything, "p-testdata-gl-testname-log", mock.Anything).
 					Return(nil, errors.ErrNotFound).Once()
-				return NewAPIServer(resourceService)
+				return NewAPIServer(resourceService, nil)
 			},
 			request: &entropyv1beta1.UpdateResourceRequest{
 				Urn: "p-testdata-gl-testname-log",
@@ -205,7 +319,7 @@ func TestAPIServer_UpdateResource(t *testing.T) {
 				resourceService.EXPECT().
 					UpdateResource(mock.Anything, "p-testdata-gl-testname-log", mock.Anything).
 					Return(nil, errors.ErrInvalid).Once()
-				return NewAPIServer(resourceService)
+				return NewAPIServer(resourceService, nil)
 			},
 			request: &entropyv1beta1.UpdateResourceRequest{
 				Urn: "p-testdata-gl-testname-log",
@@ -216,6 +330,52 @@ func TestAPIServer_UpdateResource(t *testing.T) {
 			want:    nil,
 			wantErr: status.Errorf(codes.InvalidArgument, "request is not valid"),
 		},
+		{
+			name: "ModuleRevisionLabel",
+			setup: func(t *testing.T) *APIServer {
+				t.Helper()
+				return NewAPIServer(&mocks.ResourceService{}, nil)
+			},
+			request: &entropyv1beta1.UpdateResourceRequest{
+				Urn: "p-testdata-gl-testname-log",
+				NewSpec: &entropyv1beta1.ResourceSpec{
+					Configs: configsStructValue,
+				},
+				Labels: map[string]string{LabelModuleRevision: "2"},
+			},
+			want:    nil,
+			wantErr: status.Error(codes.InvalidArgument, "label 'entropy.odpf.io/module-revision' is read-only"),
+		},
+		{
+			name: "WithExpiry",
+			setup: func(t *testing.T) *APIServer {
+				t.Helper()
+				expiresAt := time.Date(2022, 4, 28, 10, 0, 0, 0, time.UTC)
+				wantReq := resource.UpdateRequest{
+					Spec:      resource.Spec{Configs: []byte(`{"replicas":"10"}`), Dependencies: map[string]string{}},
+					Labels:    map[string]string{"reason": "load-test"},
+					ExpiresAt: &expiresAt,
+				}
+
+				resourceService := &mocks.ResourceService{}
+				resourceService.EXPECT().
+					UpdateResource(mock.Anything, "p-testdata-gl-testname-log", wantReq).
+					Return(nil, errors.ErrInvalid).Once()
+				return NewAPIServer(resourceService, nil)
+			},
+			request: &entropyv1beta1.UpdateResourceRequest{
+				Urn: "p-testdata-gl-testname-log",
+				NewSpec: &entropyv1beta1.ResourceSpec{
+					Configs: configsStructValue,
+				},
+				Labels: map[string]string{
+					"reason":       "load-test",
+					LabelExpiresAt: "2022-04-28T10:00:00Z",
+				},
+			},
+			want:    nil,
+			wantErr: status.Errorf(codes.InvalidArgument, "request is not valid"),
+		},
 		{
 			name: "Success",
 			setup: func(t *testing.T) *APIServer {
@@ -238,8 +398,11 @@ func TestAPIServer_UpdateResource(t *testing.T) {
 							Status: resource.StatusPending,
 						},
 					}, nil).Once()
+				resourceService.EXPECT().
+					GetSecrets(mock.Anything, "log", "p-testdata-gl").
+					Return(&module.Secrets{}, nil).Once()
 
-				return NewAPIServer(resourceService)
+				return NewAPIServer(resourceService, nil)
 			},
 			request: &entropyv1beta1.UpdateResourceRequest{
 				Urn: "p-testdata-gl-testname-log",
@@ -296,6 +459,12 @@ func TestAPIServer_GetResource(t *testing.T) {
 	configsStructValue := &structpb.Value{}
 	require.NoError(t, json.Unmarshal([]byte(`{"replicas": "10"}`), &configsStructValue))
 
+	maskedConfigs := &structpb.Value{}
+	require.NoError(t, json.Unmarshal([]byte(`{"replicas": "10", "token": "********"}`), &maskedConfigs))
+
+	maskedOutput := &structpb.Value{}
+	require.NoError(t, json.Unmarshal([]byte(`{"conf": {"token": "********"}}`), &maskedOutput))
+
 	tests := []struct {
 		name    string
 		setup   func(t *testing.T) *APIServer
@@ -311,7 +480,7 @@ func TestAPIServer_GetResource(t *testing.T) {
 				resourceService.EXPECT().
 					GetResource(mock.Anything, "p-testdata-gl-testname-log").
 					Return(nil, errors.ErrNotFound).Once()
-				return NewAPIServer(resourceService)
+				return NewAPIServer(resourceService, nil)
 			},
 			request: &entropyv1beta1.GetResourceRequest{
 				Urn: "p-testdata-gl-testname-log",
@@ -341,8 +510,11 @@ func TestAPIServer_GetResource(t *testing.T) {
 							Status: resource.StatusPending,
 						},
 					}, nil).Once()
+				resourceService.EXPECT().
+					GetSecrets(mock.Anything, "log", "p-testdata-gl").
+					Return(&module.Secrets{}, nil).Once()
 
-				return NewAPIServer(resourceService)
+				return NewAPIServer(resourceService, nil)
 			},
 			request: &entropyv1beta1.GetResourceRequest{
 				Urn: "p-testdata-gl-testname-log",
@@ -365,6 +537,113 @@ func TestAPIServer_GetResource(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "WithModuleRevision",
+			setup: func(t *testing.T) *APIServer {
+				t.Helper()
+				resourceService := &mocks.ResourceService{}
+				resourceService.EXPECT().
+					GetResource(mock.Anything, "p-testdata-gl-testname-log").
+					Return(&resource.Resource{
+						URN:       "p-testdata-gl-testname-log",
+						Kind:      "log",
+						Name:      "testname",
+						Project:   "p-testdata-gl",
+						Labels:    map[string]string{"team": "data"},
+						CreatedAt: createdAt,
+						UpdatedAt: updatedAt,
+						Spec: resource.Spec{
+							Configs: []byte(`{"replicas": "10"}`),
+						},
+						State: resource.State{
+							Status:         resource.StatusCompleted,
+							ModuleRevision: 3,
+						},
+					}, nil).Once()
+				resourceService.EXPECT().
+					GetSecrets(mock.Anything, "log", "p-testdata-gl").
+					Return(&module.Secrets{}, nil).Once()
+
+				return NewAPIServer(resourceService, nil)
+			},
+			request: &entropyv1beta1.GetResourceRequest{
+				Urn: "p-testdata-gl-testname-log",
+			},
+			want: &entropyv1beta1.GetResourceResponse{
+				Resource: &entropyv1beta1.Resource{
+					Urn:  "p-testdata-gl-testname-log",
+					Kind: "log",
+					Name: "testname",
+					Labels: map[string]string{
+						"team":              "data",
+						LabelModuleRevision: "3",
+					},
+					Project:   "p-testdata-gl",
+					CreatedAt: timestamppb.New(createdAt),
+					UpdatedAt: timestamppb.New(updatedAt),
+					Spec: &entropyv1beta1.ResourceSpec{
+						Configs: configsStructValue,
+					},
+					State: &entropyv1beta1.ResourceState{
+						Status: entropyv1beta1.ResourceState_STATUS_COMPLETED,
+					},
+				},
+			},
+		},
+		{
+			name: "SecretsMasked",
+			setup: func(t *testing.T) *APIServer {
+				t.Helper()
+				resourceService := &mocks.ResourceService{}
+				resourceService.EXPECT().
+					GetResource(mock.Anything, "p-testdata-gl-testname-log").
+					Return(&resource.Resource{
+						URN:       "p-testdata-gl-testname-log",
+						Kind:      "log",
+						Name:      "testname",
+						Project:   "p-testdata-gl",
+						Labels:    nil,
+						CreatedAt: createdAt,
+						UpdatedAt: updatedAt,
+						Spec: resource.Spec{
+							Configs: []byte(`{"replicas": "10", "token": "foo"}`),
+						},
+						State: resource.State{
+							Status: resource.StatusCompleted,
+							Output: []byte(`{"conf": {"token": "foo"}}`),
+						},
+					}, nil).Once()
+				resourceService.EXPECT().
+					GetSecrets(mock.Anything, "log", "p-testdata-gl").
+					Return(&module.Secrets{
+						Configs: []string{"token"},
+						Output:  []string{"conf.token"},
+					}, nil).Once()
+
+				return NewAPIServer(resourceService, nil)
+			},
+			request: &entropyv1beta1.GetResourceRequest{
+				Urn: "p-testdata-gl-testname-log",
+			},
+			want: &entropyv1beta1.GetResourceResponse{
+				Resource: &entropyv1beta1.Resource{
+					Urn:       "p-testdata-gl-testname-log",
+					Kind:      "log",
+					Name:      "testname",
+					Labels:    nil,
+					Project:   "p-testdata-gl",
+					CreatedAt: timestamppb.New(createdAt),
+					UpdatedAt: timestamppb.New(updatedAt),
+					Spec: &entropyv1beta1.ResourceSpec{
+						Configs: maskedConfigs,
+					},
+					State: &entropyv1beta1.ResourceState{
+						Status: entropyv1beta1.ResourceState_STATUS_COMPLETED,
+						Output: maskedOutput,
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -412,7 +691,7 @@ func TestAPIServer_ListResources(t *testing.T) {
 					ListResources(mock.Anything, mock.Anything).
 					Return(nil, errors.New("failed")).Once()
 
-				return NewAPIServer(resourceService)
+				return NewAPIServer(resourceService, nil)
 			},
 			request: &entropyv1beta1.ListResourcesRequest{
 				Project: "p-testdata-gl",
@@ -445,8 +724,11 @@ func TestAPIServer_ListResources(t *testing.T) {
 							},
 						},
 					}, nil).Once()
+				resourceService.EXPECT().
+					GetSecrets(mock.Anything, "log", "p-testdata-gl").
+					Return(&module.Secrets{}, nil).Once()
 
-				return NewAPIServer(resourceService)
+				return NewAPIServer(resourceService, nil)
 			},
 			request: &entropyv1beta1.ListResourcesRequest{
 				Project: "p-testdata-gl",
@@ -472,6 +754,77 @@ func TestAPIServer_ListResources(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "SecretsResolvedOncePerKind",
+			setup: func(t *testing.T) *APIServer {
+				t.Helper()
+				res := func(name, kind string, configs string) resource.Resource {
+					return resource.Resource{
+						URN:       "p-testdata-gl-" + name + "-" + kind,
+						Kind:      kind,
+						Name:      name,
+						Project:   "p-testdata-gl",
+						CreatedAt: createdAt,
+						UpdatedAt: updatedAt,
+						Spec:      resource.Spec{Configs: []byte(configs)},
+						State:     resource.State{Status: resource.StatusPending, Output: []byte(`{"token": "secret"}`)},
+					}
+				}
+
+				resourceService := &mocks.ResourceService{}
+				resourceService.EXPECT().
+					ListResources(mock.Anything, mock.Anything).
+					Return([]resource.Resource{
+						res("a", "log", `{"replicas": "10", "password": "secret"}`),
+						res("b", "log", `{"replicas": "10", "password": "secret"}`),
+						res("c", "firehose", `{"replicas": "10"}`),
+					}, nil).Once()
+				resourceService.EXPECT().
+					GetSecrets(mock.Anything, "log", "p-testdata-gl").
+					Return(&module.Secrets{Configs: []string{"password"}, Output: []string{"token"}}, nil).Once()
+				resourceService.EXPECT().
+					GetSecrets(mock.Anything, "firehose", "p-testdata-gl").
+					Return(nil, errors.New("failed")).Once()
+
+				return NewAPIServer(resourceService, nil)
+			},
+			request: &entropyv1beta1.ListResourcesRequest{
+				Project: "p-testdata-gl",
+			},
+			want: func() *entropyv1beta1.ListResourcesResponse {
+				proto := func(name, kind string, configs, output *structpb.Value) *entropyv1beta1.Resource {
+					return &entropyv1beta1.Resource{
+						Urn:       "p-testdata-gl-" + name + "-" + kind,
+						Kind:      kind,
+						Name:      name,
+						Project:   "p-testdata-gl",
+						CreatedAt: timestamppb.New(createdAt),
+						UpdatedAt: timestamppb.New(updatedAt),
+						Spec:      &entropyv1beta1.ResourceSpec{Configs: configs},
+						State: &entropyv1beta1.ResourceState{
+							Status: entropyv1beta1.ResourceState_STATUS_PENDING,
+							Output: output,
+						},
+					}
+				}
+
+				masked := structpb.NewStructValue(&structpb.Struct{Fields: map[string]*structpb.Value{
+					"replicas": structpb.NewStringValue("10"),
+					"password": structpb.NewStringValue(module.RedactedValue),
+				}})
+				maskedOutput := structpb.NewStructValue(&structpb.Struct{Fields: map[string]*structpb.Value{
+					"token": structpb.NewStringValue(module.RedactedValue),
+				}})
+
+				return &entropyv1beta1.ListResourcesResponse{
+					Resources: []*entropyv1beta1.Resource{
+						proto("a", "log", masked, maskedOutput),
+						proto("b", "log", masked, maskedOutput),
+						proto("c", "firehose", structpb.NewNullValue(), nil),
+					},
+				}
+			}(),
+		},
 	}
 
 	for _, tt := range tests {
@@ -494,6 +847,38 @@ func TestAPIServer_ListResources(t *testing.T) {
 	}
 }
 
+func TestAPIServer_ListResources_HealthFilter(t *testing.T) {
+	t.Parallel()
+
+	request := &entropyv1beta1.ListResourcesRequest{Project: "demo", Kind: "firehose"}
+
+	t.Run("Filtered", func(t *testing.T) {
+		t.Parallel()
+
+		resourceService := &mocks.ResourceService{}
+		resourceService.EXPECT().
+			ListResources(mock.Anything, resource.Filter{Kind: "firehose", Project: "demo", Health: resource.HealthDegraded}).
+			Return(nil, nil).Once()
+		srv := NewAPIServer(resourceService, nil)
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(HealthFilterKey, "degraded"))
+		got, err := srv.ListResources(ctx, request)
+		assert.NoError(t, err)
+		assert.Empty(t, got.GetResources())
+		resourceService.AssertExpectations(t)
+	})
+
+	t.Run("InvalidHealth", func(t *testing.T) {
+		t.Parallel()
+
+		srv := NewAPIServer(&mocks.ResourceService{}, nil)
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(HealthFilterKey, "sick"))
+		_, err := srv.ListResources(ctx, request)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
 func TestAPIServer_DeleteResource(t *testing.T) {
 	t.Parallel()
 
@@ -512,7 +897,7 @@ func TestAPIServer_DeleteResource(t *testing.T) {
 				resourceService.EXPECT().
 					DeleteResource(mock.Anything, "p-testdata-gl-testname-log").
 					Return(errors.ErrNotFound).Once()
-				return NewAPIServer(resourceService)
+				return NewAPIServer(resourceService, nil)
 			},
 			request: &entropyv1beta1.DeleteResourceRequest{
 				Urn: "p-testdata-gl-testname-log",
@@ -529,7 +914,7 @@ func TestAPIServer_DeleteResource(t *testing.T) {
 					DeleteResource(mock.Anything, "p-testdata-gl-testname-log").
 					Return(nil).Once()
 
-				return NewAPIServer(resourceService)
+				return NewAPIServer(resourceService, nil)
 			},
 			request: &entropyv1beta1.DeleteResourceRequest{
 				Urn: "p-testdata-gl-testname-log",
@@ -582,7 +967,7 @@ func TestAPIServer_ApplyAction(t *testing.T) {
 				resourceService.EXPECT().
 					ApplyAction(mock.Anything, "p-testdata-gl-testname-log", mock.Anything).
 					Return(nil, errors.ErrNotFound).Once()
-				return NewAPIServer(resourceService)
+				return NewAPIServer(resourceService, nil)
 			},
 			request: &entropyv1beta1.ApplyActionRequest{
 				Urn:    "p-testdata-gl-testname-log",
@@ -613,8 +998,11 @@ func TestAPIServer_ApplyAction(t *testing.T) {
 							Status: resource.StatusPending,
 						},
 					}, nil).Once()
+				resourceService.EXPECT().
+					GetSecrets(mock.Anything, "log", "p-testdata-gl").
+					Return(&module.Secrets{}, nil).Once()
 
-				return NewAPIServer(resourceService)
+				return NewAPIServer(resourceService, nil)
 			},
 			request: &entropyv1beta1.ApplyActionRequest{
 				Urn:    "p-testdata-gl-testname-log",
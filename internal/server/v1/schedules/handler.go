@@ -0,0 +1,122 @@
+package schedules
+
+//go:generate mockery --name=ScheduleService -r --case underscore --with-expecter --structname ScheduleService --filename=schedule_service.go --output=../mocks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	gorillamux "github.com/gorilla/mux"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/internal/server/serverutils"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+// ScheduleService manages the actions scheduled on resources.
+type ScheduleService interface {
+	ScheduleAction(ctx context.Context, urn string, act module.ActionRequest, runAt time.Time) (*resource.ScheduledAction, error)
+	ScheduleRecurringAction(ctx context.Context, urn string, act module.ActionRequest, cronExpr string) (*resource.ScheduledAction, error)
+	ListScheduledActions(ctx context.Context, selector resource.ScheduleSelector) ([]resource.ScheduledAction, error)
+	ListScheduleRuns(ctx context.Context, id string) ([]resource.ScheduleRun, error)
+	CancelScheduledAction(ctx context.Context, id string) (*resource.ScheduledAction, error)
+}
+
+// Register adds the routes for scheduled actions to the router:
+//
+//	GET  /api/v1beta1/schedules              - list scheduled actions, by 'urn' and 'status'.
+//	POST /api/v1beta1/schedules              - schedule an action, once or recurring.
+//	GET  /api/v1beta1/schedules/{id}/runs    - list the runs of a scheduled action.
+//	POST /api/v1beta1/schedules/{id}/cancel  - cancel a pending scheduled action.
+func Register(router *gorillamux.Router, svc ScheduleService) {
+	router.Handle("/api/v1beta1/schedules", listSchedules(svc)).Methods(http.MethodGet)
+	router.Handle("/api/v1beta1/schedules", createSchedule(svc)).Methods(http.MethodPost)
+	router.Handle("/api/v1beta1/schedules/{id}/runs", listScheduleRuns(svc)).Methods(http.MethodGet)
+	router.Handle("/api/v1beta1/schedules/{id}/cancel", cancelSchedule(svc)).Methods(http.MethodPost)
+}
+
+func listSchedules(svc ScheduleService) http.HandlerFunc {
+	return func(wr http.ResponseWriter, req *http.Request) {
+		query := req.URL.Query()
+		schedules, err := svc.ListScheduledActions(req.Context(), resource.ScheduleSelector{
+			URN:    query.Get("urn"),
+			Status: query.Get("status"),
+		})
+		if err != nil {
+			serverutils.WriteHTTPError(wr, err)
+			return
+		}
+
+		serverutils.WriteJSON(wr, http.StatusOK, map[string]interface{}{
+			"schedules": schedules,
+		})
+	}
+}
+
+func createSchedule(svc ScheduleService) http.HandlerFunc {
+	return func(wr http.ResponseWriter, req *http.Request) {
+		var body struct {
+			URN    string          `json:"urn"`
+			Action string          `json:"action"`
+			Params json.RawMessage `json:"params"`
+			RunAt  *time.Time      `json:"run_at"`
+			Cron   string          `json:"cron"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			serverutils.WriteHTTPError(wr, errors.ErrInvalid.WithMsgf("invalid request body: %v", err))
+			return
+		} else if (body.RunAt == nil) == (body.Cron == "") {
+			serverutils.WriteHTTPError(wr, errors.ErrInvalid.WithMsgf("exactly one of run_at or cron must be set"))
+			return
+		}
+
+		act := module.ActionRequest{Name: body.Action, Params: body.Params}
+
+		var sa *resource.ScheduledAction
+		var err error
+		if body.Cron != "" {
+			sa, err = svc.ScheduleRecurringAction(req.Context(), body.URN, act, body.Cron)
+		} else {
+			sa, err = svc.ScheduleAction(req.Context(), body.URN, act, *body.RunAt)
+		}
+		if err != nil {
+			serverutils.WriteHTTPError(wr, err)
+			return
+		}
+
+		serverutils.WriteJSON(wr, http.StatusCreated, map[string]interface{}{
+			"schedule": sa,
+		})
+	}
+}
+
+func listScheduleRuns(svc ScheduleService) http.HandlerFunc {
+	return func(wr http.ResponseWriter, req *http.Request) {
+		runs, err := svc.ListScheduleRuns(req.Context(), gorillamux.Vars(req)["id"])
+		if err != nil {
+			serverutils.WriteHTTPError(wr, err)
+			return
+		}
+
+		serverutils.WriteJSON(wr, http.StatusOK, map[string]interface{}{
+			"runs": runs,
+		})
+	}
+}
+
+func cancelSchedule(svc ScheduleService) http.HandlerFunc {
+	return func(wr http.ResponseWriter, req *http.Request) {
+		sa, err := svc.CancelScheduledAction(req.Context(), gorillamux.Vars(req)["id"])
+		if err != nil {
+			serverutils.WriteHTTPError(wr, err)
+			return
+		}
+
+		serverutils.WriteJSON(wr, http.StatusOK, map[string]interface{}{
+			"schedule": sa,
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package schedules_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	gorillamux "github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/internal/server/v1/mocks"
+	"github.com/odpf/entropy/internal/server/v1/schedules"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+
+	runAt := time.Date(2022, 4, 21, 22, 0, 0, 0, time.UTC)
+	sampleSchedule := resource.ScheduledAction{
+		ID:     "sched-1",
+		URN:    "orn:entropy:firehose:demo:orders",
+		Action: "scale",
+		Params: []byte(`{"replicas":0}`),
+		RunAt:  runAt,
+		Status: resource.ScheduleStatusPending,
+	}
+	sampleJSON := `{
+		"id": "sched-1",
+		"urn": "orn:entropy:firehose:demo:orders",
+		"action": "scale",
+		"params": {"replicas": 0},
+		"labels": null,
+		"run_at": "2022-04-21T22:00:00Z",
+		"status": "PENDING",
+		"created_at": "0001-01-01T00:00:00Z",
+		"updated_at": "0001-01-01T00:00:00Z"
+	}`
+
+	table := []struct {
+		title      string
+		method     string
+		path       string
+		body       string
+		setup      func(t *testing.T) schedules.ScheduleService
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			title:  "ListSchedules",
+			method: http.MethodGet,
+			path:   "/api/v1beta1/schedules?urn=orn:entropy:firehose:demo:orders&status=PENDING",
+			setup: func(t *testing.T) schedules.ScheduleService {
+				t.Helper()
+				svc := &mocks.ScheduleService{}
+				svc.EXPECT().
+					ListScheduledActions(mock.Anything, resource.ScheduleSelector{
+						URN:    "orn:entropy:firehose:demo:orders",
+						Status: resource.ScheduleStatusPending,
+					}).
+					Return([]resource.ScheduledAction{sampleSchedule}, nil).Once()
+				return svc
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"schedules": [` + sampleJSON + `]}`,
+		},
+		{
+			title:  "CreateSchedule_Once",
+			method: http.MethodPost,
+			path:   "/api/v1beta1/schedules",
+			body:   `{"urn": "orn:entropy:firehose:demo:orders", "action": "scale", "params": {"replicas": 0}, "run_at": "2022-04-21T22:00:00Z"}`,
+			setup: func(t *testing.T) schedules.ScheduleService {
+				t.Helper()
+				svc := &mocks.ScheduleService{}
+				svc.EXPECT().
+					ScheduleAction(mock.Anything, "orn:entropy:firehose:demo:orders",
+						module.ActionRequest{Name: "scale", Params: []byte(`{"replicas": 0}`)}, runAt).
+					Return(&sampleSchedule, nil).Once()
+				return svc
+			},
+			wantStatus: http.StatusCreated,
+			wantBody:   `{"schedule": ` + sampleJSON + `}`,
+		},
+		{
+			title:  "CreateSchedule_Recurring",
+			method: http.MethodPost,
+			path:   "/api/v1beta1/schedules",
+			body:   `{"urn": "orn:entropy:firehose:demo:orders", "action": "stop", "cron": "0 22 * * 1-5"}`,
+			setup: func(t *testing.T) schedules.ScheduleService {
+				t.Helper()
+				svc := &mocks.ScheduleService{}
+				svc.EXPECT().
+					ScheduleRecurringAction(mock.Anything, "orn:entropy:firehose:demo:orders",
+						module.ActionRequest{Name: "stop"}, "0 22 * * 1-5").
+					Return(&sampleSchedule, nil).Once()
+				return svc
+			},
+			wantStatus: http.StatusCreated,
+			wantBody:   `{"schedule": ` + sampleJSON + `}`,
+		},
+		{
+			title:  "CreateSchedule_BothTimes",
+			method: http.MethodPost,
+			path:   "/api/v1beta1/schedules",
+			body:   `{"urn": "orn:entropy:firehose:demo:orders", "action": "stop", "cron": "0 22 * * *", "run_at": "2022-04-21T22:00:00Z"}`,
+			setup: func(t *testing.T) schedules.ScheduleService {
+				t.Helper()
+				return &mocks.ScheduleService{}
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"code": "bad_request", "message": "exactly one of run_at or cron must be set"}`,
+		},
+		{
+			title:  "ListScheduleRuns",
+			method: http.MethodGet,
+			path:   "/api/v1beta1/schedules/sched-1/runs",
+			setup: func(t *testing.T) schedules.ScheduleService {
+				t.Helper()
+				svc := &mocks.ScheduleService{}
+				svc.EXPECT().
+					ListScheduleRuns(mock.Anything, "sched-1").
+					Return([]resource.ScheduleRun{{ScheduleID: "sched-1", RunAt: runAt, Status: resource.ScheduleStatusDone, CreatedAt: runAt}}, nil).Once()
+				return svc
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `{"runs": [{
+				"schedule_id": "sched-1",
+				"run_at": "2022-04-21T22:00:00Z",
+				"status": "DONE",
+				"created_at": "2022-04-21T22:00:00Z"
+			}]}`,
+		},
+		{
+			title:  "CancelSchedule_NotFound",
+			method: http.MethodPost,
+			path:   "/api/v1beta1/schedules/sched-2/cancel",
+			setup: func(t *testing.T) schedules.ScheduleService {
+				t.Helper()
+				svc := &mocks.ScheduleService{}
+				svc.EXPECT().
+					CancelScheduledAction(mock.Anything, "sched-2").
+					Return(nil, errors.ErrNotFound.WithMsgf("scheduled action 'sched-2' not found")).Once()
+				return svc
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody:   `{"code": "not_found", "message": "scheduled action 'sched-2' not found"}`,
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			router := gorillamux.NewRouter()
+			schedules.Register(router, tt.setup(t))
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
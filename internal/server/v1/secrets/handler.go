@@ -0,0 +1,87 @@
+package secrets
+
+//go:generate mockery --name=SecretService -r --case underscore --with-expecter --structname SecretService --filename=secret_service.go --output=../mocks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	gorillamux "github.com/gorilla/mux"
+
+	"github.com/odpf/entropy/core/secret"
+	"github.com/odpf/entropy/internal/server/serverutils"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+// SecretService manages the secrets of projects. Values of secrets are
+// never returned.
+type SecretService interface {
+	PutSecret(ctx context.Context, sec secret.Secret) (*secret.Secret, error)
+	ListSecrets(ctx context.Context, project string) ([]secret.Secret, error)
+	DeleteSecret(ctx context.Context, project, name string) error
+}
+
+// Register adds the routes for project secrets to the router:
+//
+//	GET    /api/v1beta1/projects/{project}/secrets         - list secrets of the project.
+//	PUT    /api/v1beta1/projects/{project}/secrets/{name}  - create or update a secret.
+//	DELETE /api/v1beta1/projects/{project}/secrets/{name}  - delete a secret.
+func Register(router *gorillamux.Router, svc SecretService) {
+	router.Handle("/api/v1beta1/projects/{project}/secrets", listSecrets(svc)).Methods(http.MethodGet)
+	router.Handle("/api/v1beta1/projects/{project}/secrets/{name}", putSecret(svc)).Methods(http.MethodPut)
+	router.Handle("/api/v1beta1/projects/{project}/secrets/{name}", deleteSecret(svc)).Methods(http.MethodDelete)
+}
+
+func listSecrets(svc SecretService) http.HandlerFunc {
+	return func(wr http.ResponseWriter, req *http.Request) {
+		secrets, err := svc.ListSecrets(req.Context(), gorillamux.Vars(req)["project"])
+		if err != nil {
+			serverutils.WriteHTTPError(wr, err)
+			return
+		}
+
+		serverutils.WriteJSON(wr, http.StatusOK, map[string]interface{}{
+			"secrets": secrets,
+		})
+	}
+}
+
+func putSecret(svc SecretService) http.HandlerFunc {
+	return func(wr http.ResponseWriter, req *http.Request) {
+		var body struct {
+			Value string `json:"value"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			serverutils.WriteHTTPError(wr, errors.ErrInvalid.WithMsgf("invalid request body: %v", err))
+			return
+		}
+
+		vars := gorillamux.Vars(req)
+		sec, err := svc.PutSecret(req.Context(), secret.Secret{
+			Name:    vars["name"],
+			Project: vars["project"],
+			Value:   body.Value,
+		})
+		if err != nil {
+			serverutils.WriteHTTPError(wr, err)
+			return
+		}
+
+		serverutils.WriteJSON(wr, http.StatusOK, map[string]interface{}{
+			"secret": sec,
+		})
+	}
+}
+
+func deleteSecret(svc SecretService) http.HandlerFunc {
+	return func(wr http.ResponseWriter, req *http.Request) {
+		vars := gorillamux.Vars(req)
+		if err := svc.DeleteSecret(req.Context(), vars["project"], vars["name"]); err != nil {
+			serverutils.WriteHTTPError(wr, err)
+			return
+		}
+
+		serverutils.WriteJSON(wr, http.StatusOK, map[string]interface{}{})
+	}
+}
@@ -0,0 +1,134 @@
+package secrets_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	gorillamux "github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/odpf/entropy/core/secret"
+	"github.com/odpf/entropy/internal/server/v1/mocks"
+	"github.com/odpf/entropy/internal/server/v1/secrets"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+
+	updatedAt := time.Date(2022, 4, 21, 10, 29, 15, 0, time.UTC)
+
+	table := []struct {
+		title      string
+		method     string
+		path       string
+		body       string
+		setup      func(t *testing.T) secrets.SecretService
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			title:  "ListSecrets",
+			method: http.MethodGet,
+			path:   "/api/v1beta1/projects/demo/secrets",
+			setup: func(t *testing.T) secrets.SecretService {
+				t.Helper()
+				svc := &mocks.SecretService{}
+				svc.EXPECT().
+					ListSecrets(mock.Anything, "demo").
+					Return([]secret.Secret{{Name: "oauth-secret", Project: "demo", CreatedAt: updatedAt, UpdatedAt: updatedAt}}, nil).Once()
+				return svc
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `{"secrets": [{
+				"name": "oauth-secret",
+				"project": "demo",
+				"created_at": "2022-04-21T10:29:15Z",
+				"updated_at": "2022-04-21T10:29:15Z"
+			}]}`,
+		},
+		{
+			title:  "PutSecret",
+			method: http.MethodPut,
+			path:   "/api/v1beta1/projects/demo/secrets/oauth-secret",
+			body:   `{"value": "s3cr3t"}`,
+			setup: func(t *testing.T) secrets.SecretService {
+				t.Helper()
+				svc := &mocks.SecretService{}
+				svc.EXPECT().
+					PutSecret(mock.Anything, secret.Secret{Name: "oauth-secret", Project: "demo", Value: "s3cr3t"}).
+					Return(&secret.Secret{Name: "oauth-secret", Project: "demo", CreatedAt: updatedAt, UpdatedAt: updatedAt}, nil).Once()
+				return svc
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `{"secret": {
+				"name": "oauth-secret",
+				"project": "demo",
+				"created_at": "2022-04-21T10:29:15Z",
+				"updated_at": "2022-04-21T10:29:15Z"
+			}}`,
+		},
+		{
+			title:  "PutSecret_InvalidBody",
+			method: http.MethodPut,
+			path:   "/api/v1beta1/projects/demo/secrets/oauth-secret",
+			body:   `s3cr3t`,
+			setup: func(t *testing.T) secrets.SecretService {
+				t.Helper()
+				return &mocks.SecretService{}
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"code": "bad_request", "message": "invalid request body: invalid character 's' looking for beginning of value"}`,
+		},
+		{
+			title:  "DeleteSecret_NotFound",
+			method: http.MethodDelete,
+			path:   "/api/v1beta1/projects/demo/secrets/foo",
+			setup: func(t *testing.T) secrets.SecretService {
+				t.Helper()
+				svc := &mocks.SecretService{}
+				svc.EXPECT().
+					DeleteSecret(mock.Anything, "demo", "foo").
+					Return(errors.ErrNotFound.WithMsgf("secret 'foo' not found in project 'demo'")).Once()
+				return svc
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody:   `{"code": "not_found", "message": "secret 'foo' not found in project 'demo'"}`,
+		},
+		{
+			title:  "DeleteSecret_Success",
+			method: http.MethodDelete,
+			path:   "/api/v1beta1/projects/demo/secrets/oauth-secret",
+			setup: func(t *testing.T) secrets.SecretService {
+				t.Helper()
+				svc := &mocks.SecretService{}
+				svc.EXPECT().
+					DeleteSecret(mock.Anything, "demo", "oauth-secret").
+					Return(nil).Once()
+				return svc
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{}`,
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			router := gorillamux.NewRouter()
+			secrets.Register(router, tt.setup(t))
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
@@ -12,7 +12,10 @@ import (
 	"github.com/odpf/entropy/pkg/errors"
 )
 
-const tableModules = "modules"
+const (
+	tableModules         = "modules"
+	tableModuleRevisions = "module_revisions"
+)
 
 type moduleModel struct {
 	URN       string    `db:"urn"`
@@ -21,6 +24,25 @@ type moduleModel struct {
 	CreatedAt time.Time `db:"created_at"`
 	UpdatedAt time.Time `db:"updated_at"`
 	Configs   []byte    `db:"configs"`
+	Revision  int64     `db:"revision"`
+}
+
+type moduleRevisionModel struct {
+	URN       string    `db:"urn"`
+	Revision  int64     `db:"revision"`
+	Reason    string    `db:"reason"`
+	Configs   []byte    `db:"configs"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (mrm moduleRevisionModel) toRevision() module.Revision {
+	return module.Revision{
+		URN:       mrm.URN,
+		Revision:  mrm.Revision,
+		Reason:    mrm.Reason,
+		Configs:   mrm.Configs,
+		CreatedAt: mrm.CreatedAt,
+	}
 }
 
 func (mm moduleModel) toModule() module.Module {
@@ -29,13 +51,14 @@ func (mm moduleModel) toModule() module.Module {
 		Name:      mm.Name,
 		Project:   mm.Project,
 		Configs:   mm.Configs,
+		Revision:  mm.Revision,
 		CreatedAt: mm.CreatedAt,
 		UpdatedAt: mm.UpdatedAt,
 	}
 }
 
 func readModuleRecord(ctx context.Context, r sqlx.QueryerContext, urn string, into *moduleModel) error {
-	cols := []string{"urn", "project", "name", "created_at", "updated_at", "configs"}
+	cols := []string{"urn", "project", "name", "created_at", "updated_at", "configs", "revision"}
 	builder := sq.Select(cols...).From(tableModules).Where(sq.Eq{"urn": urn})
 
 	query, args, err := builder.PlaceholderFormat(sq.Dollar).ToSql()
@@ -54,8 +77,37 @@ func readModuleRecord(ctx context.Context, r sqlx.QueryerContext, urn string, in
 
 func insertModuleRecord(ctx context.Context, runner sq.BaseRunner, mod module.Module) error {
 	q := sq.Insert(tableModules).
-		Columns("urn", "project", "name", "created_at", "updated_at", "configs").
-		Values(mod.URN, mod.Project, mod.Name, mod.CreatedAt, mod.UpdatedAt, mod.Configs).
+		Columns("urn", "project", "name", "created_at", "updated_at", "configs", "revision").
+		Values(mod.URN, mod.Project, mod.Name, mod.CreatedAt, mod.UpdatedAt, mod.Configs, mod.Revision).
+		PlaceholderFormat(sq.Dollar)
+
+	_, err := q.RunWith(runner).ExecContext(ctx)
+	return err
+}
+
+func readModuleRevisionRecord(ctx context.Context, r sqlx.QueryerContext, urn string, revision int64, into *moduleRevisionModel) error {
+	cols := []string{"urn", "revision", "reason", "configs", "created_at"}
+	builder := sq.Select(cols...).From(tableModuleRevisions).
+		Where(sq.Eq{"urn": urn, "revision": revision})
+
+	query, args, err := builder.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	if err := r.QueryRowxContext(ctx, query, args...).StructScan(into); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func insertModuleRevisionRecord(ctx context.Context, runner sq.BaseRunner, mod module.Module, reason string) error {
+	q := sq.Insert(tableModuleRevisions).
+		Columns("urn", "revision", "reason", "configs", "created_at").
+		Values(mod.URN, mod.Revision, reason, mod.Configs, mod.UpdatedAt).
 		PlaceholderFormat(sq.Dollar)
 
 	_, err := q.RunWith(runner).ExecContext(ctx)
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
 
 	"github.com/odpf/entropy/core/module"
 	"github.com/odpf/entropy/pkg/errors"
@@ -15,14 +16,8 @@ func (st *Store) GetModule(ctx context.Context, urn string) (*module.Module, err
 	if err := readModuleRecord(ctx, st.db, urn, &rec); err != nil {
 		return nil, err
 	}
-	return &module.Module{
-		URN:       rec.URN,
-		Name:      rec.Name,
-		Project:   rec.Project,
-		Configs:   rec.Configs,
-		CreatedAt: rec.CreatedAt,
-		UpdatedAt: rec.UpdatedAt,
-	}, nil
+	mod := rec.toModule()
+	return &mod, nil
 }
 
 func (st *Store) ListModules(ctx context.Context, project string) ([]module.Module, error) {
@@ -58,31 +53,94 @@ func (st *Store) ListModules(ctx context.Context, project string) ([]module.Modu
 }
 
 func (st *Store) CreateModule(ctx context.Context, m module.Module) error {
-	err := insertModuleRecord(ctx, st.db, m)
-	if err != nil {
+	insertModule := func(ctx context.Context, tx *sqlx.Tx) error {
+		if err := insertModuleRecord(ctx, tx, m); err != nil {
+			return err
+		}
+		return insertModuleRevisionRecord(ctx, tx, m, "create")
+	}
+
+	if err := withinTx(ctx, st.db, false, insertModule); err != nil {
 		return translateErr(err)
 	}
 	return nil
 }
 
-func (st *Store) UpdateModule(ctx context.Context, m module.Module) error {
-	updateSpec := sq.Update(tableModules).
-		Where(sq.Eq{"urn": m.URN}).
-		SetMap(map[string]interface{}{
-			"configs":    m.Configs,
-			"updated_at": sq.Expr("current_timestamp"),
-		}).
-		PlaceholderFormat(sq.Dollar)
-
-	_, err := updateSpec.RunWith(st.db).ExecContext(ctx)
-	return translateErr(err)
+func (st *Store) UpdateModule(ctx context.Context, m module.Module, reason string) error {
+	updateModule := func(ctx context.Context, tx *sqlx.Tx) error {
+		// revision insert fails with conflict if the same revision was
+		// created by a concurrent update.
+		if err := insertModuleRevisionRecord(ctx, tx, m, reason); err != nil {
+			return err
+		}
+
+		updateSpec := sq.Update(tableModules).
+			Where(sq.Eq{"urn": m.URN}).
+			SetMap(map[string]interface{}{
+				"configs":    m.Configs,
+				"revision":   m.Revision,
+				"updated_at": sq.Expr("current_timestamp"),
+			}).
+			PlaceholderFormat(sq.Dollar)
+
+		_, err := updateSpec.RunWith(tx).ExecContext(ctx)
+		return err
+	}
+
+	return translateErr(withinTx(ctx, st.db, false, updateModule))
 }
 
 func (st *Store) DeleteModule(ctx context.Context, urn string) error {
-	_, err := sq.Delete(tableModules).
+	deleteModule := func(ctx context.Context, tx *sqlx.Tx) error {
+		_, err := sq.Delete(tableModuleRevisions).
+			Where(sq.Eq{"urn": urn}).
+			PlaceholderFormat(sq.Dollar).
+			RunWith(tx).
+			ExecContext(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = sq.Delete(tableModules).
+			Where(sq.Eq{"urn": urn}).
+			PlaceholderFormat(sq.Dollar).
+			RunWith(tx).
+			ExecContext(ctx)
+		return err
+	}
+
+	return translateErr(withinTx(ctx, st.db, false, deleteModule))
+}
+
+func (st *Store) GetModuleRevision(ctx context.Context, urn string, revision int64) (*module.Revision, error) {
+	var rec moduleRevisionModel
+	if err := readModuleRevisionRecord(ctx, st.db, urn, revision, &rec); err != nil {
+		return nil, err
+	}
+	rev := rec.toRevision()
+	return &rev, nil
+}
+
+func (st *Store) ListModuleRevisions(ctx context.Context, urn string) ([]module.Revision, error) {
+	cols := []string{"urn", "revision", "reason", "configs", "created_at"}
+	query, args, err := sq.Select(cols...).
+		From(tableModuleRevisions).
 		Where(sq.Eq{"urn": urn}).
+		OrderBy("revision DESC").
 		PlaceholderFormat(sq.Dollar).
-		RunWith(st.db).
-		ExecContext(ctx)
-	return translateErr(err)
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var recs []moduleRevisionModel
+	if err := sqlx.SelectContext(ctx, st.db, &recs, query, args...); err != nil {
+		return nil, err
+	}
+
+	revs := make([]module.Revision, 0, len(recs))
+	for _, rec := range recs {
+		revs = append(revs, rec.toRevision())
+	}
+	return revs, nil
 }
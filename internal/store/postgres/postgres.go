@@ -24,7 +24,8 @@ const (
 var schema string
 
 type Store struct {
-	db *sqlx.DB
+	db      *sqlx.DB
+	secrets *secretCipher
 }
 
 func (st *Store) Migrate(ctx context.Context) error {
@@ -34,6 +35,18 @@ func (st *Store) Migrate(ctx context.Context) error {
 
 func (st *Store) Close() error { return st.db.Close() }
 
+// EncryptSecrets makes the store encrypt the values of project secrets
+// with the given 32-byte key (AES-256-GCM). Values stored before this is
+// enabled are still read as-is, until they are set again.
+func (st *Store) EncryptSecrets(key []byte) error {
+	sc, err := newSecretCipher(key)
+	if err != nil {
+		return err
+	}
+	st.secrets = sc
+	return nil
+}
+
 // Open returns store instance backed by PostgreSQL.
 func Open(conStr string) (*Store, error) {
 	db, err := sqlx.Open("postgres", conStr)
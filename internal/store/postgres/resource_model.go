@@ -12,23 +12,25 @@ import (
 )
 
 type resourceModel struct {
-	ID              int64     `db:"id"`
-	URN             string    `db:"urn"`
-	Kind            string    `db:"kind"`
-	Name            string    `db:"name"`
-	Project         string    `db:"project"`
-	CreatedAt       time.Time `db:"created_at"`
-	UpdatedAt       time.Time `db:"updated_at"`
-	SpecConfigs     []byte    `db:"spec_configs"`
-	StateStatus     string    `db:"state_status"`
-	StateOutput     []byte    `db:"state_output"`
-	StateModuleData []byte    `db:"state_module_data"`
+	ID              int64      `db:"id"`
+	URN             string     `db:"urn"`
+	Kind            string     `db:"kind"`
+	Name            string     `db:"name"`
+	Project         string     `db:"project"`
+	CreatedAt       time.Time  `db:"created_at"`
+	UpdatedAt       time.Time  `db:"updated_at"`
+	SpecConfigs     []byte     `db:"spec_configs"`
+	StateStatus     string     `db:"state_status"`
+	StateOutput     []byte     `db:"state_output"`
+	StateModuleData []byte     `db:"state_module_data"`
+	StateModuleRev  int64      `db:"state_module_revision"`
+	ExpiresAt       *time.Time `db:"expires_at"`
 }
 
 func readResourceRecord(ctx context.Context, r sqlx.QueryerContext, urn string, into *resourceModel) error {
 	cols := []string{
 		"id", "urn", "kind", "project", "name", "created_at", "updated_at",
-		"spec_configs", "state_status", "state_output", "state_module_data",
+		"spec_configs", "state_status", "state_output", "state_module_data", "state_module_revision", "expires_at",
 	}
 	builder := sq.Select(cols...).From(tableResources).Where(sq.Eq{"urn": urn})
 
@@ -44,14 +44,16 @@ func (st *Store) GetByURN(ctx context.Context, urn string) (*resource.Resource,
 		Labels:    tagsToLabelMap(tags),
 		CreatedAt: rec.CreatedAt,
 		UpdatedAt: rec.UpdatedAt,
+		ExpiresAt: rec.ExpiresAt,
 		Spec: resource.Spec{
 			Configs:      rec.SpecConfigs,
 			Dependencies: deps,
 		},
 		State: resource.State{
-			Status:     rec.StateStatus,
-			Output:     rec.StateOutput,
-			ModuleData: rec.StateModuleData,
+			Status:         rec.StateStatus,
+			Output:         rec.StateOutput,
+			ModuleData:     rec.StateModuleData,
+			ModuleRevision: rec.StateModuleRev,
 		},
 	}, nil
 }
@@ -145,11 +147,13 @@ func (st *Store) Update(ctx context.Context, r resource.Resource, saveRevision b
 		updateSpec := sq.Update(tableResources).
 			Where(sq.Eq{"id": id}).
 			SetMap(map[string]interface{}{
-				"updated_at":        sq.Expr("current_timestamp"),
-				"spec_configs":      r.Spec.Configs,
-				"state_status":      r.State.Status,
-				"state_output":      r.State.Output,
-				"state_module_data": r.State.ModuleData,
+				"updated_at":            sq.Expr("current_timestamp"),
+				"spec_configs":          r.Spec.Configs,
+				"state_status":          r.State.Status,
+				"state_output":          r.State.Output,
+				"state_module_data":     r.State.ModuleData,
+				"state_module_revision": r.State.ModuleRevision,
+				"expires_at":            r.ExpiresAt,
 			}).
 			PlaceholderFormat(sq.Dollar)
 
@@ -222,9 +226,9 @@ func (st *Store) Delete(ctx context.Context, urn string, hooks ...resource.Mutat
 func insertResourceRecord(ctx context.Context, runner sq.BaseRunner, r resource.Resource) (int64, error) {
 	q := sq.Insert(tableResources).
 		Columns("urn", "kind", "project", "name", "created_at", "updated_at",
-			"spec_configs", "state_status", "state_output", "state_module_data").
+			"spec_configs", "state_status", "state_output", "state_module_data", "state_module_revision", "expires_at").
 		Values(r.URN, r.Kind, r.Project, r.Name, r.CreatedAt, r.UpdatedAt,
-			r.Spec.Configs, r.State.Status, r.State.Output, r.State.ModuleData).
+			r.Spec.Configs, r.State.Status, r.State.Output, r.State.ModuleData, r.State.ModuleRevision, r.ExpiresAt).
 		Suffix(`RETURNING "id"`).
 		PlaceholderFormat(sq.Dollar)
 
@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/odpf/entropy/core/resource"
+)
+
+const (
+	tableScheduledActions    = "scheduled_actions"
+	tableScheduledActionRuns = "scheduled_action_runs"
+)
+
+var scheduleColumns = []string{
+	"id", "urn", "action", "params", "labels", "cron", "run_at", "status", "result", "created_at", "updated_at",
+}
+
+type scheduleModel struct {
+	ID        string    `db:"id"`
+	URN       string    `db:"urn"`
+	Action    string    `db:"action"`
+	Params    []byte    `db:"params"`
+	Labels    []byte    `db:"labels"`
+	Cron      string    `db:"cron"`
+	RunAt     time.Time `db:"run_at"`
+	Status    string    `db:"status"`
+	Result    string    `db:"result"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (sm scheduleModel) toScheduledAction() (*resource.ScheduledAction, error) {
+	var labels map[string]string
+	if err := json.Unmarshal(sm.Labels, &labels); err != nil {
+		return nil, err
+	}
+
+	return &resource.ScheduledAction{
+		ID:        sm.ID,
+		URN:       sm.URN,
+		Action:    sm.Action,
+		Params:    sm.Params,
+		Labels:    labels,
+		Cron:      sm.Cron,
+		RunAt:     sm.RunAt,
+		Status:    sm.Status,
+		Result:    sm.Result,
+		CreatedAt: sm.CreatedAt,
+		UpdatedAt: sm.UpdatedAt,
+	}, nil
+}
+
+type scheduleRunModel struct {
+	ScheduleID string    `db:"schedule_id"`
+	RunAt      time.Time `db:"run_at"`
+	Status     string    `db:"status"`
+	Result     string    `db:"result"`
+	CreatedAt  time.Time `db:"created_at"`
+}
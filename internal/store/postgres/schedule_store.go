@@ -0,0 +1,183 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+func (st *Store) GetSchedule(ctx context.Context, id string) (*resource.ScheduledAction, error) {
+	query, args, err := sq.Select(scheduleColumns...).
+		From(tableScheduledActions).
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var rec scheduleModel
+	if err := st.db.QueryRowxContext(ctx, query, args...).StructScan(&rec); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.ErrNotFound
+		}
+		return nil, err
+	}
+	return rec.toScheduledAction()
+}
+
+func (st *Store) ListSchedules(ctx context.Context, selector resource.ScheduleSelector) ([]resource.ScheduledAction, error) {
+	q := sq.Select(scheduleColumns...).From(tableScheduledActions)
+	if selector.URN != "" {
+		q = q.Where(sq.Eq{"urn": selector.URN})
+	}
+	if selector.Status != "" {
+		q = q.Where(sq.Eq{"status": selector.Status})
+	}
+
+	query, args, err := q.OrderBy("run_at").PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := st.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var schedules []resource.ScheduledAction
+	for rows.Next() {
+		var rec scheduleModel
+		if err := rows.StructScan(&rec); err != nil {
+			return nil, err
+		}
+
+		sa, err := rec.toScheduledAction()
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, *sa)
+	}
+	return schedules, rows.Err()
+}
+
+func (st *Store) CreateSchedule(ctx context.Context, sa resource.ScheduledAction, hooks ...resource.MutationHook) error {
+	labels, err := json.Marshal(sa.Labels)
+	if err != nil {
+		return err
+	}
+
+	params := []byte(sa.Params)
+	if params == nil {
+		params = []byte{}
+	}
+
+	insertSchedule := func(ctx context.Context, tx *sqlx.Tx) error {
+		_, err := sq.Insert(tableScheduledActions).
+			Columns(scheduleColumns...).
+			Values(sa.ID, sa.URN, sa.Action, params, labels, sa.Cron, sa.RunAt,
+				sa.Status, sa.Result, sa.CreatedAt, sa.UpdatedAt).
+			PlaceholderFormat(sq.Dollar).
+			RunWith(tx).
+			ExecContext(ctx)
+		if err != nil {
+			return translateErr(err)
+		}
+
+		return runAllHooks(ctx, hooks)
+	}
+
+	return withinTx(ctx, st.db, false, insertSchedule)
+}
+
+func (st *Store) UpdateSchedule(ctx context.Context, sa resource.ScheduledAction) error {
+	return updateScheduleRecord(ctx, st.db, sa)
+}
+
+func (st *Store) RecordScheduleRun(ctx context.Context, sa resource.ScheduledAction, run resource.ScheduleRun, hooks ...resource.MutationHook) error {
+	recordRun := func(ctx context.Context, tx *sqlx.Tx) error {
+		if err := updateScheduleRecord(ctx, tx, sa); err != nil {
+			return err
+		}
+
+		_, err := sq.Insert(tableScheduledActionRuns).
+			Columns("schedule_id", "run_at", "status", "result", "created_at").
+			Values(run.ScheduleID, run.RunAt, run.Status, run.Result, run.CreatedAt).
+			PlaceholderFormat(sq.Dollar).
+			RunWith(tx).
+			ExecContext(ctx)
+		if err != nil {
+			return translateErr(err)
+		}
+
+		return runAllHooks(ctx, hooks)
+	}
+
+	return withinTx(ctx, st.db, false, recordRun)
+}
+
+func (st *Store) ListScheduleRuns(ctx context.Context, scheduleID string) ([]resource.ScheduleRun, error) {
+	query, args, err := sq.Select("schedule_id", "run_at", "status", "result", "created_at").
+		From(tableScheduledActionRuns).
+		Where(sq.Eq{"schedule_id": scheduleID}).
+		OrderBy("id").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := st.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var runs []resource.ScheduleRun
+	for rows.Next() {
+		var rec scheduleRunModel
+		if err := rows.StructScan(&rec); err != nil {
+			return nil, err
+		}
+
+		runs = append(runs, resource.ScheduleRun{
+			ScheduleID: rec.ScheduleID,
+			RunAt:      rec.RunAt,
+			Status:     rec.Status,
+			Result:     rec.Result,
+			CreatedAt:  rec.CreatedAt,
+		})
+	}
+	return runs, rows.Err()
+}
+
+func updateScheduleRecord(ctx context.Context, runner sq.BaseRunner, sa resource.ScheduledAction) error {
+	res, err := sq.Update(tableScheduledActions).
+		Where(sq.Eq{"id": sa.ID}).
+		SetMap(map[string]interface{}{
+			"status":     sa.Status,
+			"result":     sa.Result,
+			"run_at":     sa.RunAt,
+			"updated_at": sa.UpdatedAt,
+		}).
+		PlaceholderFormat(sq.Dollar).
+		RunWith(runner).
+		ExecContext(ctx)
+	if err != nil {
+		return translateErr(err)
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
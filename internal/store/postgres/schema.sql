@@ -67,3 +67,57 @@ CREATE TABLE IF NOT EXISTS modules (
 
 CREATE INDEX IF NOT EXISTS idx_modules_project ON modules (project);
 ALTER TABLE revisions ADD COLUMN IF NOT EXISTS reason TEXT DEFAULT '<none>' NOT NULL;
+
+CREATE TABLE IF NOT EXISTS secrets (
+   project      TEXT  NOT NULL,
+   name         TEXT  NOT NULL,
+   value        bytea NOT NULL,
+   created_at   timestamp with time zone NOT NULL DEFAULT current_timestamp,
+   updated_at   timestamp with time zone NOT NULL DEFAULT current_timestamp,
+   PRIMARY KEY (project, name)
+);
+
+CREATE TABLE IF NOT EXISTS scheduled_actions (
+   id           TEXT      NOT NULL PRIMARY KEY,
+   urn          TEXT      NOT NULL,
+   action       TEXT      NOT NULL,
+   params       bytea     NOT NULL,
+   labels       jsonb     NOT NULL,
+   run_at       timestamp with time zone NOT NULL,
+   status       TEXT      NOT NULL,
+   result       TEXT      NOT NULL DEFAULT '',
+   created_at   timestamp with time zone NOT NULL DEFAULT current_timestamp,
+   updated_at   timestamp with time zone NOT NULL DEFAULT current_timestamp
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduled_actions_urn ON scheduled_actions (urn);
+CREATE INDEX IF NOT EXISTS idx_scheduled_actions_status ON scheduled_actions (status);
+ALTER TABLE scheduled_actions ADD COLUMN IF NOT EXISTS cron TEXT DEFAULT '' NOT NULL;
+
+CREATE TABLE IF NOT EXISTS scheduled_action_runs (
+   id           BIGSERIAL NOT NULL PRIMARY KEY,
+   schedule_id  TEXT      NOT NULL REFERENCES scheduled_actions (id),
+   run_at       timestamp with time zone NOT NULL,
+   status       TEXT      NOT NULL,
+   result       TEXT      NOT NULL DEFAULT '',
+   created_at   timestamp with time zone NOT NULL DEFAULT current_timestamp
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduled_action_runs_schedule_id ON scheduled_action_runs (schedule_id);
+ALTER TABLE resources ADD COLUMN IF NOT EXISTS expires_at timestamp with time zone NULL;
+ALTER TABLE modules ADD COLUMN IF NOT EXISTS revision BIGINT DEFAULT 1 NOT NULL;
+
+CREATE TABLE IF NOT EXISTS module_revisions (
+   urn          TEXT   NOT NULL,
+   revision     BIGINT NOT NULL,
+   reason       TEXT   NOT NULL DEFAULT '',
+   configs      jsonb  NOT NULL,
+   created_at   timestamp with time zone NOT NULL DEFAULT current_timestamp,
+   PRIMARY KEY (urn, revision)
+);
+
+INSERT INTO module_revisions (urn, revision, reason, configs, created_at)
+SELECT urn, revision, 'existing', configs, updated_at FROM modules
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE resources ADD COLUMN IF NOT EXISTS state_module_revision BIGINT DEFAULT 0 NOT NULL;
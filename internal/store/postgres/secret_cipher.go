@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"io"
+
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+// encryptedPrefix marks the secret values encrypted by secretCipher. Values
+// without it were stored before encryption was enabled.
+var encryptedPrefix = []byte("aesgcm:")
+
+// secretCipher encrypts secret values with AES-256-GCM. The project and the
+// name of the secret are authenticated along with the value, so that an
+// encrypted value cannot be moved to another secret.
+type secretCipher struct {
+	aead cipher.AEAD
+}
+
+func newSecretCipher(key []byte) (*secretCipher, error) {
+	if len(key) != 32 {
+		return nil, errors.ErrInvalid.WithMsgf("secret key must be 32 bytes, not %d", len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &secretCipher{aead: aead}, nil
+}
+
+// seal returns the value to be stored. Values are stored as-is if the
+// cipher is nil (i.e., no secret key is configured).
+func (sc *secretCipher) seal(project, name string, value []byte) ([]byte, error) {
+	if sc == nil {
+		return value, nil
+	}
+
+	nonce := make([]byte, sc.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	sealed := append(append([]byte(nil), encryptedPrefix...), nonce...)
+	return sc.aead.Seal(sealed, nonce, value, secretAD(project, name)), nil
+}
+
+// open returns the value from the stored one.
+func (sc *secretCipher) open(project, name string, stored []byte) ([]byte, error) {
+	if !bytes.HasPrefix(stored, encryptedPrefix) {
+		return stored, nil
+	} else if sc == nil {
+		return nil, errors.ErrInternal.
+			WithMsgf("secret '%s' in project '%s' is encrypted, but no secret key is configured", name, project)
+	}
+
+	sealed := stored[len(encryptedPrefix):]
+	if len(sealed) < sc.aead.NonceSize() {
+		return nil, errors.ErrInternal.WithMsgf("secret '%s' in project '%s' is corrupt", name, project)
+	}
+
+	nonce, ciphertext := sealed[:sc.aead.NonceSize()], sealed[sc.aead.NonceSize():]
+	value, err := sc.aead.Open(nil, nonce, ciphertext, secretAD(project, name))
+	if err != nil {
+		return nil, errors.ErrInternal.
+			WithMsgf("failed to decrypt secret '%s' in project '%s'", name, project).WithCausef(err.Error())
+	}
+	return value, nil
+}
+
+func secretAD(project, name string) []byte {
+	return []byte(project + "/" + name)
+}
@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+func TestSecretCipher(t *testing.T) {
+	t.Parallel()
+
+	sc, err := newSecretCipher(bytes.Repeat([]byte("k"), 32))
+	require.NoError(t, err)
+
+	stored, err := sc.seal("demo", "oauth-secret", []byte("s3cr3t"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(stored), "s3cr3t")
+
+	value, err := sc.open("demo", "oauth-secret", stored)
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", string(value))
+
+	// encrypted value must not be usable for another secret.
+	_, err = sc.open("demo", "other-secret", stored)
+	assert.True(t, errors.Is(err, errors.ErrInternal))
+
+	// values stored before encryption was enabled are read as-is.
+	value, err = sc.open("demo", "oauth-secret", []byte("plain"))
+	require.NoError(t, err)
+	assert.Equal(t, "plain", string(value))
+
+	var noCipher *secretCipher
+	_, err = noCipher.open("demo", "oauth-secret", stored)
+	assert.True(t, errors.Is(err, errors.ErrInternal))
+
+	_, err = newSecretCipher([]byte("short"))
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+}
@@ -0,0 +1,27 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/odpf/entropy/core/secret"
+)
+
+const tableSecrets = "secrets"
+
+type secretModel struct {
+	Project   string    `db:"project"`
+	Name      string    `db:"name"`
+	Value     []byte    `db:"value"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (sm secretModel) toSecret() secret.Secret {
+	return secret.Secret{
+		Name:      sm.Name,
+		Project:   sm.Project,
+		Value:     string(sm.Value),
+		CreatedAt: sm.CreatedAt,
+		UpdatedAt: sm.UpdatedAt,
+	}
+}
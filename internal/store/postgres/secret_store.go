@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/odpf/entropy/core/secret"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+func (st *Store) GetSecret(ctx context.Context, project, name string) (*secret.Secret, error) {
+	query, args, err := sq.Select("project", "name", "value", "created_at", "updated_at").
+		From(tableSecrets).
+		Where(sq.Eq{"project": project, "name": name}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var rec secretModel
+	if err := st.db.QueryRowxContext(ctx, query, args...).StructScan(&rec); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.ErrNotFound.WithMsgf("secret '%s' not found in project '%s'", name, project)
+		}
+		return nil, err
+	}
+
+	value, err := st.secrets.open(rec.Project, rec.Name, rec.Value)
+	if err != nil {
+		return nil, err
+	}
+	rec.Value = value
+
+	sec := rec.toSecret()
+	return &sec, nil
+}
+
+func (st *Store) ListSecrets(ctx context.Context, project string) ([]secret.Secret, error) {
+	q := sq.Select("project", "name", "created_at", "updated_at").From(tableSecrets)
+	if project != "" {
+		q = q.Where(sq.Eq{"project": project})
+	}
+
+	query, args, err := q.OrderBy("project", "name").PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := st.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var secrets []secret.Secret
+	for rows.Next() {
+		var rec secretModel
+		if err := rows.StructScan(&rec); err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, rec.toSecret())
+	}
+	return secrets, rows.Err()
+}
+
+func (st *Store) UpsertSecret(ctx context.Context, sec secret.Secret) error {
+	value, err := st.secrets.seal(sec.Project, sec.Name, []byte(sec.Value))
+	if err != nil {
+		return err
+	}
+
+	_, err = sq.Insert(tableSecrets).
+		Columns("project", "name", "value", "created_at", "updated_at").
+		Values(sec.Project, sec.Name, value, sec.CreatedAt, sec.UpdatedAt).
+		Suffix("ON CONFLICT (project, name) DO UPDATE SET value = EXCLUDED.value, updated_at = EXCLUDED.updated_at").
+		PlaceholderFormat(sq.Dollar).
+		RunWith(st.db).
+		ExecContext(ctx)
+	return translateErr(err)
+}
+
+func (st *Store) DeleteSecret(ctx context.Context, project, name string) error {
+	res, err := sq.Delete(tableSecrets).
+		Where(sq.Eq{"project": project, "name": name}).
+		PlaceholderFormat(sq.Dollar).
+		RunWith(st.db).
+		ExecContext(ctx)
+	if err != nil {
+		return translateErr(err)
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return errors.ErrNotFound.WithMsgf("secret '%s' not found in project '%s'", name, project)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package firehose
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/kafka"
+)
+
+const defaultAutoscaleCooldown = "5m"
+
+// autoscalingPolicy keeps the consumer lag of a firehose within the target
+// band, i.e., between half of the target lag and the target lag.
+type autoscalingPolicy struct {
+	MinReplicas int   `json:"min_replicas"`
+	MaxReplicas int   `json:"max_replicas"`
+	TargetLag   int64 `json:"target_lag"`
+
+	// Cooldown is the minimum duration between two scale actions (e.g.,
+	// '10m'). Manual scale actions are also taken into account.
+	Cooldown string `json:"cooldown"`
+}
+
+func (p *autoscalingPolicy) validateAndSanitize() error {
+	if p.MinReplicas < 1 {
+		return errors.ErrInvalid.WithMsgf("autoscaling.min_replicas must be at least 1")
+	} else if p.MaxReplicas < p.MinReplicas {
+		return errors.ErrInvalid.WithMsgf("autoscaling.max_replicas must not be less than min_replicas")
+	} else if p.TargetLag < 1 {
+		return errors.ErrInvalid.WithMsgf("autoscaling.target_lag must be at least 1")
+	}
+
+	if p.Cooldown == "" {
+		p.Cooldown = defaultAutoscaleCooldown
+	}
+	if d, err := time.ParseDuration(p.Cooldown); err != nil || d < 0 {
+		return errors.ErrInvalid.WithMsgf("autoscaling.cooldown '%s' is not a valid duration", p.Cooldown)
+	}
+	return nil
+}
+
+// desiredReplicas returns the replicas needed to bring the lag within the
+// target band, along with the reason if it is different from current. The
+// lag does not scale the firehose beyond the partitions of the topic (if
+// known, i.e., non-zero), since the extra replicas would be idle.
+func (p autoscalingPolicy) desiredReplicas(current int, lag int64, partitions int) (int, string) {
+	switch {
+	case current < p.MinReplicas:
+		return p.MinReplicas, fmt.Sprintf("replicas are below min_replicas %d", p.MinReplicas)
+
+	case current > p.MaxReplicas:
+		return p.MaxReplicas, fmt.Sprintf("replicas are above max_replicas %d", p.MaxReplicas)
+
+	case lag > p.TargetLag:
+		// scale up in proportion to the lag, as a burst needs to be caught
+		// up quickly.
+		desired := int(math.Ceil(float64(current) * float64(lag) / float64(p.TargetLag)))
+		if desired > p.MaxReplicas {
+			desired = p.MaxReplicas
+		}
+
+		reason := fmt.Sprintf("consumer lag %d is above the target %d", lag, p.TargetLag)
+		if partitions > 0 && desired > partitions {
+			desired = partitions
+			reason += fmt.Sprintf(" (capped at %d partitions)", partitions)
+		}
+		if desired <= current {
+			return current, ""
+		}
+		return desired, reason
+
+	case lag < p.TargetLag/2 && current > p.MinReplicas:
+		// scale down gradually, to avoid flapping when the traffic is bursty.
+		return current - 1, fmt.Sprintf("consumer lag %d is below half of the target %d", lag, p.TargetLag)
+	}
+
+	return current, ""
+}
+
+func (m *firehoseModule) Autoscale(ctx context.Context, res module.ExpandedResource) (*module.ScaleDecision, error) {
+	r := res.Resource
+
+	var conf moduleConfig
+	if err := json.Unmarshal(r.Spec.Configs, &conf); err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid config json: %v", err)
+	}
+
+	isStopped := conf.State == stateStopped || (conf.StopTime != nil && conf.StopTime.Before(m.clock()))
+	if conf.Autoscaling == nil || isStopped {
+		return nil, nil
+	}
+
+	data, err := readModuleData(r.State.ModuleData)
+	if err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid module data: %v", err)
+	}
+
+	cooldown, err := time.ParseDuration(conf.Autoscaling.Cooldown)
+	if err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid autoscaling cooldown: %v", err)
+	} else if data.LastScaledAt != nil && m.clock().Sub(*data.LastScaledAt) < cooldown {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, lagFetchTimeout)
+	defer cancel()
+
+	brokers := strings.Split(conf.Firehose.KafkaBrokerAddress, ",")
+	lag, err := m.consumerLag(ctx, brokers, conf.Firehose.KafkaConsumerID, conf.Firehose.KafkaTopic)
+	if err != nil {
+		return nil, err
+	}
+
+	current := conf.Firehose.Replicas
+	replicas, reason := conf.Autoscaling.desiredReplicas(current, lag.Total, len(lag.Partitions))
+	if replicas == current {
+		return nil, nil
+	}
+
+	params, err := json.Marshal(map[string]int{"replicas": replicas})
+	if err != nil {
+		return nil, err
+	}
+
+	return &module.ScaleDecision{
+		Action: module.ActionRequest{Name: ScaleAction, Params: params},
+		Reason: fmt.Sprintf("%s, replicas %d -> %d", reason, current, replicas),
+	}, nil
+}
+
+func getConsumerLag(ctx context.Context, brokers []string, group, topic string) (*kafka.ConsumerLag, error) {
+	return kafka.NewAdmin(brokers).GetConsumerLag(ctx, group, topic)
+}
@@ -0,0 +1,181 @@
+package firehose
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/kafka"
+)
+
+func TestAutoscalingPolicy_DesiredReplicas(t *testing.T) {
+	t.Parallel()
+
+	policy := autoscalingPolicy{MinReplicas: 2, MaxReplicas: 10, TargetLag: 1000}
+
+	table := []struct {
+		title      string
+		current    int
+		lag        int64
+		partitions int
+		want       int
+	}{
+		{title: "WithinBand", current: 4, lag: 700, want: 4},
+		{title: "AtTarget", current: 4, lag: 1000, want: 4},
+		{title: "AboveTarget", current: 4, lag: 2500, want: 10},
+		{title: "AboveTargetProportional", current: 2, lag: 1400, want: 3},
+		{title: "AboveTargetAtMax", current: 10, lag: 5000, want: 10},
+		{title: "AboveTargetCappedAtPartitions", current: 2, lag: 5000, partitions: 6, want: 6},
+		{title: "AboveTargetAtPartitions", current: 6, lag: 5000, partitions: 6, want: 6},
+		{title: "AboveTargetBeyondPartitions", current: 8, lag: 5000, partitions: 6, want: 8},
+		{title: "BelowHalfTarget", current: 4, lag: 100, want: 3},
+		{title: "BelowHalfTargetAtMin", current: 2, lag: 0, want: 2},
+		{title: "BelowMinReplicas", current: 1, lag: 700, want: 2},
+		{title: "AboveMaxReplicas", current: 12, lag: 700, want: 10},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+			got, reason := policy.desiredReplicas(tt.current, tt.lag, tt.partitions)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, got == tt.current, reason == "")
+		})
+	}
+}
+
+func TestFirehoseModule_Autoscale(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1650536955, 0).UTC()
+	fiveMinutesAgo := now.Add(-5 * time.Minute)
+	hourAgo := now.Add(-1 * time.Hour)
+
+	const autoscaling = `"autoscaling":{"min_replicas":1,"max_replicas":4,"target_lag":1000,"cooldown":"10m"}`
+
+	resourceWith := func(configs string, data string) module.ExpandedResource {
+		return module.ExpandedResource{
+			Resource: resource.Resource{
+				URN:  "urn:odpf:entropy:firehose:test",
+				Kind: "firehose",
+				Name: "test",
+				Spec: resource.Spec{Configs: []byte(configs)},
+				State: resource.State{
+					Status:     resource.StatusCompleted,
+					ModuleData: []byte(data),
+				},
+			},
+		}
+	}
+
+	table := []struct {
+		title      string
+		res        module.ExpandedResource
+		lag        int64
+		partitions int
+		lagErr     error
+		want       *module.ScaleDecision
+		wantErr    error
+	}{
+		{
+			title: "NoPolicy",
+			res:   resourceWith(`{"state":"RUNNING","firehose":{"replicas":1,"kafka_topic":"topic","kafka_consumer_id":"group"}}`, `{}`),
+			lag:   5000,
+			want:  nil,
+		},
+		{
+			title: "Stopped",
+			res:   resourceWith(`{"state":"STOPPED",`+autoscaling+`,"firehose":{"replicas":1,"kafka_topic":"topic","kafka_consumer_id":"group"}}`, `{}`),
+			lag:   5000,
+			want:  nil,
+		},
+		{
+			title: "StopTimeElapsed",
+			res:   resourceWith(`{"state":"RUNNING","stop_time":"`+hourAgo.Format(time.RFC3339)+`",`+autoscaling+`,"firehose":{"replicas":1,"kafka_topic":"topic","kafka_consumer_id":"group"}}`, `{}`),
+			lag:   5000,
+			want:  nil,
+		},
+		{
+			title: "InCooldown",
+			res:   resourceWith(`{"state":"RUNNING",`+autoscaling+`,"firehose":{"replicas":1,"kafka_topic":"topic","kafka_consumer_id":"group"}}`, `{"last_scaled_at":"`+fiveMinutesAgo.Format(time.RFC3339)+`"}`),
+			lag:   5000,
+			want:  nil,
+		},
+		{
+			title:   "LagError",
+			res:     resourceWith(`{"state":"RUNNING",`+autoscaling+`,"firehose":{"replicas":1,"kafka_topic":"topic","kafka_consumer_id":"group"}}`, `{}`),
+			lagErr:  kafka.ErrTopicNotFound,
+			wantErr: errors.ErrNotFound,
+		},
+		{
+			title: "WithinBand",
+			res:   resourceWith(`{"state":"RUNNING",`+autoscaling+`,"firehose":{"replicas":2,"kafka_topic":"topic","kafka_consumer_id":"group"}}`, `{}`),
+			lag:   800,
+			want:  nil,
+		},
+		{
+			title: "ScaleUp",
+			res:   resourceWith(`{"state":"RUNNING",`+autoscaling+`,"firehose":{"replicas":1,"kafka_topic":"topic","kafka_consumer_id":"group"}}`, `{"last_scaled_at":"`+hourAgo.Format(time.RFC3339)+`"}`),
+			lag:   2500,
+			want: &module.ScaleDecision{
+				Action: module.ActionRequest{Name: ScaleAction, Params: []byte(`{"replicas":3}`)},
+				Reason: "consumer lag 2500 is above the target 1000, replicas 1 -> 3",
+			},
+		},
+		{
+			title:      "ScaleUpCappedAtPartitions",
+			res:        resourceWith(`{"state":"RUNNING",`+autoscaling+`,"firehose":{"replicas":1,"kafka_topic":"topic","kafka_consumer_id":"group"}}`, `{}`),
+			lag:        2500,
+			partitions: 2,
+			want: &module.ScaleDecision{
+				Action: module.ActionRequest{Name: ScaleAction, Params: []byte(`{"replicas":2}`)},
+				Reason: "consumer lag 2500 is above the target 1000 (capped at 2 partitions), replicas 1 -> 2",
+			},
+		},
+		{
+			title: "ScaleDown",
+			res:   resourceWith(`{"state":"RUNNING",`+autoscaling+`,"firehose":{"replicas":3,"kafka_topic":"topic","kafka_consumer_id":"group"}}`, `{}`),
+			lag:   10,
+			want: &module.ScaleDecision{
+				Action: module.ActionRequest{Name: ScaleAction, Params: []byte(`{"replicas":2}`)},
+				Reason: "consumer lag 10 is below half of the target 1000, replicas 3 -> 2",
+			},
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			m := firehoseModule{
+				clock: func() time.Time { return now },
+				consumerLag: func(ctx context.Context, brokers []string, group, topic string) (*kafka.ConsumerLag, error) {
+					_, hasDeadline := ctx.Deadline()
+					assert.True(t, hasDeadline)
+					if tt.lagErr != nil {
+						return nil, tt.lagErr
+					}
+					return &kafka.ConsumerLag{Group: group, Topic: topic, Total: tt.lag, Partitions: make([]kafka.PartitionLag, tt.partitions)}, nil
+				},
+			}
+
+			got, err := m.Autoscale(context.Background(), tt.res)
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tt.wantErr))
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -1,17 +1,54 @@
 package firehose
 
 import (
+	"bytes"
+	"crypto/sha256"
 	_ "embed"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/odpf/entropy/core/module"
 	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/core/secret"
+	"github.com/odpf/entropy/modules/kafkatopic"
+	"github.com/odpf/entropy/modules/kubenamespace"
+	"github.com/odpf/entropy/modules/postgresdatabase"
 	"github.com/odpf/entropy/pkg/errors"
 	"github.com/odpf/entropy/pkg/helm"
 )
 
-const firehoseConsumerIDStartingSequence = "0001"
+const (
+	firehoseConsumerIDStartingSequence = "0001"
+
+	keyKafkaTopicDependency = "kafka_topic"
+	keyDatabaseDependency   = "postgres_database"
+	keyNamespaceDependency  = "kube_namespace"
+
+	envJDBCURL      = "SINK_JDBC_URL"
+	envJDBCUsername = "SINK_JDBC_USERNAME"
+	envJDBCPassword = "SINK_JDBC_PASSWORD"
+
+	restartedAtAnnotation = "entropy.odpf.io/restarted-at"
+
+	// secretsChecksumAnnotation holds the checksum of the values in the
+	// kubernetes secret, so that the pods are replaced when any changes.
+	secretsChecksumAnnotation = "entropy.odpf.io/secrets-checksum"
+
+	// configsValue is the helm value that records the configs of the
+	// firehose in every revision of the release, so that a rollback can
+	// restore them. It is not used by the chart.
+	configsValue = "entropyConfigs"
+)
+
+// consumerIDSequence matches the sequence at the end of a consumer ID.
+var consumerIDSequence = regexp.MustCompile(`^(.+)-(\d+)$`)
 
 var (
 	//go:embed schema/config.json
@@ -22,19 +59,59 @@ var (
 
 	//go:embed schema/reset.json
 	resetActionSchema string
+
+	//go:embed schema/rollback.json
+	rollbackActionSchema string
+
+	//go:embed schema/output.json
+	outputSchema string
 )
 
 type moduleConfig struct {
-	State    string                 `json:"state"`
-	StopTime *time.Time             `json:"stop_time"`
-	Telegraf map[string]interface{} `json:"telegraf"`
+	State     string                 `json:"state"`
+	StopTime  *time.Time             `json:"stop_time"`
+	Namespace string                 `json:"namespace,omitempty"`
+	Telegraf  map[string]interface{} `json:"telegraf"`
+
+	// Autoscaling, if set, lets Entropy scale the firehose based on the
+	// consumer lag.
+	Autoscaling *autoscalingPolicy `json:"autoscaling,omitempty"`
+
 	Firehose struct {
-		Replicas           int               `json:"replicas"`
-		KafkaBrokerAddress string            `json:"kafka_broker_address"`
-		KafkaTopic         string            `json:"kafka_topic"`
-		KafkaConsumerID    string            `json:"kafka_consumer_id"`
-		EnvVariables       map[string]string `json:"env_variables"`
+		Replicas           int                 `json:"replicas"`
+		KafkaBrokerAddress string              `json:"kafka_broker_address"`
+		KafkaTopic         string              `json:"kafka_topic"`
+		KafkaConsumerID    string              `json:"kafka_consumer_id"`
+		EnvVariables       map[string]envValue `json:"env_variables"`
+
+		// podSpec holds the compute resources & scheduling of the pods.
+		podSpec
 	} `json:"firehose"`
+
+	// dependencySecrets are the secret env variables derived from the
+	// dependencies during sync. These are never persisted.
+	dependencySecrets map[string]string
+
+	// secretsChecksum is the checksum of the rendered secret data, set
+	// during sync (see secretsChecksum). It is never persisted.
+	secretsChecksum string
+}
+
+// nextConsumerID returns the consumer ID with its sequence incremented, e.g.
+// 'x-firehose-0002' for 'x-firehose-0001'. A sequence is added to consumer
+// IDs without one.
+func nextConsumerID(consumerID string) string {
+	match := consumerIDSequence.FindStringSubmatch(consumerID)
+	if match == nil {
+		return fmt.Sprintf("%s-%s", consumerID, firehoseConsumerIDStartingSequence)
+	}
+
+	seq, err := strconv.Atoi(match[2])
+	if err != nil {
+		// sequence is too long to be a number.
+		return fmt.Sprintf("%s-%s", consumerID, firehoseConsumerIDStartingSequence)
+	}
+	return fmt.Sprintf("%s-%0*d", match[1], len(match[2]), seq+1)
 }
 
 func (mc *moduleConfig) validateAndSanitize(r resource.Resource) error {
@@ -47,6 +124,135 @@ func (mc *moduleConfig) validateAndSanitize(r resource.Resource) error {
 		mc.Firehose.KafkaConsumerID = fmt.Sprintf("%s-%s", generateFirehoseName(r), firehoseConsumerIDStartingSequence)
 	}
 
+	if mc.Autoscaling != nil {
+		if err := mc.Autoscaling.validateAndSanitize(); err != nil {
+			return err
+		}
+	}
+	return mc.Firehose.podSpec.validate()
+}
+
+// resolveKafkaSource fills the topic and broker address from the kafka_topic
+// dependency, if the resource has one. Values set explicitly in the config
+// must match the ones of the dependency.
+func (mc *moduleConfig) resolveKafkaSource(deps map[string]module.ResolvedDependency) error {
+	dep, found := deps[keyKafkaTopicDependency]
+	if found {
+		if dep.Kind != kafkatopic.Module.Kind {
+			return errors.ErrInvalid.
+				WithMsgf("value for '%s' must be of kind '%s', not '%s'", keyKafkaTopicDependency, kafkatopic.Module.Kind, dep.Kind)
+		}
+
+		var topic kafkatopic.Output
+		if err := json.Unmarshal(dep.Output, &topic); err != nil {
+			return errors.ErrInvalid.WithMsgf("invalid kafka_topic output: %v", err)
+		} else if topic.Name == "" || len(topic.Brokers) == 0 {
+			return errors.ErrInvalid.WithMsgf("kafka_topic dependency is not ready yet")
+		}
+		brokers := strings.Join(topic.Brokers, ",")
+
+		if mc.Firehose.KafkaTopic == "" {
+			mc.Firehose.KafkaTopic = topic.Name
+		} else if mc.Firehose.KafkaTopic != topic.Name {
+			return errors.ErrInvalid.
+				WithMsgf("kafka_topic '%s' does not match the dependency topic '%s'", mc.Firehose.KafkaTopic, topic.Name)
+		}
+
+		if mc.Firehose.KafkaBrokerAddress == "" {
+			mc.Firehose.KafkaBrokerAddress = brokers
+		} else if mc.Firehose.KafkaBrokerAddress != brokers {
+			return errors.ErrInvalid.
+				WithMsgf("kafka_broker_address '%s' does not match the dependency brokers '%s'", mc.Firehose.KafkaBrokerAddress, brokers)
+		}
+	}
+
+	if mc.Firehose.KafkaTopic == "" || mc.Firehose.KafkaBrokerAddress == "" {
+		return errors.ErrInvalid.
+			WithMsgf("kafka_topic and kafka_broker_address must be set, or a '%s' dependency must be used", keyKafkaTopicDependency)
+	}
+	return nil
+}
+
+// resolveNamespace sets the namespace to deploy into from the kube_namespace
+// dependency, if the resource has one. Otherwise, the namespace set in the
+// config (an existing namespace) is used, or the one from the module config
+// if it is not set.
+func (mc *moduleConfig) resolveNamespace(deps map[string]module.ResolvedDependency) error {
+	dep, found := deps[keyNamespaceDependency]
+	if !found {
+		if mc.Namespace != "" {
+			if errs := validation.IsDNS1123Label(mc.Namespace); len(errs) > 0 {
+				return errors.ErrInvalid.WithMsgf("invalid namespace '%s': %s", mc.Namespace, strings.Join(errs, ", "))
+			}
+		}
+		return nil
+	} else if dep.Kind != kubenamespace.Module.Kind {
+		return errors.ErrInvalid.
+			WithMsgf("value for '%s' must be of kind '%s', not '%s'", keyNamespaceDependency, kubenamespace.Module.Kind, dep.Kind)
+	}
+
+	var ns kubenamespace.Output
+	if err := json.Unmarshal(dep.Output, &ns); err != nil {
+		return errors.ErrInvalid.WithMsgf("invalid kube_namespace output: %v", err)
+	} else if ns.Namespace == "" {
+		return errors.ErrInvalid.WithMsgf("kube_namespace dependency is not ready yet")
+	}
+
+	if mc.Namespace != "" && mc.Namespace != ns.Namespace {
+		return errors.ErrInvalid.
+			WithMsgf("namespace '%s' does not match the dependency namespace '%s'", mc.Namespace, ns.Namespace)
+	}
+	mc.Namespace = ns.Namespace
+	return nil
+}
+
+// validateDatabaseDependency ensures the postgres_database dependency, if
+// the resource has one, is of the right kind and the JDBC env variables are
+// not set explicitly.
+func (mc *moduleConfig) validateDatabaseDependency(deps map[string]module.ResolvedDependency) error {
+	dep, found := deps[keyDatabaseDependency]
+	if !found {
+		return nil
+	} else if dep.Kind != postgresdatabase.Module.Kind {
+		return errors.ErrInvalid.
+			WithMsgf("value for '%s' must be of kind '%s', not '%s'", keyDatabaseDependency, postgresdatabase.Module.Kind, dep.Kind)
+	}
+
+	for _, key := range []string{envJDBCURL, envJDBCUsername, envJDBCPassword} {
+		if _, isSet := mc.Firehose.EnvVariables[key]; isSet {
+			return errors.ErrInvalid.
+				WithMsgf("env variable '%s' must not be set when '%s' dependency is used", key, keyDatabaseDependency)
+		}
+	}
+	return nil
+}
+
+// applyDatabaseDependency sets the JDBC env variables using the connection
+// details from the postgres_database dependency, if the resource has one.
+// The password is rendered into the kubernetes secret (see secretData).
+func (mc *moduleConfig) applyDatabaseDependency(deps map[string]module.ResolvedDependency) error {
+	if err := mc.validateDatabaseDependency(deps); err != nil {
+		return err
+	}
+
+	dep, found := deps[keyDatabaseDependency]
+	if !found {
+		return nil
+	}
+
+	var db postgresdatabase.Output
+	if err := json.Unmarshal(dep.Output, &db); err != nil {
+		return errors.ErrInvalid.WithMsgf("invalid postgres_database output: %v", err)
+	} else if db.Password == "" {
+		return errors.ErrInvalid.WithMsgf("postgres_database dependency is not ready yet")
+	}
+
+	if mc.Firehose.EnvVariables == nil {
+		mc.Firehose.EnvVariables = map[string]envValue{}
+	}
+	mc.Firehose.EnvVariables[envJDBCURL] = envValue{Value: db.JDBCURL}
+	mc.Firehose.EnvVariables[envJDBCUsername] = envValue{Value: db.Username}
+	mc.dependencySecrets = map[string]string{envJDBCPassword: db.Password}
 	return nil
 }
 
@@ -63,33 +269,137 @@ func (mc *moduleConfig) GetHelmReleaseConfig(r resource.Resource) (*helm.Release
 	rc.Repository = defaults.ChartRepository
 	rc.Chart = defaults.ChartName
 	rc.Namespace = defaults.Namespace
+	if mc.Namespace != "" {
+		rc.Namespace = mc.Namespace
+	}
 	rc.ForceUpdate = true
 	rc.Version = defaults.ChartVersion
+	// the rollout is checked by the release_rollout step instead, without
+	// blocking the worker.
+	rc.Wait = false
 
 	fc := mc.Firehose
-	fc.EnvVariables["SOURCE_KAFKA_BROKERS"] = fc.KafkaBrokerAddress
-	fc.EnvVariables["SOURCE_KAFKA_TOPIC"] = fc.KafkaTopic
-	fc.EnvVariables["SOURCE_KAFKA_CONSUMER_GROUP_ID"] = fc.KafkaConsumerID
+	envVars := map[string]string{}
+	for name, val := range fc.EnvVariables {
+		if val.SecretRef == "" {
+			envVars[name] = val.Value
+		}
+	}
+	envVars["SOURCE_KAFKA_BROKERS"] = fc.KafkaBrokerAddress
+	envVars["SOURCE_KAFKA_TOPIC"] = fc.KafkaTopic
+	envVars["SOURCE_KAFKA_CONSUMER_GROUP_ID"] = fc.KafkaConsumerID
+
+	firehoseValues := map[string]interface{}{
+		"image": map[string]interface{}{
+			"repository": defaults.ImageRepository,
+			"pullPolicy": defaults.ImagePullPolicy,
+			"tag":        defaults.ImageTag,
+		},
+		"config": envVars,
+	}
+	if mc.hasSecretRefs() {
+		// values of secret env variables are rendered into a kubernetes
+		// secret (see secretData) instead of the helm values.
+		firehoseValues["envFrom"] = []map[string]interface{}{
+			{"secretRef": map[string]interface{}{"name": generateSecretName(r)}},
+		}
+	}
+
+	data, err := readModuleData(r.State.ModuleData)
+	if err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid module data: %v", err)
+	}
+
+	podFirehoseValues, podValues, err := fc.podSpec.helmValues()
+	if err != nil {
+		return nil, err
+	}
+	// a change in the pod template makes kubernetes replace the pods one
+	// by one, as done by 'kubectl rollout restart'.
+	entropyAnnotations := map[string]string{}
+	if data.RestartedAt != nil {
+		entropyAnnotations[restartedAtAnnotation] = data.RestartedAt.UTC().Format(time.RFC3339)
+	}
+	if mc.secretsChecksum != "" {
+		entropyAnnotations[secretsChecksumAnnotation] = mc.secretsChecksum
+	}
+	if len(entropyAnnotations) > 0 {
+		annotations := map[string]string{}
+		for k, v := range fc.PodAnnotations {
+			annotations[k] = v
+		}
+		for k, v := range entropyAnnotations {
+			annotations[k] = v
+		}
+		podValues["podAnnotations"] = annotations
+	}
+	for k, v := range podFirehoseValues {
+		firehoseValues[k] = v
+	}
 
 	hv := map[string]interface{}{
 		"replicaCount": mc.Firehose.Replicas,
-		"firehose": map[string]interface{}{
-			"image": map[string]interface{}{
-				"repository": defaults.ImageRepository,
-				"pullPolicy": defaults.ImagePullPolicy,
-				"tag":        defaults.ImageTag,
-			},
-			"config": fc.EnvVariables,
-		},
+		"firehose":     firehoseValues,
+	}
+	for k, v := range podValues {
+		hv[k] = v
 	}
 	if len(mc.Telegraf) > 0 {
 		hv["telegraf"] = mc.Telegraf
 	}
+	if len(r.Spec.Configs) > 0 {
+		hv[configsValue] = string(r.Spec.Configs)
+	}
 	rc.Values = hv
 
 	return rc, nil
 }
 
+func (mc *moduleConfig) hasSecretRefs() bool {
+	if len(mc.dependencySecrets) > 0 {
+		return true
+	}
+	for _, val := range mc.Firehose.EnvVariables {
+		if val.SecretRef != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// secretData returns the env variables referring to secrets, with values
+// resolved using the given secret values.
+func (mc *moduleConfig) secretData(secrets map[string]string) (map[string]string, error) {
+	data := map[string]string{}
+	for name, val := range mc.dependencySecrets {
+		data[name] = val
+	}
+	for name, val := range mc.Firehose.EnvVariables {
+		if val.SecretRef == "" {
+			continue
+		}
+
+		secretVal, found := secrets[val.SecretRef]
+		if !found {
+			return nil, errors.ErrInvalid.
+				WithMsgf("secret '%s' referred by env variable '%s' is not available", val.SecretRef, name)
+		}
+		data[name] = secretVal
+	}
+	return data, nil
+}
+
+// secretsChecksum returns the SHA-256 checksum of the secret data.
+func secretsChecksum(data map[string]string) string {
+	// map keys are marshalled in sorted order.
+	b, err := json.Marshal(data)
+	if err != nil {
+		panic(err)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
 func (mc *moduleConfig) JSON() []byte {
 	b, err := json.Marshal(mc)
 	if err != nil {
@@ -101,3 +411,37 @@ func (mc *moduleConfig) JSON() []byte {
 func generateFirehoseName(r resource.Resource) string {
 	return fmt.Sprintf("%s-%s-firehose", r.Project, r.Name)
 }
+
+func generateSecretName(r resource.Resource) string {
+	return fmt.Sprintf("%s-secrets", generateFirehoseName(r))
+}
+
+// envValue is the value of an env variable. It is either a plain string
+// or a reference to a project secret (i.e., {"secret_ref": "<name>"}).
+type envValue struct {
+	Value     string
+	SecretRef string
+}
+
+func (ev envValue) MarshalJSON() ([]byte, error) {
+	if ev.SecretRef != "" {
+		return json.Marshal(secret.Ref{Name: ev.SecretRef})
+	}
+	return json.Marshal(ev.Value)
+}
+
+func (ev *envValue) UnmarshalJSON(b []byte) error {
+	if trimmed := bytes.TrimSpace(b); len(trimmed) > 0 && trimmed[0] == '{' {
+		var ref secret.Ref
+		if err := json.Unmarshal(trimmed, &ref); err != nil {
+			return err
+		} else if ref.Name == "" {
+			return errors.ErrInvalid.WithMsgf("value for '%s' must be set", secret.RefKey)
+		}
+		*ev = envValue{SecretRef: ref.Name}
+		return nil
+	}
+
+	*ev = envValue{}
+	return json.Unmarshal(b, &ev.Value)
+}
@@ -0,0 +1,292 @@
+package firehose
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+func TestModuleConfig_SecretRefs(t *testing.T) {
+	t.Parallel()
+
+	res := resource.Resource{
+		URN:     "orn:entropy:firehose:demo:test",
+		Kind:    "firehose",
+		Name:    "test",
+		Project: "demo",
+		State: resource.State{
+			Output: Output{Defaults: firehoseModuleWithDefaultConfigs().Config}.JSON(),
+		},
+	}
+
+	configs := `{"firehose":{"replicas":1,"kafka_broker_address":"localhost:9092","kafka_topic":"topic","kafka_consumer_id":"consumer","env_variables":{"SINK_TYPE":"HTTP","SINK_HTTP_OAUTH2_CLIENT_SECRET":{"secret_ref":"oauth-secret"}}}}`
+
+	var conf moduleConfig
+	require.NoError(t, json.Unmarshal([]byte(configs), &conf))
+
+	// secret references must be retained as-is in the configs.
+	assert.Contains(t, string(conf.JSON()), `"SINK_HTTP_OAUTH2_CLIENT_SECRET":{"secret_ref":"oauth-secret"}`)
+
+	hc, err := conf.GetHelmReleaseConfig(res)
+	require.NoError(t, err)
+
+	firehoseValues := hc.Values["firehose"].(map[string]interface{})
+	assert.Equal(t, map[string]string{
+		"SINK_TYPE":                      "HTTP",
+		"SOURCE_KAFKA_BROKERS":           "localhost:9092",
+		"SOURCE_KAFKA_TOPIC":             "topic",
+		"SOURCE_KAFKA_CONSUMER_GROUP_ID": "consumer",
+	}, firehoseValues["config"])
+	assert.Equal(t, []map[string]interface{}{
+		{"secretRef": map[string]interface{}{"name": "demo-test-firehose-secrets"}},
+	}, firehoseValues["envFrom"])
+
+	data, err := conf.secretData(map[string]string{"oauth-secret": "s3cr3t"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"SINK_HTTP_OAUTH2_CLIENT_SECRET": "s3cr3t"}, data)
+
+	_, err = conf.secretData(nil)
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+
+	// pods must be replaced when the secret values change.
+	conf.secretsChecksum = secretsChecksum(data)
+	hc, err = conf.GetHelmReleaseConfig(res)
+	require.NoError(t, err)
+	annotations := hc.Values["podAnnotations"].(map[string]string)
+	assert.Len(t, annotations[secretsChecksumAnnotation], 64)
+	assert.Equal(t, secretsChecksum(map[string]string{"SINK_HTTP_OAUTH2_CLIENT_SECRET": "s3cr3t"}), annotations[secretsChecksumAnnotation])
+	assert.NotEqual(t, secretsChecksum(map[string]string{"SINK_HTTP_OAUTH2_CLIENT_SECRET": "rotated"}), annotations[secretsChecksumAnnotation])
+
+	// the configs recorded in the release have the secret refs only.
+	res.Spec.Configs = conf.JSON()
+	hc, err = conf.GetHelmReleaseConfig(res)
+	require.NoError(t, err)
+	assert.Equal(t, string(conf.JSON()), hc.Values[configsValue])
+	assert.NotContains(t, hc.Values[configsValue], "s3cr3t")
+}
+
+func TestModuleConfig_DatabaseDependency(t *testing.T) {
+	t.Parallel()
+
+	res := resource.Resource{
+		URN:     "orn:entropy:firehose:demo:test",
+		Kind:    "firehose",
+		Name:    "test",
+		Project: "demo",
+		State: resource.State{
+			Output: Output{Defaults: firehoseModuleWithDefaultConfigs().Config}.JSON(),
+		},
+	}
+
+	deps := map[string]module.ResolvedDependency{
+		"kube_cluster": {Kind: "kubernetes"},
+		"postgres_database": {
+			Kind:   "postgres_database",
+			Output: []byte(`{"host":"localhost","port":5432,"database":"orders","username":"orders_owner","password":"s3cr3t","jdbc_url":"jdbc:postgresql://localhost:5432/orders"}`),
+		},
+	}
+
+	var conf moduleConfig
+	require.NoError(t, json.Unmarshal([]byte(`{"firehose":{"replicas":1,"kafka_broker_address":"localhost:9092","kafka_topic":"topic","kafka_consumer_id":"consumer","env_variables":{"SINK_TYPE":"JDBC"}}}`), &conf))
+	require.NoError(t, conf.applyDatabaseDependency(deps))
+
+	// password must never end up in the configs.
+	assert.NotContains(t, string(conf.JSON()), "s3cr3t")
+
+	hc, err := conf.GetHelmReleaseConfig(res)
+	require.NoError(t, err)
+
+	firehoseValues := hc.Values["firehose"].(map[string]interface{})
+	assert.Equal(t, map[string]string{
+		"SINK_TYPE":                      "JDBC",
+		"SINK_JDBC_URL":                  "jdbc:postgresql://localhost:5432/orders",
+		"SINK_JDBC_USERNAME":             "orders_owner",
+		"SOURCE_KAFKA_BROKERS":           "localhost:9092",
+		"SOURCE_KAFKA_TOPIC":             "topic",
+		"SOURCE_KAFKA_CONSUMER_GROUP_ID": "consumer",
+	}, firehoseValues["config"])
+	assert.NotNil(t, firehoseValues["envFrom"])
+
+	data, err := conf.secretData(nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"SINK_JDBC_PASSWORD": "s3cr3t"}, data)
+
+	var explicit moduleConfig
+	require.NoError(t, json.Unmarshal([]byte(`{"firehose":{"env_variables":{"SINK_JDBC_URL":"jdbc:postgresql://other/db"}}}`), &explicit))
+	assert.True(t, errors.Is(explicit.validateDatabaseDependency(deps), errors.ErrInvalid))
+}
+
+func TestModuleConfig_Namespace(t *testing.T) {
+	t.Parallel()
+
+	res := resource.Resource{
+		URN:     "orn:entropy:firehose:demo:test",
+		Kind:    "firehose",
+		Name:    "test",
+		Project: "demo",
+		State: resource.State{
+			Output: Output{Defaults: firehoseModuleWithDefaultConfigs().Config}.JSON(),
+		},
+	}
+
+	var conf moduleConfig
+	require.NoError(t, json.Unmarshal([]byte(`{"firehose":{"replicas":1}}`), &conf))
+
+	hc, err := conf.GetHelmReleaseConfig(res)
+	require.NoError(t, err)
+	assert.Equal(t, "firehose", hc.Namespace)
+
+	require.NoError(t, conf.resolveNamespace(map[string]module.ResolvedDependency{
+		"kube_namespace": {Kind: "kube_namespace", Output: []byte(`{"namespace":"team-data"}`)},
+	}))
+
+	hc, err = conf.GetHelmReleaseConfig(res)
+	require.NoError(t, err)
+	assert.Equal(t, "team-data", hc.Namespace)
+}
+
+func TestModuleConfig_PodSpec(t *testing.T) {
+	t.Parallel()
+
+	res := resource.Resource{
+		URN:     "orn:entropy:firehose:demo:test",
+		Kind:    "firehose",
+		Name:    "test",
+		Project: "demo",
+		State: resource.State{
+			Output: Output{Defaults: firehoseModuleWithDefaultConfigs().Config}.JSON(),
+		},
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		t.Parallel()
+
+		configs := `{"firehose":{"replicas":1,"kafka_consumer_id":"orders","resources":{"requests":{"cpu":"500m","memory":"1Gi"},"limits":{"memory":"2Gi"}},"node_selector":{"pool":"sinks"},"tolerations":[{"key":"dedicated","operator":"Equal","value":"sinks","effect":"NoSchedule"}],"affinity":{"nodeAffinity":{"requiredDuringSchedulingIgnoredDuringExecution":{"nodeSelectorTerms":[{"matchExpressions":[{"key":"zone","operator":"In","values":["a"]}]}]}}},"pod_annotations":{"prometheus.io/scrape":"true"},"pod_labels":{"team":"data"}}}`
+
+		var conf moduleConfig
+		require.NoError(t, json.Unmarshal([]byte(configs), &conf))
+		require.NoError(t, conf.validateAndSanitize(res))
+
+		// pod spec must be retained in the configs.
+		assert.Contains(t, string(conf.JSON()), `"node_selector":{"pool":"sinks"}`)
+
+		hc, err := conf.GetHelmReleaseConfig(res)
+		require.NoError(t, err)
+
+		firehoseValues := hc.Values["firehose"].(map[string]interface{})
+		assert.Equal(t, map[string]interface{}{
+			"requests": map[string]string{"cpu": "500m", "memory": "1Gi"},
+			"limits":   map[string]string{"memory": "2Gi"},
+		}, firehoseValues["resources"])
+		assert.Equal(t, map[string]string{"pool": "sinks"}, hc.Values["nodeSelector"])
+		assert.Equal(t, map[string]string{"prometheus.io/scrape": "true"}, hc.Values["podAnnotations"])
+		assert.Equal(t, map[string]string{"team": "data"}, hc.Values["podLabels"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"key": "dedicated", "operator": "Equal", "value": "sinks", "effect": "NoSchedule"},
+		}, hc.Values["tolerations"])
+		assert.Contains(t, hc.Values["affinity"], "nodeAffinity")
+	})
+
+	t.Run("Defaults", func(t *testing.T) {
+		t.Parallel()
+
+		var conf moduleConfig
+		require.NoError(t, json.Unmarshal([]byte(`{"firehose":{"replicas":1}}`), &conf))
+
+		hc, err := conf.GetHelmReleaseConfig(res)
+		require.NoError(t, err)
+
+		// chart defaults must be used when the pod spec is not set.
+		assert.NotContains(t, hc.Values["firehose"], "resources")
+		for _, key := range []string{"nodeSelector", "tolerations", "affinity", "podAnnotations", "podLabels"} {
+			assert.NotContains(t, hc.Values, key)
+		}
+	})
+
+	t.Run("Restarted", func(t *testing.T) {
+		t.Parallel()
+
+		restartedAt := time.Date(2022, 4, 21, 10, 29, 15, 0, time.UTC)
+		restarted := res
+		restarted.State.ModuleData = moduleData{RestartedAt: &restartedAt}.JSON()
+
+		var conf moduleConfig
+		require.NoError(t, json.Unmarshal([]byte(`{"firehose":{"replicas":1,"pod_annotations":{"prometheus.io/scrape":"true"}}}`), &conf))
+
+		hc, err := conf.GetHelmReleaseConfig(restarted)
+		require.NoError(t, err)
+
+		// the restart annotation is added along with the configured ones.
+		assert.Equal(t, map[string]string{
+			"prometheus.io/scrape":         "true",
+			"entropy.odpf.io/restarted-at": "2022-04-21T10:29:15Z",
+		}, hc.Values["podAnnotations"])
+		assert.Equal(t, map[string]string{"prometheus.io/scrape": "true"}, conf.Firehose.PodAnnotations)
+	})
+
+	invalid := []struct {
+		title   string
+		configs string
+	}{
+		{title: "InvalidQuantity", configs: `{"resources":{"limits":{"memory":"2 gigs"}}}`},
+		{title: "RequestAboveLimit", configs: `{"resources":{"requests":{"cpu":"2"},"limits":{"cpu":"1500m"}}}`},
+		{title: "InvalidNodeSelector", configs: `{"node_selector":{"pool":"sinks/large"}}`},
+		{title: "InvalidPodLabel", configs: `{"pod_labels":{"-team":"data"}}`},
+		{title: "InvalidAnnotation", configs: `{"pod_annotations":{"prometheus.io/scrape/port":"8080"}}`},
+		{title: "ExistsTolerationWithValue", configs: `{"tolerations":[{"key":"dedicated","operator":"Exists","value":"sinks"}]}`},
+	}
+
+	for _, tt := range invalid {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			var conf moduleConfig
+			require.NoError(t, json.Unmarshal([]byte(`{"firehose":`+tt.configs+`}`), &conf))
+			err := conf.validateAndSanitize(res)
+			assert.True(t, errors.Is(err, errors.ErrInvalid))
+		})
+	}
+}
+
+func TestNextConsumerID(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		consumerID string
+		want       string
+	}{
+		{consumerID: "demo-orders-firehose-0001", want: "demo-orders-firehose-0002"},
+		{consumerID: "demo-orders-firehose-0099", want: "demo-orders-firehose-0100"},
+		{consumerID: "demo-orders-firehose-9999", want: "demo-orders-firehose-10000"},
+		{consumerID: "orders-sink", want: "orders-sink-0001"},
+		{consumerID: "orders-sink-v2", want: "orders-sink-v2-0001"},
+	}
+
+	for _, tt := range table {
+		assert.Equal(t, tt.want, nextConsumerID(tt.consumerID), tt.consumerID)
+	}
+}
+
+func TestModule_Secrets(t *testing.T) {
+	t.Parallel()
+
+	secrets, err := Module.Secrets()
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"firehose.env_variables.SINK_HTTP_HEADERS",
+		"firehose.env_variables.SINK_HTTP_OAUTH2_CLIENT_SECRET",
+		"firehose.env_variables.SINK_INFLUX_PASSWORD",
+		"firehose.env_variables.SINK_JDBC_PASSWORD",
+		"firehose.env_variables.SINK_MONGO_AUTH_PASSWORD",
+		"firehose.env_variables.SOURCE_KAFKA_CONSUMER_CONFIG_SASL_JAAS_CONFIG",
+	}, secrets.Configs)
+	assert.Equal(t, []string{"release_history.*.configs"}, secrets.Output)
+}
@@ -1,11 +1,46 @@
 package firehose
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/odpf/entropy/pkg/kube"
+)
 
 type moduleData struct {
-	PendingSteps  []string `json:"pending_steps"`
-	ResetTo       string   `json:"reset_to,omitempty"`
-	StateOverride string   `json:"state_override,omitempty"`
+	PendingSteps  []string     `json:"pending_steps"`
+	Reset         *resetParams `json:"reset,omitempty"`
+	StateOverride string       `json:"state_override,omitempty"`
+
+	// RollbackTo is the helm revision that the release_rollback step rolls
+	// the release back to.
+	RollbackTo int `json:"rollback_to,omitempty"`
+
+	// RotatedFrom is the previous consumer group, when the consumer group
+	// is being rotated.
+	RotatedFrom string `json:"rotated_from,omitempty"`
+
+	// ResetTo is the reset target planned by the older versions, and is
+	// used only if Reset is not set.
+	ResetTo string `json:"reset_to,omitempty"`
+
+	// LastScaledAt is the time of the last scale action, manual or by the
+	// autoscaler. It is carried over to every new plan.
+	LastScaledAt *time.Time `json:"last_scaled_at,omitempty"`
+
+	// RestartedAt is the time of the last restart action. It is set as a
+	// pod annotation, so it is carried over to every new plan to avoid
+	// restarting the pods again.
+	RestartedAt *time.Time `json:"restarted_at,omitempty"`
+
+	// RolloutDeadline is the time until which the release_rollout step
+	// waits for the rollout to complete.
+	RolloutDeadline *time.Time `json:"rollout_deadline,omitempty"`
+
+	// FailedRollout is the rollout that failed while the firehose was
+	// stopped for the remaining steps. It is reported once the release is
+	// restored to the configured state.
+	FailedRollout *kube.RolloutStatus `json:"failed_rollout,omitempty"`
 }
 
 func (md moduleData) JSON() json.RawMessage {
@@ -15,3 +50,14 @@ func (md moduleData) JSON() json.RawMessage {
 	}
 	return bytes
 }
+
+func readModuleData(data json.RawMessage) (*moduleData, error) {
+	var md moduleData
+	if len(data) == 0 {
+		return &md, nil
+	}
+	if err := json.Unmarshal(data, &md); err != nil {
+		return nil, err
+	}
+	return &md, nil
+}
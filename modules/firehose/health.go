@@ -0,0 +1,67 @@
+package firehose
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/kube"
+)
+
+const waitingCrashLoop = "CrashLoopBackOff"
+
+func (m *firehoseModule) Health(ctx context.Context, res module.ExpandedResource) (*resource.Health, error) {
+	var conf moduleConfig
+	if err := json.Unmarshal(res.Resource.Spec.Configs, &conf); err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid config json: %v", err)
+	}
+
+	pods, err := m.podDetails(ctx, res)
+	if err != nil {
+		return &resource.Health{
+			Status:  resource.HealthUnknown,
+			Reasons: []string{fmt.Sprintf("failed to fetch pods: %v", err)},
+		}, nil
+	}
+
+	health := m.evaluateHealth(conf, pods)
+	return &health, nil
+}
+
+// evaluateHealth flags a running firehose as degraded when any of its pods
+// is crash-looping, or when fewer pods than the replicas are ready. Stopped
+// firehoses are always healthy.
+func (m *firehoseModule) evaluateHealth(conf moduleConfig, pods []kube.Pod) resource.Health {
+	stopped := conf.State == stateStopped || (conf.StopTime != nil && conf.StopTime.Before(m.clock()))
+	if stopped || conf.Firehose.Replicas == 0 {
+		return resource.Health{Status: resource.HealthHealthy}
+	}
+
+	var reasons []string
+	ready := 0
+	for _, pod := range pods {
+		if pod.Ready {
+			ready++
+		}
+
+		if pod.WaitingReason == waitingCrashLoop {
+			reason := fmt.Sprintf("pod '%s' is crash-looping after %d restarts", pod.Name, pod.Restarts)
+			if pod.LastTerminationReason != "" {
+				reason += fmt.Sprintf(" (last termination: %s)", pod.LastTerminationReason)
+			}
+			reasons = append(reasons, reason)
+		}
+	}
+
+	if ready < conf.Firehose.Replicas {
+		reasons = append(reasons, fmt.Sprintf("%d of %d pods are ready", ready, conf.Firehose.Replicas))
+	}
+
+	if len(reasons) > 0 {
+		return resource.Health{Status: resource.HealthDegraded, Reasons: reasons}
+	}
+	return resource.Health{Status: resource.HealthHealthy}
+}
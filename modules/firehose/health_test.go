@@ -0,0 +1,93 @@
+package firehose
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/kube"
+)
+
+func TestFirehoseModule_EvaluateHealth(t *testing.T) {
+	t.Parallel()
+
+	running := func(replicas int) moduleConfig {
+		var conf moduleConfig
+		conf.State = stateRunning
+		conf.Firehose.Replicas = replicas
+		return conf
+	}
+	readyPod := kube.Pod{Name: "orders-firehose-1", Phase: "Running", Ready: true}
+	crashingPod := kube.Pod{
+		Name:                  "orders-firehose-2",
+		Phase:                 "Running",
+		Restarts:              7,
+		LastTerminationReason: "OOMKilled",
+		WaitingReason:         "CrashLoopBackOff",
+	}
+
+	table := []struct {
+		title string
+		conf  moduleConfig
+		pods  []kube.Pod
+		want  resource.Health
+	}{
+		{
+			title: "AllReady",
+			conf:  running(1),
+			pods:  []kube.Pod{readyPod},
+			want:  resource.Health{Status: resource.HealthHealthy},
+		},
+		{
+			title: "CrashLooping",
+			conf:  running(2),
+			pods:  []kube.Pod{readyPod, crashingPod},
+			want: resource.Health{
+				Status: resource.HealthDegraded,
+				Reasons: []string{
+					"pod 'orders-firehose-2' is crash-looping after 7 restarts (last termination: OOMKilled)",
+					"1 of 2 pods are ready",
+				},
+			},
+		},
+		{
+			title: "MissingPods",
+			conf:  running(3),
+			pods:  []kube.Pod{readyPod, {Name: "orders-firehose-3", Phase: "Pending"}},
+			want: resource.Health{
+				Status:  resource.HealthDegraded,
+				Reasons: []string{"1 of 3 pods are ready"},
+			},
+		},
+		{
+			title: "Stopped",
+			conf: func() moduleConfig {
+				conf := running(2)
+				conf.State = stateStopped
+				return conf
+			}(),
+			want: resource.Health{Status: resource.HealthHealthy},
+		},
+		{
+			title: "PastStopTime",
+			conf: func() moduleConfig {
+				conf := running(2)
+				stopTime := frozenTime.Add(-time.Hour)
+				conf.StopTime = &stopTime
+				return conf
+			}(),
+			want: resource.Health{Status: resource.HealthHealthy},
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+			m := firehoseModule{clock: func() time.Time { return frozenTime }}
+			assert.Equal(t, tt.want, m.evaluateHealth(tt.conf, tt.pods))
+		})
+	}
+}
@@ -2,58 +2,123 @@ package kafka
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/kafka"
 	"github.com/odpf/entropy/pkg/kube"
 )
 
 const (
 	kafkaImage = "bitnami/kafka:2.0.0"
 	retries    = 6
+
+	// shellSafeChars need no quoting in the args of a sh command line.
+	shellSafeChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_-.,:/=@%+"
+
+	// datetimeFormat is the format of --to-datetime of kafka-consumer-groups.sh.
+	datetimeFormat = "2006-01-02T15:04:05.000Z07:00"
 )
 
+// offsetResetter resets the offsets of a consumer group natively.
+type offsetResetter interface {
+	ResetOffsets(ctx context.Context, group, topic string, spec kafka.ResetSpec) (*kafka.ResetResult, error)
+}
+
+// jobRunner runs a job to completion.
+type jobRunner interface {
+	RunJob(ctx context.Context, namespace, name string, image string, cmd []string, retries int32) error
+}
+
+// ConsumerGroupManager resets the offsets of consumer groups using a kafka
+// admin client. If the brokers are not reachable from entropy (e.g., they
+// are reachable only from inside the kubernetes cluster), the reset is done
+// by a job running kafka-consumer-groups.sh in the cluster instead.
 type ConsumerGroupManager struct {
 	brokers   string
-	kube      *kube.Client
+	admin     offsetResetter
+	kube      jobRunner
 	namespace string
 }
 
 func NewConsumerGroupManager(brokers string, kube *kube.Client, namespace string) *ConsumerGroupManager {
 	return &ConsumerGroupManager{
 		brokers:   brokers,
+		admin:     kafka.NewAdmin(strings.Split(brokers, ",")),
 		kube:      kube,
 		namespace: namespace,
 	}
 }
 
-func (k ConsumerGroupManager) ResetOffsetToDatetime(ctx context.Context, consumerID string, datetime string) error {
-	return k.kube.RunJob(ctx, k.namespace,
-		getJobName(consumerID),
-		kafkaImage,
-		append(k.getDefaultCMD(consumerID), "--to-datetime", datetime),
-		retries,
-	)
-}
+// ResetOffsets resets the offsets of the consumer group on the topic. The
+// per-partition result is returned only if the reset was done natively,
+// and is nil if it was done by a job.
+func (k ConsumerGroupManager) ResetOffsets(ctx context.Context, consumerID, topic string, spec kafka.ResetSpec) (*kafka.ResetResult, error) {
+	result, err := k.admin.ResetOffsets(ctx, consumerID, topic, spec)
+	if err == nil || !errors.Is(err, kafka.ErrUnreachable) {
+		return result, err
+	}
+
+	var cmd []string
+	switch spec.To {
+	case kafka.ResetEarliest:
+		cmd = append(k.getDefaultCMD(consumerID, topic), "--to-earliest")
+	case kafka.ResetLatest:
+		cmd = append(k.getDefaultCMD(consumerID, topic), "--to-latest")
+	case kafka.ResetDatetime:
+		cmd = append(k.getDefaultCMD(consumerID, topic), "--to-datetime", spec.Datetime.Format(datetimeFormat))
+	case kafka.ResetShiftBy:
+		cmd = append(k.getDefaultCMD(consumerID, topic), "--shift-by", strconv.FormatInt(spec.ShiftBy, 10))
+	case kafka.ResetPartitionOffsets:
+		// --to-offset applies the same offset to all the partitions given
+		// with --topic, so the tool is run once for every partition.
+		var partitions []int
+		for partition := range spec.Offsets {
+			partitions = append(partitions, partition)
+		}
+		sort.Ints(partitions)
+
+		var runs []string
+		for _, partition := range partitions {
+			topicPartition := fmt.Sprintf("%s:%d", topic, partition)
+			args := append(k.getDefaultCMD(consumerID, topicPartition), "--to-offset", strconv.FormatInt(spec.Offsets[partition], 10))
+			runs = append(runs, shellJoin(args))
+		}
+		cmd = []string{"sh", "-c", strings.Join(runs, " && ")}
+	case kafka.ResetFromGroup:
+		return nil, errors.ErrUnsupported.WithMsgf("reset to '%s' needs the kafka brokers to be reachable from entropy", spec.To)
+	default:
+		return nil, errors.ErrInvalid.WithMsgf("unknown reset target '%s'", spec.To)
+	}
 
-func (k ConsumerGroupManager) ResetOffsetToLatest(ctx context.Context, consumerID string) error {
-	return k.kube.RunJob(ctx, k.namespace,
+	return nil, k.kube.RunJob(ctx, k.namespace,
 		getJobName(consumerID),
 		kafkaImage,
-		append(k.getDefaultCMD(consumerID), "--to-latest"),
+		cmd,
 		retries,
 	)
 }
 
-func (k ConsumerGroupManager) ResetOffsetToEarliest(ctx context.Context, consumerID string) error {
-	return k.kube.RunJob(ctx, k.namespace,
-		getJobName(consumerID),
-		kafkaImage,
-		append(k.getDefaultCMD(consumerID), "--to-earliest"),
-		retries,
-	)
+func (k ConsumerGroupManager) getDefaultCMD(consumerID, topic string) []string {
+	return []string{"kafka-consumer-groups.sh", "--bootstrap-server", k.brokers, "--group", consumerID, "--reset-offsets", "--execute", "--topic", topic}
 }
 
-func (k ConsumerGroupManager) getDefaultCMD(consumerID string) []string {
-	return []string{"kafka-consumer-groups.sh", "--bootstrap-server", k.brokers, "--group", consumerID, "--reset-offsets", "--execute", "--all-topics"}
+// shellJoin joins the args into a command line for sh. Args are quoted
+// unless they are made of safe characters only, since group and topic
+// names come from the resource configs.
+func shellJoin(args []string) string {
+	quoted := make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg != "" && strings.Trim(arg, shellSafeChars) == "" {
+			quoted = append(quoted, arg)
+			continue
+		}
+		quoted = append(quoted, "'"+strings.ReplaceAll(arg, "'", `'\''`)+"'")
+	}
+	return strings.Join(quoted, " ")
 }
 
 func getJobName(consumerID string) string {
@@ -0,0 +1,148 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/kafka"
+)
+
+type fakeResetter struct {
+	result *kafka.ResetResult
+	err    error
+}
+
+func (fr fakeResetter) ResetOffsets(_ context.Context, _, _ string, _ kafka.ResetSpec) (*kafka.ResetResult, error) {
+	return fr.result, fr.err
+}
+
+type fakeJobRunner struct {
+	name string
+	cmd  []string
+}
+
+func (fj *fakeJobRunner) RunJob(_ context.Context, _, name string, _ string, cmd []string, _ int32) error {
+	fj.name, fj.cmd = name, cmd
+	return nil
+}
+
+func TestConsumerGroupManager_ResetOffsets(t *testing.T) {
+	t.Parallel()
+
+	resetTo := kafka.ResetSpec{
+		To:       kafka.ResetDatetime,
+		Datetime: time.Date(2022, 6, 22, 10, 30, 0, 0, time.UTC),
+	}
+
+	t.Run("Native", func(t *testing.T) {
+		t.Parallel()
+
+		want := &kafka.ResetResult{Group: "orders-firehose-0001", Topic: "orders"}
+		jobs := &fakeJobRunner{}
+		cgm := ConsumerGroupManager{
+			brokers: "localhost:9092",
+			admin:   fakeResetter{result: want},
+			kube:    jobs,
+		}
+
+		got, err := cgm.ResetOffsets(context.Background(), "orders-firehose-0001", "orders", resetTo)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+		assert.Empty(t, jobs.name)
+	})
+
+	t.Run("NativeFailed", func(t *testing.T) {
+		t.Parallel()
+
+		jobs := &fakeJobRunner{}
+		cgm := ConsumerGroupManager{
+			brokers: "localhost:9092",
+			admin:   fakeResetter{err: kafka.ErrGroupActive},
+			kube:    jobs,
+		}
+
+		got, err := cgm.ResetOffsets(context.Background(), "orders-firehose-0001", "orders", resetTo)
+		assert.True(t, errors.Is(err, kafka.ErrGroupActive))
+		assert.Nil(t, got)
+		assert.Empty(t, jobs.name)
+	})
+
+	t.Run("JobFallback", func(t *testing.T) {
+		t.Parallel()
+
+		jobs := &fakeJobRunner{}
+		cgm := ConsumerGroupManager{
+			brokers: "localhost:9092",
+			admin:   fakeResetter{err: kafka.ErrUnreachable},
+			kube:    jobs,
+		}
+
+		got, err := cgm.ResetOffsets(context.Background(), "orders-firehose-0001", "orders", resetTo)
+		require.NoError(t, err)
+		assert.Nil(t, got)
+		assert.Equal(t, "orders-firehose-0001-reset", jobs.name)
+		assert.Equal(t, []string{
+			"kafka-consumer-groups.sh", "--bootstrap-server", "localhost:9092",
+			"--group", "orders-firehose-0001", "--reset-offsets", "--execute", "--topic", "orders",
+			"--to-datetime", "2022-06-22T10:30:00.000Z",
+		}, jobs.cmd)
+	})
+
+	t.Run("JobFallbackPartitionOffsets", func(t *testing.T) {
+		t.Parallel()
+
+		jobs := &fakeJobRunner{}
+		cgm := ConsumerGroupManager{
+			brokers: "localhost:9092",
+			admin:   fakeResetter{err: kafka.ErrUnreachable},
+			kube:    jobs,
+		}
+
+		spec := kafka.ResetSpec{To: kafka.ResetPartitionOffsets, Offsets: map[int]int64{1: 500, 0: 1200}}
+		_, err := cgm.ResetOffsets(context.Background(), "orders-firehose-0001", "orders", spec)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"sh", "-c",
+			"kafka-consumer-groups.sh --bootstrap-server localhost:9092 --group orders-firehose-0001 --reset-offsets --execute --topic orders:0 --to-offset 1200 && " +
+				"kafka-consumer-groups.sh --bootstrap-server localhost:9092 --group orders-firehose-0001 --reset-offsets --execute --topic orders:1 --to-offset 500",
+		}, jobs.cmd)
+	})
+
+	t.Run("JobFallbackPartitionOffsetsQuoted", func(t *testing.T) {
+		t.Parallel()
+
+		jobs := &fakeJobRunner{}
+		cgm := ConsumerGroupManager{
+			brokers: "localhost:9092",
+			admin:   fakeResetter{err: kafka.ErrUnreachable},
+			kube:    jobs,
+		}
+
+		spec := kafka.ResetSpec{To: kafka.ResetPartitionOffsets, Offsets: map[int]int64{0: 1200}}
+		_, err := cgm.ResetOffsets(context.Background(), "orders'; rm -rf /", "orders;reboot", spec)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"sh", "-c",
+			`kafka-consumer-groups.sh --bootstrap-server localhost:9092 --group 'orders'\''; rm -rf /' --reset-offsets --execute --topic 'orders;reboot:0' --to-offset 1200`,
+		}, jobs.cmd)
+	})
+
+	t.Run("JobFallbackUnsupported", func(t *testing.T) {
+		t.Parallel()
+
+		jobs := &fakeJobRunner{}
+		cgm := ConsumerGroupManager{
+			brokers: "localhost:9092",
+			admin:   fakeResetter{err: kafka.ErrUnreachable},
+			kube:    jobs,
+		}
+
+		spec := kafka.ResetSpec{To: kafka.ResetFromGroup, Group: "orders-replay"}
+		_, err := cgm.ResetOffsets(context.Background(), "orders-firehose-0001", "orders", spec)
+		assert.True(t, errors.Is(err, errors.ErrUnsupported))
+		assert.Empty(t, jobs.name)
+	})
+}
@@ -1,11 +1,17 @@
 package firehose
 
 import (
+	"context"
 	_ "embed"
 	"encoding/json"
+	"time"
 
 	"github.com/odpf/entropy/core/module"
 	"github.com/odpf/entropy/modules/kubernetes"
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/helm"
+	"github.com/odpf/entropy/pkg/kafka"
+	"github.com/odpf/entropy/pkg/kube"
 )
 
 const (
@@ -14,12 +20,24 @@ const (
 	ScaleAction   = "scale"
 	ResetAction   = "reset"
 	UpgradeAction = "upgrade"
+	RestartAction = "restart"
+
+	RollbackReleaseAction     = "rollback_release"
+	RotateConsumerGroupAction = "rotate_consumer_group"
 )
 
 const (
 	releaseCreate = "release_create"
 	releaseUpdate = "release_update"
 	consumerReset = "consumer_reset"
+	releaseDelete = "release_delete"
+
+	releaseRollback = "release_rollback"
+
+	// releaseRollout waits for the pods of the release to be replaced by
+	// the ones of the latest revision. It is added by the sync after every
+	// release create or update.
+	releaseRollout = "release_rollout"
 )
 
 const (
@@ -31,12 +49,16 @@ const (
 	ResetToDateTime = "DATETIME"
 	ResetToEarliest = "EARLIEST"
 	ResetToLatest   = "LATEST"
+	ResetToOffsets  = "OFFSETS"
+	ResetToShiftBy  = "SHIFT_BY"
+	ResetToGroup    = "GROUP"
 )
 
 const keyKubeDependency = "kube_cluster"
 
 var Module = module.Descriptor{
-	Kind: "firehose",
+	Kind:         "firehose",
+	OutputSchema: outputSchema,
 	Dependencies: map[string]string{
 		keyKubeDependency: kubernetes.Module.Kind,
 	},
@@ -51,6 +73,10 @@ var Module = module.Descriptor{
 			Description: "Updates an existing firehose instance.",
 			ParamSchema: completeConfigSchema,
 		},
+		{
+			Name:        module.DeleteAction,
+			Description: "Uninstalls the firehose and deletes its kubernetes secret.",
+		},
 		{
 			Name:        ScaleAction,
 			Description: "Scale-up or scale-down an existing firehose instance.",
@@ -66,13 +92,27 @@ var Module = module.Descriptor{
 		},
 		{
 			Name:        ResetAction,
-			Description: "Reset firehose kafka consumer group to given timestamp",
+			Description: "Reset firehose kafka consumer group offsets",
 			ParamSchema: resetActionSchema,
 		},
 		{
 			Name:        UpgradeAction,
 			Description: "Upgrade firehose to current stable version",
 		},
+		{
+			Name:        RestartAction,
+			Description: "Restart all pods of firehose one by one, without downtime",
+		},
+		{
+			Name:        RollbackReleaseAction,
+			Description: "Roll back the firehose helm release to a revision (previous revision by default)",
+			ParamSchema: rollbackActionSchema,
+		},
+		{
+			Name:        RotateConsumerGroupAction,
+			Description: "Move firehose to a new kafka consumer group, starting from the given position",
+			ParamSchema: resetActionSchema,
+		},
 	},
 	DriverFactory: func(conf json.RawMessage) (module.Driver, error) {
 		fm := firehoseModuleWithDefaultConfigs()
@@ -86,6 +126,11 @@ var Module = module.Descriptor{
 
 type firehoseModule struct {
 	Config config `json:"config"`
+
+	clock          func() time.Time
+	pods           func(ctx context.Context, kubeConf kube.Config, namespace string, labels map[string]string) ([]kube.Pod, error)
+	consumerLag    func(ctx context.Context, brokers []string, group, topic string) (*kafka.ConsumerLag, error)
+	releaseHistory func(ctx context.Context, kubeConf kube.Config, rc *helm.ReleaseConfig, max int) ([]helm.ReleaseRevision, error)
 }
 
 type config struct {
@@ -101,7 +146,7 @@ type config struct {
 
 func firehoseModuleWithDefaultConfigs() *firehoseModule {
 	return &firehoseModule{
-		config{
+		Config: config{
 			ChartRepository: "https://odpf.github.io/charts/",
 			ChartName:       "firehose",
 			ChartVersion:    "0.1.3",
@@ -111,5 +156,27 @@ func firehoseModuleWithDefaultConfigs() *firehoseModule {
 			Namespace:       "firehose",
 			ImagePullPolicy: "IfNotPresent",
 		},
+		clock:          time.Now,
+		pods:           getPods,
+		consumerLag:    getConsumerLag,
+		releaseHistory: getReleaseHistory,
+	}
+}
+
+func getPods(ctx context.Context, kubeConf kube.Config, namespace string, labels map[string]string) ([]kube.Pod, error) {
+	return kube.NewClient(kubeConf).GetPodDetails(ctx, namespace, labels)
+}
+
+// getReleaseHistory fetches the history of the release. The helm client
+// does not take a context, so the deadline of ctx bounds each request made
+// by the client instead.
+func getReleaseHistory(ctx context.Context, kubeConf kube.Config, rc *helm.ReleaseConfig, max int) ([]helm.ReleaseRevision, error) {
+	helmConf := &helm.Config{Kubernetes: kubeConf}
+	if deadline, ok := ctx.Deadline(); ok {
+		helmConf.Timeout = time.Until(deadline)
+		if helmConf.Timeout <= 0 {
+			return nil, errors.ErrInternal.WithMsgf("failed to fetch release history").WithCausef(context.DeadlineExceeded.Error())
+		}
 	}
+	return helm.NewClient(helmConf).History(rc, max)
 }
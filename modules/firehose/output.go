@@ -3,18 +3,79 @@ package firehose
 import (
 	"context"
 	"encoding/json"
+	"strings"
+	"time"
 
 	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
 	"github.com/odpf/entropy/modules/kubernetes"
 	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/helm"
+	"github.com/odpf/entropy/pkg/kafka"
 	"github.com/odpf/entropy/pkg/kube"
 )
 
+// lagFetchTimeout bounds the time spent on fetching the consumer lag, so
+// that an unreachable kafka cluster does not block the output.
+const lagFetchTimeout = 5 * time.Second
+
+// releaseHistoryTimeout bounds the time spent on fetching the history of
+// the release, so that an unreachable cluster does not block a sync.
+const releaseHistoryTimeout = 10 * time.Second
+
+// releaseHistoryMax is the number of latest helm revisions in the output.
+const releaseHistoryMax = 10
+
 type Output struct {
 	Namespace   string     `json:"namespace,omitempty"`
 	ReleaseName string     `json:"release_name,omitempty"`
 	Pods        []kube.Pod `json:"pods,omitempty"`
 	Defaults    config     `json:"defaults,omitempty"`
+
+	// ConsumerLag is the lag of the consumer group of the firehose on
+	// its topic. It is omitted if the lag could not be fetched.
+	ConsumerLag *kafka.ConsumerLag `json:"consumer_lag,omitempty"`
+
+	// LastReset is the per-partition result of the last consumer reset.
+	// It is not available if the reset was done by a kubernetes job.
+	LastReset *kafka.ResetResult `json:"last_reset,omitempty"`
+
+	// PreviousConsumerGroups are the consumer groups that the firehose has
+	// been rotated away from, oldest first.
+	PreviousConsumerGroups []PreviousConsumerGroup `json:"previous_consumer_groups,omitempty"`
+
+	// Rollout is the status of the last rollout of the release.
+	Rollout *kube.RolloutStatus `json:"rollout,omitempty"`
+
+	// Health is evaluated from the pods.
+	Health *resource.Health `json:"health,omitempty"`
+
+	// ReleaseHistory is the latest revisions of the helm release, oldest
+	// first. It is omitted if the history could not be fetched.
+	ReleaseHistory []ReleaseRevision `json:"release_history,omitempty"`
+
+	// ObservedAt is the time at which pods, consumer lag and health were
+	// observed. It is the end of the last sync if the output could not be
+	// observed when it was read.
+	ObservedAt *time.Time `json:"observed_at,omitempty"`
+}
+
+// ReleaseRevision is a revision of the helm release of the firehose.
+type ReleaseRevision struct {
+	helm.ReleaseRevision
+
+	// Configs are the configs of the firehose applied by the revision,
+	// used to restore the configs on a rollback. The output schema marks
+	// them as secret, since they may hold secret values.
+	Configs json.RawMessage `json:"configs,omitempty"`
+}
+
+// PreviousConsumerGroup is a consumer group that the firehose used before
+// a rotation, along with its lag at the time of the rotation.
+type PreviousConsumerGroup struct {
+	Group     string             `json:"group"`
+	RotatedAt time.Time          `json:"rotated_at"`
+	Lag       *kafka.ConsumerLag `json:"lag,omitempty"`
 }
 
 func (out Output) JSON() []byte {
@@ -25,36 +86,120 @@ func (out Output) JSON() []byte {
 	return b
 }
 
+// Output returns the output stored by the last sync, with the pods, the
+// consumer lag and the health observed now (see module.LiveOutput). The
+// release history is only observed by a sync.
 func (m *firehoseModule) Output(ctx context.Context, res module.ExpandedResource) (json.RawMessage, error) {
+	conf, output, err := readConfigAndOutput(res)
+	if err != nil {
+		return nil, err
+	}
+
+	return module.LiveOutput(ctx, res, func(ctx context.Context) (json.RawMessage, error) {
+		if err := m.observePods(ctx, res, *conf, output); err != nil {
+			return nil, err
+		}
+		return output.JSON(), nil
+	}), nil
+}
+
+// observe returns the output with the pods, consumer lag, health and
+// release history observed now. It is meant to be used at the end of a
+// sync.
+func (m *firehoseModule) observe(ctx context.Context, res module.ExpandedResource) (*Output, error) {
+	conf, output, err := readConfigAndOutput(res)
+	if err != nil {
+		return nil, err
+	}
+
+	hc, err := conf.GetHelmReleaseConfig(res.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.observePods(ctx, res, *conf, output); err != nil {
+		return nil, err
+	}
+	output.Namespace, output.ReleaseName = hc.Namespace, hc.Name
+	output.ReleaseHistory = m.history(ctx, res, hc)
+	return output, nil
+}
+
+// observePods sets the pods, the consumer lag and the health evaluated
+// from the pods in the output.
+func (m *firehoseModule) observePods(ctx context.Context, res module.ExpandedResource, conf moduleConfig, output *Output) error {
+	pods, err := m.podDetails(ctx, res)
+	if err != nil {
+		return err
+	}
+
+	health := m.evaluateHealth(conf, pods)
+	observedAt := m.clock()
+	output.Pods = pods
+	output.ConsumerLag = m.groupLag(ctx, conf, conf.Firehose.KafkaConsumerID)
+	output.Health = &health
+	output.ObservedAt = &observedAt
+	return nil
+}
+
+func readConfigAndOutput(res module.ExpandedResource) (*moduleConfig, *Output, error) {
 	var conf moduleConfig
 	if err := json.Unmarshal(res.Resource.Spec.Configs, &conf); err != nil {
-		return nil, errors.ErrInvalid.WithMsgf("invalid config json: %v", err)
+		return nil, nil, errors.ErrInvalid.WithMsgf("invalid config json: %v", err)
 	}
 
 	var output Output
 	if err := json.Unmarshal(res.Resource.State.Output, &output); err != nil {
-		return nil, errors.ErrInvalid.WithMsgf("invalid output json: %v", err)
+		return nil, nil, errors.ErrInvalid.WithMsgf("invalid output json: %v", err)
 	}
+	return &conf, &output, nil
+}
 
-	pods, err := m.podDetails(ctx, res)
-	if err != nil {
-		return nil, err
+// history returns the latest revisions of the release, or nil if they
+// cannot be fetched. Like the lag, the history is informational only.
+func (m *firehoseModule) history(ctx context.Context, res module.ExpandedResource, hc *helm.ReleaseConfig) []ReleaseRevision {
+	var kubeOut kubernetes.Output
+	if err := json.Unmarshal(res.Dependencies[keyKubeDependency].Output, &kubeOut); err != nil {
+		return nil
 	}
 
-	hc, err := conf.GetHelmReleaseConfig(res.Resource)
+	ctx, cancel := context.WithTimeout(ctx, releaseHistoryTimeout)
+	defer cancel()
+
+	history, err := m.releaseHistory(ctx, kubeOut.Configs, hc, releaseHistoryMax)
 	if err != nil {
-		return nil, err
+		return nil
 	}
 
-	return Output{
-		Namespace:   hc.Namespace,
-		ReleaseName: hc.Name,
-		Pods:        pods,
-		Defaults:    output.Defaults,
-	}.JSON(), nil
+	revisions := make([]ReleaseRevision, 0, len(history))
+	for _, rev := range history {
+		revision := ReleaseRevision{ReleaseRevision: rev}
+		// revisions released by older versions of the module have no
+		// recorded configs.
+		if configs, ok := rev.Values[configsValue].(string); ok && json.Valid([]byte(configs)) {
+			revision.Configs = json.RawMessage(configs)
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions
 }
 
-func (*firehoseModule) podDetails(ctx context.Context, res module.ExpandedResource) ([]kube.Pod, error) {
+// groupLag returns the lag of the consumer group on the topic of the
+// firehose, or nil if it cannot be fetched. The lag is informational, and
+// an unreachable (or a deleted) topic must not fail the output.
+func (m *firehoseModule) groupLag(ctx context.Context, conf moduleConfig, group string) *kafka.ConsumerLag {
+	ctx, cancel := context.WithTimeout(ctx, lagFetchTimeout)
+	defer cancel()
+
+	brokers := strings.Split(conf.Firehose.KafkaBrokerAddress, ",")
+	lag, err := m.consumerLag(ctx, brokers, group, conf.Firehose.KafkaTopic)
+	if err != nil {
+		return nil
+	}
+	return lag
+}
+
+func (m *firehoseModule) podDetails(ctx context.Context, res module.ExpandedResource) ([]kube.Pod, error) {
 	r := res.Resource
 
 	var conf moduleConfig
@@ -62,8 +207,8 @@ func (*firehoseModule) podDetails(ctx context.Context, res module.ExpandedResour
 		return nil, errors.ErrInvalid.WithMsgf("invalid config json: %v", err)
 	}
 
-	var kubeOut kubernetes.Output
-	if err := json.Unmarshal(res.Dependencies[keyKubeDependency].Output, &kubeOut); err != nil {
+	kubeOut, err := kubernetes.ClusterOutput(res, keyKubeDependency)
+	if err != nil {
 		return nil, err
 	}
 
@@ -71,7 +216,5 @@ func (*firehoseModule) podDetails(ctx context.Context, res module.ExpandedResour
 	if err != nil {
 		return nil, err
 	}
-
-	kubeCl := kube.NewClient(kubeOut.Configs)
-	return kubeCl.GetPodDetails(ctx, hc.Namespace, map[string]string{"app": hc.Name})
+	return m.pods(ctx, kubeOut.Configs, hc.Namespace, map[string]string{"app": hc.Name})
 }
@@ -0,0 +1,205 @@
+package firehose
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/helm"
+	"github.com/odpf/entropy/pkg/kafka"
+	"github.com/odpf/entropy/pkg/kube"
+)
+
+func TestFirehoseModule_Output(t *testing.T) {
+	t.Parallel()
+
+	observedAt := frozenTime.Add(time.Minute)
+	pods := []kube.Pod{{Name: "orders-firehose-1", Phase: "Running", Ready: false, WaitingReason: waitingCrashLoop, Restarts: 3}}
+	lag := &kafka.ConsumerLag{Group: "orders-firehose", Topic: "orders", Total: 40}
+
+	stored := Output{
+		Namespace:   "firehose",
+		ReleaseName: "demo-orders-firehose",
+		Defaults:    config{Namespace: "firehose"},
+		Pods:        []kube.Pod{{Name: "orders-firehose-1", Phase: "Running", Ready: true}},
+		ConsumerLag: &kafka.ConsumerLag{Group: "orders-firehose", Topic: "orders", Total: 15},
+		Health:      &resource.Health{Status: resource.HealthHealthy},
+		ObservedAt:  &frozenTime,
+		ReleaseHistory: []ReleaseRevision{
+			{ReleaseRevision: helm.ReleaseRevision{Revision: 1}},
+		},
+	}
+
+	res := module.ExpandedResource{
+		Resource: resource.Resource{
+			Name:    "orders",
+			Project: "demo",
+			Spec: resource.Spec{
+				Configs: []byte(`{"state":"RUNNING","firehose":{"replicas":1,"kafka_broker_address":"localhost:9092","kafka_topic":"orders","kafka_consumer_id":"orders-firehose","env_variables":{}}}`),
+			},
+			State: resource.State{Output: stored.JSON()},
+		},
+		Dependencies: map[string]module.ResolvedDependency{
+			keyKubeDependency: {Kind: "kubernetes", Output: []byte(`{"configs":{}}`)},
+		},
+	}
+
+	live := stored
+	live.Pods = pods
+	live.ConsumerLag = lag
+	live.Health = &resource.Health{
+		Status:  resource.HealthDegraded,
+		Reasons: []string{"pod 'orders-firehose-1' is crash-looping after 3 restarts", "0 of 1 pods are ready"},
+	}
+	live.ObservedAt = &observedAt
+
+	withoutLag := live
+	withoutLag.ConsumerLag = nil
+
+	table := []struct {
+		title   string
+		podsErr error
+		lagErr  error
+		want    Output
+	}{
+		{
+			title: "Live",
+			want:  live,
+		},
+		{
+			title:  "LagFetchFailed",
+			lagErr: errors.New("connection refused"),
+			want:   withoutLag,
+		},
+		{
+			title:   "PodsFetchFailed",
+			podsErr: errors.New("connection refused"),
+			want:    stored,
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			m := firehoseModule{
+				clock: func() time.Time { return observedAt },
+				pods: func(ctx context.Context, kubeConf kube.Config, namespace string, labels map[string]string) ([]kube.Pod, error) {
+					_, hasDeadline := ctx.Deadline()
+					assert.True(t, hasDeadline)
+					assert.Equal(t, "firehose", namespace)
+					assert.Equal(t, map[string]string{"app": "demo-orders-firehose"}, labels)
+					return pods, tt.podsErr
+				},
+				consumerLag: func(ctx context.Context, brokers []string, group, topic string) (*kafka.ConsumerLag, error) {
+					if tt.lagErr != nil {
+						return nil, tt.lagErr
+					}
+					return lag, nil
+				},
+			}
+
+			got, err := m.Output(context.Background(), res)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(tt.want.JSON()), string(got))
+		})
+	}
+}
+
+func TestFirehoseModule_GroupLag(t *testing.T) {
+	t.Parallel()
+
+	var conf moduleConfig
+	conf.Firehose.KafkaBrokerAddress = "broker-1:9092,broker-2:9092"
+	conf.Firehose.KafkaTopic = "orders"
+	conf.Firehose.KafkaConsumerID = "orders-firehose"
+
+	lag := &kafka.ConsumerLag{
+		Group: "orders-firehose",
+		Topic: "orders",
+		Total: 15,
+		Partitions: []kafka.PartitionLag{
+			{Partition: 0, CommittedOffset: 90, LatestOffset: 100, Lag: 10},
+			{Partition: 1, CommittedOffset: 45, LatestOffset: 50, Lag: 5},
+		},
+	}
+
+	t.Run("Fetched", func(t *testing.T) {
+		t.Parallel()
+
+		m := firehoseModule{
+			consumerLag: func(ctx context.Context, brokers []string, group, topic string) (*kafka.ConsumerLag, error) {
+				assert.Equal(t, []string{"broker-1:9092", "broker-2:9092"}, brokers)
+				assert.Equal(t, "orders-firehose", group)
+				assert.Equal(t, "orders", topic)
+				return lag, nil
+			},
+		}
+		assert.Equal(t, lag, m.groupLag(context.Background(), conf, "orders-firehose"))
+	})
+
+	t.Run("FetchFailed", func(t *testing.T) {
+		t.Parallel()
+
+		m := firehoseModule{
+			consumerLag: func(ctx context.Context, brokers []string, group, topic string) (*kafka.ConsumerLag, error) {
+				return nil, errors.New("connection refused")
+			},
+		}
+		assert.Nil(t, m.groupLag(context.Background(), conf, "orders-firehose"))
+	})
+}
+
+func TestFirehoseModule_History(t *testing.T) {
+	t.Parallel()
+
+	res := module.ExpandedResource{
+		Dependencies: map[string]module.ResolvedDependency{
+			keyKubeDependency: {Kind: "kubernetes", Output: []byte(`{}`)},
+		},
+	}
+	hc := &helm.ReleaseConfig{Name: "orders-firehose"}
+
+	t.Run("Fetched", func(t *testing.T) {
+		t.Parallel()
+
+		m := firehoseModule{
+			releaseHistory: func(ctx context.Context, kubeConf kube.Config, rc *helm.ReleaseConfig, max int) ([]helm.ReleaseRevision, error) {
+				_, hasDeadline := ctx.Deadline()
+				assert.True(t, hasDeadline)
+				assert.Equal(t, releaseHistoryMax, max)
+				return []helm.ReleaseRevision{
+					{Revision: 1},
+					{Revision: 2, Values: map[string]interface{}{configsValue: `{"state":"RUNNING"}`}},
+				}, nil
+			},
+		}
+
+		assert.Equal(t, []ReleaseRevision{
+			{ReleaseRevision: helm.ReleaseRevision{Revision: 1}},
+			{
+				ReleaseRevision: helm.ReleaseRevision{Revision: 2, Values: map[string]interface{}{configsValue: `{"state":"RUNNING"}`}},
+				Configs:         json.RawMessage(`{"state":"RUNNING"}`),
+			},
+		}, m.history(context.Background(), res, hc))
+	})
+
+	t.Run("FetchFailed", func(t *testing.T) {
+		t.Parallel()
+
+		m := firehoseModule{
+			releaseHistory: func(ctx context.Context, kubeConf kube.Config, rc *helm.ReleaseConfig, max int) ([]helm.ReleaseRevision, error) {
+				return nil, errors.ErrInternal
+			},
+		}
+		assert.Nil(t, m.history(context.Background(), res, hc))
+	})
+}
@@ -3,18 +3,25 @@ package firehose
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
 	"github.com/odpf/entropy/core/module"
 	"github.com/odpf/entropy/core/resource"
 	"github.com/odpf/entropy/pkg/errors"
 )
 
-func (m *firehoseModule) Plan(_ context.Context, res module.ExpandedResource, act module.ActionRequest) (*module.Plan, error) {
+func (m *firehoseModule) Plan(ctx context.Context, res module.ExpandedResource, act module.ActionRequest) (*module.Plan, error) {
 	switch act.Name {
 	case module.CreateAction:
 		return m.planCreate(res, act)
 	case ResetAction:
 		return m.planReset(res, act)
+	case RotateConsumerGroupAction:
+		return m.planRotateConsumerGroup(res, act)
+	case RollbackReleaseAction:
+		return m.planRollbackRelease(res, act)
+	case module.DeleteAction:
+		return m.planDelete(res)
 	default:
 		return m.planChange(res, act)
 	}
@@ -31,6 +38,13 @@ func (m *firehoseModule) planCreate(res module.ExpandedResource, act module.Acti
 	if err := reqConf.validateAndSanitize(res.Resource); err != nil {
 		return nil, err
 	}
+	if err := reqConf.resolveKafkaSource(res.Dependencies); err != nil {
+		return nil, err
+	} else if err := reqConf.validateDatabaseDependency(res.Dependencies); err != nil {
+		return nil, err
+	} else if err := reqConf.resolveNamespace(res.Dependencies); err != nil {
+		return nil, err
+	}
 
 	output := Output{
 		Defaults: m.Config,
@@ -62,15 +76,40 @@ func (m *firehoseModule) planChange(res module.ExpandedResource, act module.Acti
 		return nil, errors.ErrInvalid.WithMsgf("invalid config json: %v", err)
 	}
 
+	data, err := readModuleData(r.State.ModuleData)
+	if err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid module data: %v", err)
+	}
+
 	switch act.Name {
 	case module.UpdateAction:
 		var reqConf moduleConfig
 		if err := json.Unmarshal(act.Params, &reqConf); err != nil {
 			return nil, errors.ErrInvalid.WithMsgf("invalid config json: %v", err)
 		}
+		if reqConf.Firehose.KafkaConsumerID == "" {
+			// keep the current group, which may have been rotated.
+			reqConf.Firehose.KafkaConsumerID = conf.Firehose.KafkaConsumerID
+		}
 		if err := reqConf.validateAndSanitize(r); err != nil {
 			return nil, err
 		}
+		if err := reqConf.resolveKafkaSource(res.Dependencies); err != nil {
+			return nil, err
+		} else if err := reqConf.validateDatabaseDependency(res.Dependencies); err != nil {
+			return nil, err
+		} else if err := reqConf.resolveNamespace(res.Dependencies); err != nil {
+			return nil, err
+		}
+		if _, found := res.Dependencies[keyNamespaceDependency]; !found && reqConf.Namespace == "" {
+			// keep the current namespace, which may have been set explicitly.
+			reqConf.Namespace = conf.Namespace
+		}
+		if reqConf.Namespace != conf.Namespace {
+			// the existing release would be left behind in the old namespace.
+			return nil, errors.ErrInvalid.WithMsgf("namespace of a firehose cannot be changed (from '%s' to '%s'); create a new firehose instead",
+				namespaceOrDefault(conf.Namespace), namespaceOrDefault(reqConf.Namespace))
+		}
 		conf = reqConf
 
 		if conf.StopTime != nil {
@@ -86,6 +125,8 @@ func (m *firehoseModule) planChange(res module.ExpandedResource, act module.Acti
 			return nil, errors.ErrInvalid.WithMsgf("invalid config json: %v", err)
 		}
 		conf.Firehose.Replicas = scaleParams.Replicas
+		now := m.clock()
+		data.LastScaledAt = &now
 		plan.Reason = "firehose scaled"
 
 	case StartAction:
@@ -96,6 +137,14 @@ func (m *firehoseModule) planChange(res module.ExpandedResource, act module.Acti
 		conf.State = stateStopped
 		plan.Reason = "firehose stopped"
 
+	case RestartAction:
+		if conf.State == stateStopped {
+			return nil, errors.ErrInvalid.WithMsgf("cannot restart a stopped firehose")
+		}
+		now := m.clock()
+		data.RestartedAt = &now
+		plan.Reason = "firehose restarted"
+
 	case UpgradeAction:
 		var output Output
 		err := json.Unmarshal(res.State.Output, &output)
@@ -115,12 +164,26 @@ func (m *firehoseModule) planChange(res module.ExpandedResource, act module.Acti
 		Output: res.State.Output,
 		ModuleData: moduleData{
 			PendingSteps: []string{releaseUpdate},
+			LastScaledAt: data.LastScaledAt,
+			RestartedAt:  data.RestartedAt,
 		}.JSON(),
 	}
 	plan.Resource = r
 	return &plan, nil
 }
 
+func (*firehoseModule) planDelete(res module.ExpandedResource) (*module.Plan, error) {
+	r := res.Resource
+	r.State = resource.State{
+		Status: resource.StatusDeleted,
+		Output: r.State.Output,
+		ModuleData: moduleData{
+			PendingSteps: []string{releaseDelete},
+		}.JSON(),
+	}
+	return &module.Plan{Resource: r, Reason: "firehose deleted"}, nil
+}
+
 func (*firehoseModule) planReset(res module.ExpandedResource, act module.ActionRequest) (*module.Plan, error) {
 	r := res.Resource
 
@@ -129,20 +192,20 @@ func (*firehoseModule) planReset(res module.ExpandedResource, act module.ActionR
 		return nil, errors.ErrInvalid.WithMsgf("invalid config json: %v", err)
 	}
 
-	var resetParams struct {
-		To       string `json:"to"`
-		Datetime string `json:"datetime"`
+	data, err := readModuleData(r.State.ModuleData)
+	if err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid module data: %v", err)
 	}
-	if err := json.Unmarshal(act.Params, &resetParams); err != nil {
+
+	var params resetParams
+	if err := json.Unmarshal(act.Params, &params); err != nil {
 		return nil, errors.ErrInvalid.WithMsgf("invalid action params json: %v", err)
 	}
 
-	var resetTo string
-	switch resetParams.To {
-	case "DATETIME":
-		resetTo = resetParams.Datetime
-	default:
-		resetTo = resetParams.To
+	if _, err := params.spec(); err != nil {
+		return nil, err
+	} else if params.To == ResetToGroup && params.Group == conf.Firehose.KafkaConsumerID {
+		return nil, errors.ErrInvalid.WithMsgf("cannot reset to the current position of the same group")
 	}
 
 	r.Spec.Configs = conf.JSON()
@@ -151,10 +214,160 @@ func (*firehoseModule) planReset(res module.ExpandedResource, act module.ActionR
 		Output: res.State.Output,
 		ModuleData: moduleData{
 			PendingSteps:  []string{releaseUpdate, consumerReset, releaseUpdate},
-			ResetTo:       resetTo,
+			Reset:         &params,
 			StateOverride: stateStopped,
+			LastScaledAt:  data.LastScaledAt,
+			RestartedAt:   data.RestartedAt,
 		}.JSON(),
 	}
 
 	return &module.Plan{Resource: r, Reason: "firehose consumer reset"}, nil
 }
+
+func (*firehoseModule) planRotateConsumerGroup(res module.ExpandedResource, act module.ActionRequest) (*module.Plan, error) {
+	r := res.Resource
+
+	var conf moduleConfig
+	if err := json.Unmarshal(r.Spec.Configs, &conf); err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid config json: %v", err)
+	}
+
+	data, err := readModuleData(r.State.ModuleData)
+	if err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid module data: %v", err)
+	}
+
+	var params resetParams
+	if err := json.Unmarshal(act.Params, &params); err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid action params json: %v", err)
+	}
+
+	previousID := conf.Firehose.KafkaConsumerID
+	conf.Firehose.KafkaConsumerID = nextConsumerID(previousID)
+
+	if _, err := params.spec(); err != nil {
+		return nil, err
+	} else if params.To == ResetToGroup && params.Group == conf.Firehose.KafkaConsumerID {
+		return nil, errors.ErrInvalid.WithMsgf("cannot start from the position of the new group itself")
+	}
+
+	// offsets of the new group are set before the release is updated to
+	// use it, while it has no members yet.
+	r.Spec.Configs = conf.JSON()
+	r.State = resource.State{
+		Status: resource.StatusPending,
+		Output: res.State.Output,
+		ModuleData: moduleData{
+			PendingSteps: []string{consumerReset, releaseUpdate},
+			Reset:        &params,
+			RotatedFrom:  previousID,
+			LastScaledAt: data.LastScaledAt,
+			RestartedAt:  data.RestartedAt,
+		}.JSON(),
+	}
+
+	return &module.Plan{Resource: r, Reason: "firehose consumer group rotated"}, nil
+}
+
+func (*firehoseModule) planRollbackRelease(res module.ExpandedResource, act module.ActionRequest) (*module.Plan, error) {
+	r := res.Resource
+
+	data, err := readModuleData(r.State.ModuleData)
+	if err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid module data: %v", err)
+	}
+
+	var params struct {
+		Revision int `json:"revision"`
+	}
+	if len(act.Params) > 0 {
+		if err := json.Unmarshal(act.Params, &params); err != nil {
+			return nil, errors.ErrInvalid.WithMsgf("invalid action params json: %v", err)
+		}
+	}
+	if params.Revision < 0 {
+		return nil, errors.ErrInvalid.WithMsgf("revision must not be negative")
+	}
+
+	// the history observed by the last sync is used, so that planning
+	// makes no calls to the cluster.
+	var output Output
+	if err := json.Unmarshal(r.State.Output, &output); err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid output json: %v", err)
+	}
+
+	target, err := rollbackRevision(output.ReleaseHistory, params.Revision)
+	if err != nil {
+		return nil, err
+	}
+
+	// the configs of the revision are restored. otherwise, the next update
+	// of the firehose would apply the rolled back configs again.
+	configs, err := revisionConfigs(*target)
+	if err != nil {
+		return nil, err
+	}
+	revision := target.Revision
+
+	r.Spec.Configs = configs
+	r.State = resource.State{
+		Status: resource.StatusPending,
+		Output: res.State.Output,
+		ModuleData: moduleData{
+			PendingSteps: []string{releaseRollback},
+			RollbackTo:   revision,
+			LastScaledAt: data.LastScaledAt,
+			RestartedAt:  data.RestartedAt,
+		}.JSON(),
+	}
+
+	return &module.Plan{
+		Resource: r,
+		Reason:   fmt.Sprintf("firehose release rolled back to revision %d", revision),
+	}, nil
+}
+
+// rollbackRevision returns the revision to roll back to. The revision must
+// be in the history, and must not be the current one. Revision 0 refers to
+// the one before the current.
+func rollbackRevision(history []ReleaseRevision, revision int) (*ReleaseRevision, error) {
+	if len(history) == 0 {
+		return nil, errors.ErrInvalid.WithMsgf("history of the firehose release is not known yet")
+	}
+	current := history[len(history)-1].Revision
+
+	if revision == 0 {
+		if len(history) < 2 {
+			return nil, errors.ErrInvalid.WithMsgf("firehose release has no previous revision")
+		}
+		return &history[len(history)-2], nil
+	} else if revision == current {
+		return nil, errors.ErrInvalid.WithMsgf("revision %d is the current revision", revision)
+	}
+
+	for i := range history {
+		if history[i].Revision == revision {
+			return &history[i], nil
+		}
+	}
+	return nil, errors.ErrInvalid.WithMsgf("revision %d not found in the release history", revision)
+}
+
+// revisionConfigs returns the configs of the firehose recorded in the
+// revision. Revisions released by older versions of the module have none,
+// and cannot be rolled back to.
+func revisionConfigs(rev ReleaseRevision) (json.RawMessage, error) {
+	if len(rev.Configs) == 0 {
+		return nil, errors.ErrInvalid.WithMsgf("configs of revision %d are not known, update the firehose instead", rev.Revision)
+	}
+	return rev.Configs, nil
+}
+
+// namespaceOrDefault names the namespace for messages, where an empty
+// namespace stands for the one from the module config.
+func namespaceOrDefault(namespace string) string {
+	if namespace == "" {
+		return "<module default>"
+	}
+	return namespace
+}
@@ -2,15 +2,19 @@ package firehose
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/odpf/entropy/core/module"
 	"github.com/odpf/entropy/core/resource"
 	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/helm"
+	"github.com/odpf/entropy/pkg/kube"
 )
 
 func TestFirehoseModule_Plan(t *testing.T) {
@@ -95,12 +99,51 @@ func TestFirehoseModule_Plan(t *testing.T) {
 					},
 					State: resource.State{
 						Status:     resource.StatusPending,
-						ModuleData: []byte(`{"pending_steps":["release_update"]}`),
+						ModuleData: []byte(`{"pending_steps":["release_update"],"last_scaled_at":"2022-04-21T10:29:15Z"}`),
 					},
 				},
 				Reason: "firehose scaled",
 			},
 		},
+		{
+			title: "ValidRestartRequest",
+			res:   module.ExpandedResource{Resource: res},
+			act: module.ActionRequest{
+				Name: RestartAction,
+			},
+			want: &module.Plan{
+				Resource: resource.Resource{
+					URN:     "orn:entropy:firehose:test",
+					Kind:    "firehose",
+					Name:    "test",
+					Project: "demo",
+					Spec: resource.Spec{
+						Configs: []byte(`{"state":"RUNNING","stop_time":null,"telegraf":null,"firehose":{"replicas":1,"kafka_broker_address":"localhost:9092","kafka_topic":"test-topic","kafka_consumer_id":"test-consumer-id","env_variables":{}}}`),
+					},
+					State: resource.State{
+						Status:     resource.StatusPending,
+						ModuleData: []byte(`{"pending_steps":["release_update"],"restarted_at":"2022-04-21T10:29:15Z"}`),
+					},
+				},
+				Reason: "firehose restarted",
+			},
+		},
+		{
+			title: "RestartStoppedFirehose",
+			res: module.ExpandedResource{Resource: resource.Resource{
+				URN:     "orn:entropy:firehose:test",
+				Kind:    "firehose",
+				Name:    "test",
+				Project: "demo",
+				Spec: resource.Spec{
+					Configs: []byte(`{"state":"STOPPED","firehose":{"replicas":1,"kafka_broker_address":"localhost:9092","kafka_topic":"test-topic","kafka_consumer_id":"test-consumer-id","env_variables":{}}}`),
+				},
+			}},
+			act: module.ActionRequest{
+				Name: RestartAction,
+			},
+			wantErr: errors.ErrInvalid,
+		},
 		{
 			title: "ValidResetRequest",
 			res:   module.ExpandedResource{Resource: res},
@@ -119,12 +162,260 @@ func TestFirehoseModule_Plan(t *testing.T) {
 					},
 					State: resource.State{
 						Status:     resource.StatusPending,
-						ModuleData: []byte(`{"pending_steps":["release_update","consumer_reset","release_update"],"reset_to":"2022-06-22T00:00:00+00:00","state_override":"STOPPED"}`),
+						ModuleData: []byte(`{"pending_steps":["release_update","consumer_reset","release_update"],"reset":{"to":"DATETIME","datetime":"2022-06-22T00:00:00+00:00"},"state_override":"STOPPED"}`),
 					},
 				},
 				Reason: "firehose consumer reset",
 			},
 		},
+		{
+			title: "ValidResetToOffsetsRequest",
+			res:   module.ExpandedResource{Resource: res},
+			act: module.ActionRequest{
+				Name:   ResetAction,
+				Params: []byte(`{"to":"OFFSETS","offsets":[{"partition":0,"offset":1200},{"partition":1,"offset":0}]}`),
+			},
+			want: &module.Plan{
+				Resource: resource.Resource{
+					URN:     "orn:entropy:firehose:test",
+					Kind:    "firehose",
+					Name:    "test",
+					Project: "demo",
+					Spec: resource.Spec{
+						Configs: []byte(`{"state":"RUNNING","stop_time":null,"telegraf":null,"firehose":{"replicas":1,"kafka_broker_address":"localhost:9092","kafka_topic":"test-topic","kafka_consumer_id":"test-consumer-id","env_variables":{}}}`),
+					},
+					State: resource.State{
+						Status:     resource.StatusPending,
+						ModuleData: []byte(`{"pending_steps":["release_update","consumer_reset","release_update"],"reset":{"to":"OFFSETS","offsets":[{"partition":0,"offset":1200},{"partition":1,"offset":0}]},"state_override":"STOPPED"}`),
+					},
+				},
+				Reason: "firehose consumer reset",
+			},
+		},
+		{
+			title: "InvalidResetDatetime",
+			res:   module.ExpandedResource{Resource: res},
+			act: module.ActionRequest{
+				Name:   ResetAction,
+				Params: []byte(`{"to":"DATETIME","datetime":"22-06-2022"}`),
+			},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title: "InvalidResetShiftBy",
+			res:   module.ExpandedResource{Resource: res},
+			act: module.ActionRequest{
+				Name:   ResetAction,
+				Params: []byte(`{"to":"SHIFT_BY"}`),
+			},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title: "InvalidResetToSameGroup",
+			res:   module.ExpandedResource{Resource: res},
+			act: module.ActionRequest{
+				Name:   ResetAction,
+				Params: []byte(`{"to":"GROUP","group":"test-consumer-id"}`),
+			},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title: "ValidRotateConsumerGroupRequest",
+			res:   module.ExpandedResource{Resource: res},
+			act: module.ActionRequest{
+				Name:   RotateConsumerGroupAction,
+				Params: []byte(`{"to":"GROUP","group":"test-consumer-id"}`),
+			},
+			want: &module.Plan{
+				Resource: resource.Resource{
+					URN:     "orn:entropy:firehose:test",
+					Kind:    "firehose",
+					Name:    "test",
+					Project: "demo",
+					Spec: resource.Spec{
+						Configs: []byte(`{"state":"RUNNING","stop_time":null,"telegraf":null,"firehose":{"replicas":1,"kafka_broker_address":"localhost:9092","kafka_topic":"test-topic","kafka_consumer_id":"test-consumer-id-0001","env_variables":{}}}`),
+					},
+					State: resource.State{
+						Status:     resource.StatusPending,
+						ModuleData: []byte(`{"pending_steps":["consumer_reset","release_update"],"reset":{"to":"GROUP","group":"test-consumer-id"},"rotated_from":"test-consumer-id"}`),
+					},
+				},
+				Reason: "firehose consumer group rotated",
+			},
+		},
+		{
+			title: "InvalidRotateConsumerGroupRequest",
+			res:   module.ExpandedResource{Resource: res},
+			act: module.ActionRequest{
+				Name:   RotateConsumerGroupAction,
+				Params: []byte(`{"to":"GROUP","group":"test-consumer-id-0001"}`),
+			},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title: "UpdateKeepsConsumerID",
+			res:   module.ExpandedResource{Resource: res},
+			act: module.ActionRequest{
+				Name:   module.UpdateAction,
+				Params: []byte(`{"state":"RUNNING","firehose":{"replicas":2,"kafka_broker_address":"localhost:9092","kafka_topic":"test-topic","env_variables":{}}}`),
+			},
+			want: &module.Plan{
+				Resource: resource.Resource{
+					URN:     "orn:entropy:firehose:test",
+					Kind:    "firehose",
+					Name:    "test",
+					Project: "demo",
+					Spec: resource.Spec{
+						Configs: []byte(`{"state":"RUNNING","stop_time":null,"telegraf":null,"firehose":{"replicas":2,"kafka_broker_address":"localhost:9092","kafka_topic":"test-topic","kafka_consumer_id":"test-consumer-id","env_variables":{}}}`),
+					},
+					State: resource.State{
+						Status:     resource.StatusPending,
+						ModuleData: []byte(`{"pending_steps":["release_update"]}`),
+					},
+				},
+				Reason: "firehose config updated",
+			},
+		},
+		{
+			title: "WithKafkaTopicDependency",
+			res: module.ExpandedResource{
+				Resource: res,
+				Dependencies: map[string]module.ResolvedDependency{
+					"kafka_topic": {Kind: "kafka_topic", Output: []byte(`{"name":"orders","brokers":["kafka-0:9092","kafka-1:9092"]}`)},
+				},
+			},
+			act: module.ActionRequest{
+				Name:   module.CreateAction,
+				Params: []byte(`{"state":"RUNNING","firehose":{"replicas":1,"kafka_consumer_id":"test-consumer-id","env_variables":{}}}`),
+			},
+			want: &module.Plan{
+				Resource: resource.Resource{
+					URN:     "orn:entropy:firehose:test",
+					Kind:    "firehose",
+					Name:    "test",
+					Project: "demo",
+					Spec: resource.Spec{
+						Configs: []byte(`{"state":"RUNNING","stop_time":null,"telegraf":null,"firehose":{"replicas":1,"kafka_broker_address":"kafka-0:9092,kafka-1:9092","kafka_topic":"orders","kafka_consumer_id":"test-consumer-id","env_variables":{}}}`),
+					},
+					State: resource.State{
+						Status:     resource.StatusPending,
+						ModuleData: []byte(`{"pending_steps":["release_create"]}`),
+						Output:     []byte(`{"defaults":{}}`),
+					},
+				},
+				Reason: "firehose created",
+			},
+		},
+		{
+			title: "KafkaTopicDependencyMismatch",
+			res: module.ExpandedResource{
+				Resource: res,
+				Dependencies: map[string]module.ResolvedDependency{
+					"kafka_topic": {Kind: "kafka_topic", Output: []byte(`{"name":"orders","brokers":["kafka-0:9092"]}`)},
+				},
+			},
+			act: module.ActionRequest{
+				Name:   module.CreateAction,
+				Params: []byte(`{"state":"RUNNING","firehose":{"replicas":1,"kafka_topic":"test-topic","kafka_consumer_id":"test-consumer-id","env_variables":{}}}`),
+			},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title: "NoKafkaTopic",
+			res:   module.ExpandedResource{Resource: res},
+			act: module.ActionRequest{
+				Name:   module.CreateAction,
+				Params: []byte(`{"state":"RUNNING","firehose":{"replicas":1,"kafka_consumer_id":"test-consumer-id","env_variables":{}}}`),
+			},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title: "WithKubeNamespaceDependency",
+			res: module.ExpandedResource{
+				Resource: res,
+				Dependencies: map[string]module.ResolvedDependency{
+					"kube_namespace": {Kind: "kube_namespace", Output: []byte(`{"namespace":"team-data"}`)},
+				},
+			},
+			act: module.ActionRequest{
+				Name:   module.CreateAction,
+				Params: []byte(`{"state":"RUNNING","firehose":{"replicas":1,"kafka_broker_address":"localhost:9092","kafka_topic":"test-topic","kafka_consumer_id":"test-consumer-id","env_variables":{}}}`),
+			},
+			want: &module.Plan{
+				Resource: resource.Resource{
+					URN:     "orn:entropy:firehose:test",
+					Kind:    "firehose",
+					Name:    "test",
+					Project: "demo",
+					Spec: resource.Spec{
+						Configs: []byte(`{"state":"RUNNING","stop_time":null,"namespace":"team-data","telegraf":null,"firehose":{"replicas":1,"kafka_broker_address":"localhost:9092","kafka_topic":"test-topic","kafka_consumer_id":"test-consumer-id","env_variables":{}}}`),
+					},
+					State: resource.State{
+						Status:     resource.StatusPending,
+						ModuleData: []byte(`{"pending_steps":["release_create"]}`),
+						Output:     []byte(`{"defaults":{}}`),
+					},
+				},
+				Reason: "firehose created",
+			},
+		},
+		{
+			title: "NamespaceWithoutDependency",
+			res:   module.ExpandedResource{Resource: res},
+			act: module.ActionRequest{
+				Name:   module.CreateAction,
+				Params: []byte(`{"state":"RUNNING","namespace":"team-data","firehose":{"replicas":1,"kafka_broker_address":"localhost:9092","kafka_topic":"test-topic","kafka_consumer_id":"test-consumer-id","env_variables":{}}}`),
+			},
+			want: &module.Plan{
+				Resource: resource.Resource{
+					URN:     "orn:entropy:firehose:test",
+					Kind:    "firehose",
+					Name:    "test",
+					Project: "demo",
+					Spec: resource.Spec{
+						Configs: []byte(`{"state":"RUNNING","stop_time":null,"namespace":"team-data","telegraf":null,"firehose":{"replicas":1,"kafka_broker_address":"localhost:9092","kafka_topic":"test-topic","kafka_consumer_id":"test-consumer-id","env_variables":{}}}`),
+					},
+					State: resource.State{
+						Status:     resource.StatusPending,
+						ModuleData: []byte(`{"pending_steps":["release_create"]}`),
+						Output:     []byte(`{"defaults":{}}`),
+					},
+				},
+				Reason: "firehose created",
+			},
+		},
+		{
+			title: "InvalidNamespace",
+			res:   module.ExpandedResource{Resource: res},
+			act: module.ActionRequest{
+				Name:   module.CreateAction,
+				Params: []byte(`{"state":"RUNNING","namespace":"Team_Data","firehose":{"replicas":1,"kafka_broker_address":"localhost:9092","kafka_topic":"test-topic","kafka_consumer_id":"test-consumer-id","env_variables":{}}}`),
+			},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title: "ValidDeleteRequest",
+			res:   module.ExpandedResource{Resource: res},
+			act: module.ActionRequest{
+				Name: module.DeleteAction,
+			},
+			want: &module.Plan{
+				Resource: resource.Resource{
+					URN:     "orn:entropy:firehose:test",
+					Kind:    "firehose",
+					Name:    "test",
+					Project: "demo",
+					Spec: resource.Spec{
+						Configs: []byte(`{"state":"RUNNING","firehose":{"replicas":1,"kafka_broker_address":"localhost:9092","kafka_topic":"test-topic","kafka_consumer_id":"test-consumer-id","env_variables":{}}}`),
+					},
+					State: resource.State{
+						Status:     resource.StatusDeleted,
+						ModuleData: []byte(`{"pending_steps":["release_delete"]}`),
+					},
+				},
+				Reason: "firehose deleted",
+			},
+		},
 		{
 			title: "WithStopTimeConfiguration",
 			res:   module.ExpandedResource{Resource: res},
@@ -157,7 +448,7 @@ func TestFirehoseModule_Plan(t *testing.T) {
 		tt := tt
 		t.Run(tt.title, func(t *testing.T) {
 			t.Parallel()
-			m := firehoseModule{}
+			m := firehoseModule{clock: func() time.Time { return frozenTime }}
 
 			got, err := m.Plan(context.Background(), tt.res, tt.act)
 			if tt.wantErr != nil || err != nil {
@@ -172,6 +463,211 @@ func TestFirehoseModule_Plan(t *testing.T) {
 	}
 }
 
+func TestFirehoseModule_PlanUpdateNamespace(t *testing.T) {
+	t.Parallel()
+
+	configs := func(namespace string) string {
+		return `{"state":"RUNNING",` + namespace + `"firehose":{"replicas":1,"kafka_broker_address":"localhost:9092","kafka_topic":"test-topic","kafka_consumer_id":"test-consumer-id","env_variables":{}}}`
+	}
+	teamData := map[string]module.ResolvedDependency{
+		"kube_namespace": {Kind: "kube_namespace", Output: []byte(`{"namespace":"team-data"}`)},
+	}
+
+	table := []struct {
+		title       string
+		configs     string
+		deps        map[string]module.ResolvedDependency
+		params      string
+		wantConfigs string
+		wantErr     string
+	}{
+		{
+			title:       "KeepExplicitNamespace",
+			configs:     configs(`"namespace":"team-data",`),
+			params:      configs(``),
+			wantConfigs: `{"state":"RUNNING","stop_time":null,"namespace":"team-data","telegraf":null,"firehose":{"replicas":1,"kafka_broker_address":"localhost:9092","kafka_topic":"test-topic","kafka_consumer_id":"test-consumer-id","env_variables":{}}}`,
+		},
+		{
+			title:       "KeepDependencyNamespace",
+			configs:     configs(`"namespace":"team-data",`),
+			deps:        teamData,
+			params:      configs(``),
+			wantConfigs: `{"state":"RUNNING","stop_time":null,"namespace":"team-data","telegraf":null,"firehose":{"replicas":1,"kafka_broker_address":"localhost:9092","kafka_topic":"test-topic","kafka_consumer_id":"test-consumer-id","env_variables":{}}}`,
+		},
+		{
+			title:   "ChangeExplicitNamespace",
+			configs: configs(`"namespace":"team-data",`),
+			params:  configs(`"namespace":"team-infra",`),
+			wantErr: "namespace of a firehose cannot be changed (from 'team-data' to 'team-infra'); create a new firehose instead",
+		},
+		{
+			title:   "AddDependency",
+			configs: configs(``),
+			deps:    teamData,
+			params:  configs(``),
+			wantErr: "namespace of a firehose cannot be changed (from '<module default>' to 'team-data'); create a new firehose instead",
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			res := module.ExpandedResource{
+				Resource: resource.Resource{
+					URN:     "orn:entropy:firehose:test",
+					Kind:    "firehose",
+					Name:    "test",
+					Project: "demo",
+					Spec:    resource.Spec{Configs: []byte(tt.configs)},
+					State:   resource.State{Status: resource.StatusCompleted},
+				},
+				Dependencies: tt.deps,
+			}
+			act := module.ActionRequest{Name: module.UpdateAction, Params: []byte(tt.params)}
+
+			m := firehoseModule{clock: func() time.Time { return frozenTime }}
+			got, err := m.Plan(context.Background(), res, act)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, errors.ErrInvalid))
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.wantConfigs, string(got.Resource.Spec.Configs))
+		})
+	}
+}
+
+func TestFirehoseModule_PlanRollbackRelease(t *testing.T) {
+	t.Parallel()
+
+	configs := func(replicas int) string {
+		return fmt.Sprintf(`{"state":"RUNNING","firehose":{"replicas":%d,"kafka_broker_address":"localhost:9092","kafka_topic":"test-topic","kafka_consumer_id":"test-consumer-id","env_variables":{}}}`, replicas)
+	}
+	history := []ReleaseRevision{
+		{ReleaseRevision: helm.ReleaseRevision{Revision: 1, Status: "superseded"}},
+		{ReleaseRevision: helm.ReleaseRevision{Revision: 2, Status: "superseded"}, Configs: []byte(configs(2))},
+		{ReleaseRevision: helm.ReleaseRevision{Revision: 3, Status: "superseded"}, Configs: []byte(configs(3))},
+		{ReleaseRevision: helm.ReleaseRevision{Revision: 4, Status: "failed"}, Configs: []byte(configs(1))},
+	}
+
+	newRes := func(history []ReleaseRevision) module.ExpandedResource {
+		return module.ExpandedResource{
+			Resource: resource.Resource{
+				URN:     "orn:entropy:firehose:test",
+				Kind:    "firehose",
+				Name:    "test",
+				Project: "demo",
+				Spec: resource.Spec{
+					Configs: []byte(configs(1)),
+				},
+				State: resource.State{
+					Status:     resource.StatusCompleted,
+					Output:     Output{ReleaseHistory: history}.JSON(),
+					ModuleData: []byte(`{"pending_steps":[],"last_scaled_at":"2022-04-21T10:29:15Z"}`),
+				},
+			},
+			Dependencies: map[string]module.ResolvedDependency{
+				"kube_cluster": {Kind: "kubernetes", Output: []byte(`{}`)},
+			},
+		}
+	}
+
+	table := []struct {
+		title       string
+		params      string
+		history     []ReleaseRevision
+		wantData    string
+		wantConfigs string
+		wantReason  string
+		wantErr     error
+	}{
+		{
+			title:       "PreviousRevision",
+			params:      `{}`,
+			history:     history,
+			wantData:    `{"pending_steps":["release_rollback"],"rollback_to":3,"last_scaled_at":"2022-04-21T10:29:15Z"}`,
+			wantConfigs: configs(3),
+			wantReason:  "firehose release rolled back to revision 3",
+		},
+		{
+			title:       "GivenRevision",
+			params:      `{"revision":2}`,
+			history:     history,
+			wantData:    `{"pending_steps":["release_rollback"],"rollback_to":2,"last_scaled_at":"2022-04-21T10:29:15Z"}`,
+			wantConfigs: configs(2),
+			wantReason:  "firehose release rolled back to revision 2",
+		},
+		{
+			title:   "ConfigsNotRecorded",
+			params:  `{"revision":1}`,
+			history: history,
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title:   "CurrentRevision",
+			params:  `{"revision":4}`,
+			history: history,
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title:   "UnknownRevision",
+			params:  `{"revision":7}`,
+			history: history,
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title:   "NegativeRevision",
+			params:  `{"revision":-1}`,
+			history: history,
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title:   "HistoryNotObserved",
+			params:  `{}`,
+			wantErr: errors.ErrInvalid,
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			// planning must not fetch the history from the cluster.
+			m := firehoseModule{
+				clock: func() time.Time { return frozenTime },
+				releaseHistory: func(ctx context.Context, kubeConf kube.Config, rc *helm.ReleaseConfig, max int) ([]helm.ReleaseRevision, error) {
+					t.Error("release history fetched while planning")
+					return nil, nil
+				},
+			}
+
+			got, err := m.Plan(context.Background(), newRes(tt.history), module.ActionRequest{
+				Name:   RollbackReleaseAction,
+				Params: []byte(tt.params),
+			})
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				assert.True(t, errors.Is(err, tt.wantErr))
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, resource.StatusPending, got.Resource.State.Status)
+				assert.JSONEq(t, tt.wantData, string(got.Resource.State.ModuleData))
+				assert.JSONEq(t, tt.wantConfigs, string(got.Resource.Spec.Configs))
+				assert.Equal(t, tt.wantReason, got.Reason)
+			}
+		})
+	}
+}
+
+var frozenTime = time.Unix(1650536955, 0).UTC()
+
 func parseTime(timeString string) time.Time {
 	t, err := time.Parse(time.RFC3339, timeString)
 	if err != nil {
@@ -0,0 +1,153 @@
+package firehose
+
+import (
+	"encoding/json"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	kuberesource "k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+// podResources are the compute resources of the firehose container, as
+// quantities by resource name (e.g., 'cpu', 'memory').
+type podResources struct {
+	Requests map[string]string `json:"requests,omitempty"`
+	Limits   map[string]string `json:"limits,omitempty"`
+}
+
+// podSpec is the scheduling and metadata of the firehose pods. Tolerations
+// and affinity are passed to the chart as-is, and use the field names of
+// the kubernetes API.
+type podSpec struct {
+	Resources      *podResources       `json:"resources,omitempty"`
+	NodeSelector   map[string]string   `json:"node_selector,omitempty"`
+	Tolerations    []corev1.Toleration `json:"tolerations,omitempty"`
+	Affinity       *corev1.Affinity    `json:"affinity,omitempty"`
+	PodAnnotations map[string]string   `json:"pod_annotations,omitempty"`
+	PodLabels      map[string]string   `json:"pod_labels,omitempty"`
+}
+
+func (ps podSpec) validate() error {
+	if ps.Resources != nil {
+		if err := ps.Resources.validate(); err != nil {
+			return err
+		}
+	}
+
+	labels := map[string]map[string]string{
+		"node_selector": ps.NodeSelector,
+		"pod_labels":    ps.PodLabels,
+	}
+	for field, values := range labels {
+		for key, val := range values {
+			if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+				return errors.ErrInvalid.WithMsgf("invalid key '%s' in %s: %s", key, field, strings.Join(errs, ", "))
+			} else if errs := validation.IsValidLabelValue(val); len(errs) > 0 {
+				return errors.ErrInvalid.WithMsgf("invalid value for '%s' in %s: %s", key, field, strings.Join(errs, ", "))
+			}
+		}
+	}
+
+	for key := range ps.PodAnnotations {
+		if errs := validation.IsQualifiedName(strings.ToLower(key)); len(errs) > 0 {
+			return errors.ErrInvalid.WithMsgf("invalid key '%s' in pod_annotations: %s", key, strings.Join(errs, ", "))
+		}
+	}
+
+	for _, t := range ps.Tolerations {
+		if t.Operator == corev1.TolerationOpExists && t.Value != "" {
+			return errors.ErrInvalid.WithMsgf("toleration for '%s' must not have a value with operator 'Exists'", t.Key)
+		} else if t.Key == "" && t.Operator != corev1.TolerationOpExists {
+			return errors.ErrInvalid.WithMsgf("toleration without a key must use operator 'Exists'")
+		}
+	}
+	return nil
+}
+
+func (pr podResources) validate() error {
+	requests, err := parseQuantities("resources.requests", pr.Requests)
+	if err != nil {
+		return err
+	}
+
+	limits, err := parseQuantities("resources.limits", pr.Limits)
+	if err != nil {
+		return err
+	}
+
+	for name, request := range requests {
+		if limit, found := limits[name]; found && request.Cmp(limit) > 0 {
+			return errors.ErrInvalid.WithMsgf("request for '%s' must not be more than its limit", name)
+		}
+	}
+	return nil
+}
+
+// helmValues returns the values for the pod spec, to be merged into the
+// chart values. Unset fields are omitted, so that the chart defaults are
+// used for them.
+func (ps podSpec) helmValues() (firehoseValues, values map[string]interface{}, err error) {
+	firehoseValues = map[string]interface{}{}
+	values = map[string]interface{}{}
+
+	if ps.Resources != nil {
+		resources := map[string]interface{}{}
+		if len(ps.Resources.Requests) > 0 {
+			resources["requests"] = ps.Resources.Requests
+		}
+		if len(ps.Resources.Limits) > 0 {
+			resources["limits"] = ps.Resources.Limits
+		}
+		firehoseValues["resources"] = resources
+	}
+
+	if len(ps.NodeSelector) > 0 {
+		values["nodeSelector"] = ps.NodeSelector
+	}
+	if len(ps.PodAnnotations) > 0 {
+		values["podAnnotations"] = ps.PodAnnotations
+	}
+	if len(ps.PodLabels) > 0 {
+		values["podLabels"] = ps.PodLabels
+	}
+	if len(ps.Tolerations) > 0 {
+		if values["tolerations"], err = plainValue(ps.Tolerations); err != nil {
+			return nil, nil, err
+		}
+	}
+	if ps.Affinity != nil {
+		if values["affinity"], err = plainValue(ps.Affinity); err != nil {
+			return nil, nil, err
+		}
+	}
+	return firehoseValues, values, nil
+}
+
+func parseQuantities(field string, values map[string]string) (map[string]kuberesource.Quantity, error) {
+	quantities := map[string]kuberesource.Quantity{}
+	for name, val := range values {
+		q, err := kuberesource.ParseQuantity(val)
+		if err != nil {
+			return nil, errors.ErrInvalid.WithMsgf("invalid quantity '%s' for '%s' in %s", val, name, field)
+		}
+		quantities[name] = q
+	}
+	return quantities, nil
+}
+
+// plainValue converts v to maps & slices, as expected in helm values.
+func plainValue(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var val interface{}
+	if err := json.Unmarshal(b, &val); err != nil {
+		return nil, err
+	}
+	return val, nil
+}
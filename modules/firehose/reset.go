@@ -0,0 +1,79 @@
+package firehose
+
+import (
+	"time"
+
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/kafka"
+)
+
+// resetParams are the params of the reset action, describing the offsets
+// to reset the consumer group of the firehose to.
+type resetParams struct {
+	To       string            `json:"to"`
+	Datetime string            `json:"datetime,omitempty"`
+	Offsets  []partitionOffset `json:"offsets,omitempty"`
+	ShiftBy  int64             `json:"shift_by,omitempty"`
+	Group    string            `json:"group,omitempty"`
+}
+
+type partitionOffset struct {
+	Partition int   `json:"partition"`
+	Offset    int64 `json:"offset"`
+}
+
+// legacyResetParams returns the params for the 'reset_to' module data set
+// by the older versions, which is either EARLIEST, LATEST or a datetime.
+func legacyResetParams(resetTo string) resetParams {
+	if resetTo == ResetToEarliest || resetTo == ResetToLatest {
+		return resetParams{To: resetTo}
+	}
+	return resetParams{To: ResetToDateTime, Datetime: resetTo}
+}
+
+func (rp resetParams) spec() (kafka.ResetSpec, error) {
+	spec := kafka.ResetSpec{To: rp.To}
+
+	switch rp.To {
+	case ResetToEarliest, ResetToLatest:
+
+	case ResetToDateTime:
+		datetime, err := time.Parse(time.RFC3339, rp.Datetime)
+		if err != nil {
+			return spec, errors.ErrInvalid.WithMsgf("invalid reset datetime '%s': %v", rp.Datetime, err)
+		}
+		spec.Datetime = datetime
+
+	case ResetToOffsets:
+		if len(rp.Offsets) == 0 {
+			return spec, errors.ErrInvalid.WithMsgf("offsets must be set for reset to '%s'", rp.To)
+		}
+
+		spec.Offsets = map[int]int64{}
+		for _, po := range rp.Offsets {
+			if _, dup := spec.Offsets[po.Partition]; dup {
+				return spec, errors.ErrInvalid.WithMsgf("offset of partition %d is set more than once", po.Partition)
+			} else if po.Partition < 0 || po.Offset < 0 {
+				return spec, errors.ErrInvalid.WithMsgf("partition and offset must not be negative")
+			}
+			spec.Offsets[po.Partition] = po.Offset
+		}
+
+	case ResetToShiftBy:
+		if rp.ShiftBy == 0 {
+			return spec, errors.ErrInvalid.WithMsgf("shift_by must be set for reset to '%s'", rp.To)
+		}
+		spec.ShiftBy = rp.ShiftBy
+
+	case ResetToGroup:
+		if rp.Group == "" {
+			return spec, errors.ErrInvalid.WithMsgf("group must be set for reset to '%s'", rp.To)
+		}
+		spec.Group = rp.Group
+
+	default:
+		return spec, errors.ErrInvalid.WithMsgf("unknown reset target '%s'", rp.To)
+	}
+
+	return spec, nil
+}
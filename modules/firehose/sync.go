@@ -3,6 +3,7 @@ package firehose
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/odpf/entropy/core/module"
@@ -11,6 +12,7 @@ import (
 	"github.com/odpf/entropy/modules/kubernetes"
 	"github.com/odpf/entropy/pkg/errors"
 	"github.com/odpf/entropy/pkg/helm"
+	pkgkafka "github.com/odpf/entropy/pkg/kafka"
 	"github.com/odpf/entropy/pkg/kube"
 	"github.com/odpf/entropy/pkg/worker"
 )
@@ -18,11 +20,17 @@ import (
 const (
 	networkErrorRetryDuration   = 5 * time.Second
 	kubeAPIRetryBackoffDuration = 30 * time.Second
+	rolloutPollDuration         = 10 * time.Second
+
+	// rolloutTimeout is the time given to the pods of a release to be
+	// replaced and become ready, after which the rollout is failed.
+	rolloutTimeout = 10 * time.Minute
 )
 
 var (
-	ErrNetwork = worker.RetryableError{RetryAfter: networkErrorRetryDuration}
-	ErrKubeAPI = worker.RetryableError{RetryAfter: kubeAPIRetryBackoffDuration}
+	ErrNetwork        = worker.RetryableError{RetryAfter: networkErrorRetryDuration}
+	ErrKubeAPI        = worker.RetryableError{RetryAfter: kubeAPIRetryBackoffDuration}
+	ErrRolloutPending = worker.RetryableError{RetryAfter: rolloutPollDuration}
 )
 
 func (m *firehoseModule) Sync(ctx context.Context, res module.ExpandedResource) (*resource.State, error) {
@@ -49,86 +57,274 @@ func (m *firehoseModule) Sync(ctx context.Context, res module.ExpandedResource)
 		return nil, err
 	}
 
+	var lastReset *pkgkafka.ResetResult
+	var rotation *PreviousConsumerGroup
+	var rollout *kube.RolloutStatus
 	switch pendingStep {
 	case releaseCreate, releaseUpdate:
 		if data.StateOverride != "" {
 			conf.State = data.StateOverride
 		}
-		if err := m.releaseSync(pendingStep == releaseCreate, conf, r, kubeOut); err != nil {
+		if err := m.releaseSync(ctx, pendingStep == releaseCreate, conf, res, kubeOut); err != nil {
+			return nil, err
+		}
+		m.awaitRollout(&data)
+	case releaseDelete:
+		if err := m.releaseDelete(ctx, conf, r, kubeOut); err != nil {
 			return nil, err
 		}
+		return &resource.State{
+			Status:     resource.StatusCompleted,
+			Output:     r.State.Output,
+			ModuleData: data.JSON(),
+		}, nil
+	case releaseRollback:
+		if err := m.releaseRollback(conf, r, kubeOut, data.RollbackTo); err != nil {
+			return nil, err
+		}
+		data.RollbackTo = 0
+		m.awaitRollout(&data)
+	case releaseRollout:
+		status, err := m.rolloutStatus(ctx, conf, r, kubeOut)
+		timedOut := data.RolloutDeadline != nil && m.clock().After(*data.RolloutDeadline)
+		if rollout, err = rolloutResult(status, err, timedOut); err != nil {
+			return nil, err
+		}
+		data.RolloutDeadline = nil
 	case consumerReset:
-		if err := m.consumerReset(ctx,
+		params := legacyResetParams(data.ResetTo)
+		if data.Reset != nil {
+			params = *data.Reset
+		}
+
+		result, err := m.consumerReset(ctx,
 			conf,
 			r,
-			data.ResetTo,
-			kubeOut); err != nil {
+			params,
+			kubeOut)
+		if err != nil {
 			return nil, err
 		}
+		lastReset = result
 		data.StateOverride = ""
+
+		if data.RotatedFrom != "" {
+			rotation = &PreviousConsumerGroup{
+				Group:     data.RotatedFrom,
+				RotatedAt: m.clock(),
+				Lag:       m.groupLag(ctx, conf, data.RotatedFrom),
+			}
+			data.RotatedFrom = ""
+		}
 	default:
-		if err := m.releaseSync(pendingStep == releaseCreate, conf, r, kubeOut); err != nil {
+		if err := m.releaseSync(ctx, pendingStep == releaseCreate, conf, res, kubeOut); err != nil {
 			return nil, err
 		}
+		m.awaitRollout(&data)
 	}
 
-	finalStatus := resource.StatusCompleted
-	if len(data.PendingSteps) > 0 {
-		finalStatus = resource.StatusPending
-	}
+	finalStatus, rollout := finishRollout(&data, rollout)
 
-	output, err := m.Output(ctx, res)
+	output, err := m.observe(ctx, res)
 	if err != nil {
 		return nil, err
 	}
+	if pendingStep == consumerReset {
+		output.LastReset = lastReset
+	}
+	if rotation != nil {
+		output.PreviousConsumerGroups = append(output.PreviousConsumerGroups, *rotation)
+	}
+	if rollout != nil {
+		output.Rollout = rollout
+	}
 
 	return &resource.State{
 		Status:     finalStatus,
-		Output:     output,
+		Output:     output.JSON(),
 		ModuleData: data.JSON(),
 	}, nil
 }
 
-func (*firehoseModule) releaseSync(isCreate bool, conf moduleConfig, r resource.Resource, kube kubernetes.Output) error {
-	helmCl := helm.NewClient(&helm.Config{Kubernetes: kube.Configs})
+func (*firehoseModule) releaseSync(ctx context.Context, isCreate bool, conf moduleConfig, res module.ExpandedResource, kubeOut kubernetes.Output) error {
+	r := res.Resource
+	helmCl := helm.NewClient(&helm.Config{Kubernetes: kubeOut.Configs})
 
 	if conf.State == stateStopped || (conf.StopTime != nil && conf.StopTime.Before(time.Now())) {
 		conf.Firehose.Replicas = 0
 	}
 
+	if err := conf.applyDatabaseDependency(res.Dependencies); err != nil {
+		// the database may not have completed its first sync yet.
+		return ErrNetwork.WithCause(err)
+	}
+
+	var secretData map[string]string
+	if conf.hasSecretRefs() {
+		data, err := conf.secretData(res.Secrets)
+		if err != nil {
+			return err
+		}
+		secretData = data
+		conf.secretsChecksum = secretsChecksum(data)
+	}
+
 	hc, err := conf.GetHelmReleaseConfig(r)
 	if err != nil {
 		return err
 	}
 
+	kubeCl := kube.NewClient(kubeOut.Configs)
+	if secretData != nil {
+		if err := kubeCl.ApplySecret(ctx, hc.Namespace, generateSecretName(r), secretData); err != nil {
+			return ErrKubeAPI.WithCause(err)
+		}
+	}
+
 	var helmErr error
 	if isCreate {
 		_, helmErr = helmCl.Create(hc)
 	} else {
 		_, helmErr = helmCl.Update(hc)
 	}
+	if helmErr != nil {
+		return helmErr
+	}
 
-	return helmErr
+	if secretData == nil {
+		// the release no longer refers to the secret.
+		if err := kubeCl.DeleteSecret(ctx, hc.Namespace, generateSecretName(r)); err != nil {
+			return ErrKubeAPI.WithCause(err)
+		}
+	}
+	return nil
 }
 
-func (*firehoseModule) consumerReset(ctx context.Context, conf moduleConfig, r resource.Resource, resetTo string, out kubernetes.Output) error {
-	releaseConfig, err := conf.GetHelmReleaseConfig(r)
+// releaseDelete uninstalls the release and deletes the secret of the
+// firehose.
+func (*firehoseModule) releaseDelete(ctx context.Context, conf moduleConfig, r resource.Resource, kubeOut kubernetes.Output) error {
+	hc, err := conf.GetHelmReleaseConfig(r)
 	if err != nil {
 		return err
 	}
 
-	cgm := kafka.NewConsumerGroupManager(conf.Firehose.KafkaBrokerAddress, kube.NewClient(out.Configs), releaseConfig.Namespace)
+	helmCl := helm.NewClient(&helm.Config{Kubernetes: kubeOut.Configs})
+	if err := helmCl.Delete(hc); err != nil {
+		return err
+	}
+
+	kubeCl := kube.NewClient(kubeOut.Configs)
+	if err := kubeCl.DeleteSecret(ctx, hc.Namespace, generateSecretName(r)); err != nil {
+		return ErrKubeAPI.WithCause(err)
+	}
+	return nil
+}
+
+func (*firehoseModule) releaseRollback(conf moduleConfig, r resource.Resource, kubeOut kubernetes.Output, revision int) error {
+	hc, err := conf.GetHelmReleaseConfig(r)
+	if err != nil {
+		return err
+	}
+
+	helmCl := helm.NewClient(&helm.Config{Kubernetes: kubeOut.Configs})
+	_, err = helmCl.Rollback(hc, revision)
+	return err
+}
+
+// awaitRollout makes the rollout of the release the next step. The release
+// is not waited for by helm, so that the worker is not blocked by it.
+func (m *firehoseModule) awaitRollout(data *moduleData) {
+	if len(data.PendingSteps) == 0 || data.PendingSteps[0] != releaseRollout {
+		data.PendingSteps = append([]string{releaseRollout}, data.PendingSteps...)
+	}
+	deadline := m.clock().Add(rolloutTimeout)
+	data.RolloutDeadline = &deadline
+}
+
+// finishRollout returns the status of the resource after the rollout of
+// the current step, if any. A failed rollout skips the remaining steps. If
+// the firehose was stopped for them (e.g., for a consumer reset), the
+// release is first updated back to the configured state, and the failed
+// rollout is reported after that.
+func finishRollout(data *moduleData, rollout *kube.RolloutStatus) (string, *kube.RolloutStatus) {
+	failed := rollout != nil && rollout.Phase == kube.RolloutFailed
+
+	switch {
+	case failed && data.StateOverride != "":
+		data.StateOverride = ""
+		data.PendingSteps = []string{releaseUpdate}
+		data.FailedRollout = rollout
+		return resource.StatusPending, rollout
+
+	case failed:
+		data.PendingSteps = nil
+		data.FailedRollout = nil
+		return resource.StatusError, rollout
+
+	case len(data.PendingSteps) > 0:
+		return resource.StatusPending, rollout
+
+	case data.FailedRollout != nil:
+		rollout, data.FailedRollout = data.FailedRollout, nil
+		return resource.StatusError, rollout
 
-	switch resetTo {
-	case ResetToEarliest:
-		err = cgm.ResetOffsetToEarliest(ctx, conf.Firehose.KafkaConsumerID)
-	case ResetToLatest:
-		err = cgm.ResetOffsetToLatest(ctx, conf.Firehose.KafkaConsumerID)
 	default:
-		err = cgm.ResetOffsetToDatetime(ctx, conf.Firehose.KafkaConsumerID, resetTo)
+		return resource.StatusCompleted, rollout
+	}
+}
+
+// rolloutResult returns the final status of a rollout, or an error to check
+// the rollout again later. A rollout that does not complete before the
+// deadline is failed.
+func rolloutResult(status *kube.RolloutStatus, err error, timedOut bool) (*kube.RolloutStatus, error) {
+	if err != nil {
+		if !timedOut {
+			return nil, ErrKubeAPI.WithCause(err)
+		}
+		status = &kube.RolloutStatus{Phase: kube.RolloutInProgress, Message: errors.Verbose(err).Error()}
+	}
+
+	if status.Phase == kube.RolloutInProgress {
+		if !timedOut {
+			// the step is retried, without blocking the worker.
+			return nil, ErrRolloutPending.WithCause(errors.New(status.Message))
+		}
+		return &kube.RolloutStatus{
+			Phase:   kube.RolloutFailed,
+			Message: fmt.Sprintf("rollout did not complete in %s: %s", rolloutTimeout, status.Message),
+		}, nil
+	}
+	return status, nil
+}
+
+func (*firehoseModule) rolloutStatus(ctx context.Context, conf moduleConfig, r resource.Resource, out kubernetes.Output) (*kube.RolloutStatus, error) {
+	hc, err := conf.GetHelmReleaseConfig(r)
+	if err != nil {
+		return nil, err
 	}
 
-	return handleErr(err)
+	kubeCl := kube.NewClient(out.Configs)
+	return kubeCl.GetRolloutStatus(ctx, hc.Namespace, map[string]string{"app": hc.Name})
+}
+
+func (*firehoseModule) consumerReset(ctx context.Context, conf moduleConfig, r resource.Resource, params resetParams, out kubernetes.Output) (*pkgkafka.ResetResult, error) {
+	releaseConfig, err := conf.GetHelmReleaseConfig(r)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := params.spec()
+	if err != nil {
+		return nil, err
+	}
+
+	cgm := kafka.NewConsumerGroupManager(conf.Firehose.KafkaBrokerAddress, kube.NewClient(out.Configs), releaseConfig.Namespace)
+
+	result, err := cgm.ResetOffsets(ctx, conf.Firehose.KafkaConsumerID, conf.Firehose.KafkaTopic, spec)
+	if err != nil {
+		return nil, handleErr(err)
+	}
+	return result, nil
 }
 
 func handleErr(err error) error {
@@ -139,6 +335,9 @@ func handleErr(err error) error {
 		return ErrKubeAPI.WithCause(err)
 	case errors.Is(err, kube.ErrJobExecutionFailed):
 		return ErrKubeAPI.WithCause(err)
+	case errors.Is(err, pkgkafka.ErrGroupActive):
+		// members of the group may still be shutting down.
+		return ErrNetwork.WithCause(err)
 	default:
 		return err
 	}
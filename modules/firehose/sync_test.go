@@ -0,0 +1,196 @@
+package firehose
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/kube"
+	"github.com/odpf/entropy/pkg/worker"
+)
+
+func TestFirehoseModule_AwaitRollout(t *testing.T) {
+	t.Parallel()
+
+	m := firehoseModule{clock: func() time.Time { return frozenTime }}
+	deadline := frozenTime.Add(rolloutTimeout)
+
+	table := []struct {
+		title string
+		steps []string
+		want  []string
+	}{
+		{title: "LastStep", steps: nil, want: []string{releaseRollout}},
+		{title: "BeforeOtherSteps", steps: []string{consumerReset, releaseUpdate}, want: []string{releaseRollout, consumerReset, releaseUpdate}},
+		{title: "AlreadyPlanned", steps: []string{releaseRollout}, want: []string{releaseRollout}},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			data := moduleData{PendingSteps: tt.steps}
+			m.awaitRollout(&data)
+			assert.Equal(t, tt.want, data.PendingSteps)
+			assert.Equal(t, &deadline, data.RolloutDeadline)
+		})
+	}
+}
+
+func TestFinishRollout(t *testing.T) {
+	t.Parallel()
+
+	complete := &kube.RolloutStatus{Phase: kube.RolloutComplete}
+	failed := &kube.RolloutStatus{Phase: kube.RolloutFailed, Message: "exceeded its progress deadline"}
+
+	table := []struct {
+		title       string
+		data        moduleData
+		rollout     *kube.RolloutStatus
+		wantStatus  string
+		wantRollout *kube.RolloutStatus
+		wantData    moduleData
+	}{
+		{
+			title:       "Completed",
+			rollout:     complete,
+			wantStatus:  resource.StatusCompleted,
+			wantRollout: complete,
+		},
+		{
+			title:       "MoreSteps",
+			data:        moduleData{PendingSteps: []string{releaseRollout}},
+			wantStatus:  resource.StatusPending,
+			wantRollout: nil,
+			wantData:    moduleData{PendingSteps: []string{releaseRollout}},
+		},
+		{
+			title:       "Failed",
+			rollout:     failed,
+			wantStatus:  resource.StatusError,
+			wantRollout: failed,
+		},
+		{
+			title: "FailedWhileStopped",
+			data: moduleData{
+				PendingSteps:  []string{consumerReset, releaseUpdate},
+				StateOverride: stateStopped,
+			},
+			rollout:     failed,
+			wantStatus:  resource.StatusPending,
+			wantRollout: failed,
+			wantData: moduleData{
+				PendingSteps:  []string{releaseUpdate},
+				FailedRollout: failed,
+			},
+		},
+		{
+			title:       "RestoredAfterFailure",
+			data:        moduleData{FailedRollout: failed},
+			rollout:     complete,
+			wantStatus:  resource.StatusError,
+			wantRollout: failed,
+		},
+		{
+			title: "RestoreFailed",
+			data:  moduleData{FailedRollout: failed},
+			rollout: &kube.RolloutStatus{
+				Phase:   kube.RolloutFailed,
+				Message: "rollout did not complete in 10m0s",
+			},
+			wantStatus: resource.StatusError,
+			wantRollout: &kube.RolloutStatus{
+				Phase:   kube.RolloutFailed,
+				Message: "rollout did not complete in 10m0s",
+			},
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			data := tt.data
+			status, rollout := finishRollout(&data, tt.rollout)
+			assert.Equal(t, tt.wantStatus, status)
+			assert.Equal(t, tt.wantRollout, rollout)
+			assert.Equal(t, tt.wantData, data)
+		})
+	}
+}
+
+func TestRolloutResult(t *testing.T) {
+	t.Parallel()
+
+	inProgress := &kube.RolloutStatus{Phase: kube.RolloutInProgress, Message: "1 of 3 new replicas have been updated"}
+
+	table := []struct {
+		title     string
+		status    *kube.RolloutStatus
+		err       error
+		timedOut  bool
+		want      *kube.RolloutStatus
+		wantRetry bool
+	}{
+		{
+			title:  "Complete",
+			status: &kube.RolloutStatus{Phase: kube.RolloutComplete},
+			want:   &kube.RolloutStatus{Phase: kube.RolloutComplete},
+		},
+		{
+			title:  "Failed",
+			status: &kube.RolloutStatus{Phase: kube.RolloutFailed, Message: "exceeded its progress deadline"},
+			want:   &kube.RolloutStatus{Phase: kube.RolloutFailed, Message: "exceeded its progress deadline"},
+		},
+		{
+			title:     "InProgress",
+			status:    inProgress,
+			wantRetry: true,
+		},
+		{
+			title:    "InProgressTimedOut",
+			status:   inProgress,
+			timedOut: true,
+			want: &kube.RolloutStatus{
+				Phase:   kube.RolloutFailed,
+				Message: "rollout did not complete in 10m0s: 1 of 3 new replicas have been updated",
+			},
+		},
+		{
+			title:     "KubeError",
+			err:       kube.ErrDeploymentNotFound,
+			wantRetry: true,
+		},
+		{
+			title:    "KubeErrorTimedOut",
+			err:      errors.New("connection refused"),
+			timedOut: true,
+			want: &kube.RolloutStatus{
+				Phase:   kube.RolloutFailed,
+				Message: "rollout did not complete in 10m0s: connection refused",
+			},
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := rolloutResult(tt.status, tt.err, tt.timedOut)
+			if tt.wantRetry {
+				var re *worker.RetryableError
+				assert.ErrorAs(t, err, &re)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
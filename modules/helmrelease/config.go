@@ -0,0 +1,79 @@
+package helmrelease
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/helm"
+)
+
+const (
+	defaultNamespace = "default"
+	defaultTimeout   = 300
+
+	// maxReleaseNameLen is the limit helm imposes on release names.
+	maxReleaseNameLen = 53
+)
+
+type moduleConfig struct {
+	ReleaseName     string                 `json:"release_name"`
+	Repository      string                 `json:"repository,omitempty"`
+	Chart           string                 `json:"chart"`
+	Version         string                 `json:"version,omitempty"`
+	Namespace       string                 `json:"namespace"`
+	Values          map[string]interface{} `json:"values,omitempty"`
+	CreateNamespace bool                   `json:"create_namespace,omitempty"`
+	Wait            bool                   `json:"wait,omitempty"`
+	Timeout         int                    `json:"timeout"`
+}
+
+func readConfig(r resource.Resource, confJSON json.RawMessage) (*moduleConfig, error) {
+	var conf moduleConfig
+	if err := json.Unmarshal(confJSON, &conf); err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid config json: %v", err)
+	}
+
+	if conf.ReleaseName == "" {
+		conf.ReleaseName = defaultReleaseName(r)
+	}
+	if conf.Namespace == "" {
+		conf.Namespace = defaultNamespace
+	}
+	if conf.Timeout == 0 {
+		conf.Timeout = defaultTimeout
+	}
+	return &conf, nil
+}
+
+func (mc moduleConfig) helmReleaseConfig() *helm.ReleaseConfig {
+	rc := helm.DefaultReleaseConfig()
+	rc.Name = mc.ReleaseName
+	rc.Repository = mc.Repository
+	rc.Chart = mc.Chart
+	rc.Version = mc.Version
+	rc.Namespace = mc.Namespace
+	rc.Values = mc.Values
+	rc.CreateNamespace = mc.CreateNamespace
+	rc.Wait = mc.Wait
+	rc.Timeout = mc.Timeout
+	return rc
+}
+
+func (mc moduleConfig) JSON() []byte {
+	b, err := json.Marshal(mc)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func defaultReleaseName(r resource.Resource) string {
+	name := strings.ToLower(fmt.Sprintf("%s-%s", r.Project, r.Name))
+	if len(name) > maxReleaseNameLen {
+		name = name[:maxReleaseNameLen]
+	}
+	return strings.Trim(name, "-")
+}
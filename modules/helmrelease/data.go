@@ -0,0 +1,59 @@
+package helmrelease
+
+import (
+	"encoding/json"
+
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+// maxReleaseConfigs is the number of latest revisions of the release whose
+// configs are kept for rollbacks.
+const maxReleaseConfigs = 10
+
+type moduleData struct {
+	PendingSteps []string `json:"pending_steps"`
+	RollbackTo   int      `json:"rollback_to,omitempty"`
+
+	// ReleaseConfigs are the configs of the resource applied by each of the
+	// latest revisions of the release. A rollback restores the configs from
+	// here without querying the cluster.
+	ReleaseConfigs map[int]json.RawMessage `json:"release_configs,omitempty"`
+}
+
+func readModuleData(raw json.RawMessage) (*moduleData, error) {
+	var data moduleData
+	if len(raw) == 0 {
+		return &data, nil
+	}
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid module data json: %v", err)
+	}
+	return &data, nil
+}
+
+// recordConfigs records the configs applied by the given revision of the
+// release, dropping the oldest revisions beyond maxReleaseConfigs.
+func (md *moduleData) recordConfigs(revision int, configs json.RawMessage) {
+	if md.ReleaseConfigs == nil {
+		md.ReleaseConfigs = map[int]json.RawMessage{}
+	}
+	md.ReleaseConfigs[revision] = configs
+
+	for len(md.ReleaseConfigs) > maxReleaseConfigs {
+		oldest := revision
+		for rev := range md.ReleaseConfigs {
+			if rev < oldest {
+				oldest = rev
+			}
+		}
+		delete(md.ReleaseConfigs, oldest)
+	}
+}
+
+func (md moduleData) JSON() json.RawMessage {
+	bytes, err := json.Marshal(md)
+	if err != nil {
+		panic(err)
+	}
+	return bytes
+}
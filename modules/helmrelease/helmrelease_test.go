@@ -0,0 +1,47 @@
+package helmrelease
+
+import (
+	"github.com/odpf/entropy/pkg/helm"
+	"github.com/odpf/entropy/pkg/kube"
+)
+
+// fakeHelm records the calls made to it and returns the configured results.
+type fakeHelm struct {
+	validateErr error
+	release     *helm.Release
+	err         error
+
+	calls    []string
+	revision int
+}
+
+func (f *fakeHelm) module() *helmReleaseModule {
+	return &helmReleaseModule{
+		helmClient: func(_ kube.Config) helmClient { return f },
+	}
+}
+
+func (f *fakeHelm) Create(_ *helm.ReleaseConfig) (*helm.Release, error) {
+	f.calls = append(f.calls, "create")
+	return f.release, f.err
+}
+
+func (f *fakeHelm) Update(_ *helm.ReleaseConfig) (*helm.Release, error) {
+	f.calls = append(f.calls, "update")
+	return f.release, f.err
+}
+
+func (f *fakeHelm) Rollback(_ *helm.ReleaseConfig, revision int) (*helm.Release, error) {
+	f.calls = append(f.calls, "rollback")
+	f.revision = revision
+	return f.release, f.err
+}
+
+func (f *fakeHelm) Delete(_ *helm.ReleaseConfig) error {
+	f.calls = append(f.calls, "delete")
+	return f.err
+}
+
+func (f *fakeHelm) ValidateValues(_ *helm.ReleaseConfig) error {
+	return f.validateErr
+}
@@ -0,0 +1,78 @@
+package helmrelease
+
+import (
+	_ "embed"
+	"encoding/json"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/modules/kubernetes"
+	"github.com/odpf/entropy/pkg/helm"
+	"github.com/odpf/entropy/pkg/kube"
+)
+
+const RollbackAction = "rollback"
+
+const (
+	releaseInstall   = "release_install"
+	releaseUpgrade   = "release_upgrade"
+	releaseRollback  = "release_rollback"
+	releaseUninstall = "release_uninstall"
+)
+
+const keyKubeDependency = "kube_cluster"
+
+var (
+	//go:embed schema/config.json
+	configSchema string
+
+	//go:embed schema/rollback.json
+	rollbackActionSchema string
+)
+
+var Module = module.Descriptor{
+	Kind: "helm_release",
+	Dependencies: map[string]string{
+		keyKubeDependency: kubernetes.Module.Kind,
+	},
+	Actions: []module.ActionDesc{
+		{
+			Name:        module.CreateAction,
+			Description: "Installs the helm chart.",
+			ParamSchema: configSchema,
+		},
+		{
+			Name:        module.UpdateAction,
+			Description: "Upgrades the helm release with the given chart & values.",
+			ParamSchema: configSchema,
+		},
+		{
+			Name:        RollbackAction,
+			Description: "Rolls back the helm release to a revision (previous revision by default).",
+			ParamSchema: rollbackActionSchema,
+		},
+		{
+			Name:        module.DeleteAction,
+			Description: "Uninstalls the helm release.",
+		},
+	},
+	DriverFactory: func(conf json.RawMessage) (module.Driver, error) {
+		return &helmReleaseModule{
+			helmClient: func(kubeConf kube.Config) helmClient {
+				return helm.NewClient(&helm.Config{Kubernetes: kubeConf})
+			},
+		}, nil
+	},
+}
+
+// helmClient is the subset of helm.Client used by the module.
+type helmClient interface {
+	Create(config *helm.ReleaseConfig) (*helm.Release, error)
+	Update(config *helm.ReleaseConfig) (*helm.Release, error)
+	Rollback(config *helm.ReleaseConfig, revision int) (*helm.Release, error)
+	Delete(config *helm.ReleaseConfig) error
+	ValidateValues(config *helm.ReleaseConfig) error
+}
+
+type helmReleaseModule struct {
+	helmClient func(kubeConf kube.Config) helmClient
+}
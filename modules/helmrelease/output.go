@@ -0,0 +1,67 @@
+package helmrelease
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/helm"
+)
+
+type Output struct {
+	Namespace    string `json:"namespace,omitempty"`
+	ReleaseName  string `json:"release_name,omitempty"`
+	Chart        string `json:"chart,omitempty"`
+	ChartVersion string `json:"chart_version,omitempty"`
+	Revision     int    `json:"revision,omitempty"`
+	Status       string `json:"status,omitempty"`
+}
+
+func (out Output) JSON() []byte {
+	b, err := json.Marshal(out)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func readOutput(raw json.RawMessage) (*Output, error) {
+	var out Output
+	if len(raw) == 0 {
+		return &out, nil
+	}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid output json: %v", err)
+	}
+	return &out, nil
+}
+
+func (*helmReleaseModule) Output(_ context.Context, res module.ExpandedResource) (json.RawMessage, error) {
+	return res.Resource.State.Output, nil
+}
+
+// releaseOutput builds the output from the release returned by helm.
+func releaseOutput(conf moduleConfig, rel *helm.Release) Output {
+	out := Output{
+		Namespace:   conf.Namespace,
+		ReleaseName: conf.ReleaseName,
+		Status:      rel.Output.Status.String(),
+	}
+
+	var relInfo struct {
+		Version int `json:"version"`
+		Chart   struct {
+			Metadata struct {
+				Name    string `json:"name"`
+				Version string `json:"version"`
+			} `json:"metadata"`
+		} `json:"chart"`
+	}
+	if err := json.Unmarshal([]byte(rel.Output.Release), &relInfo); err == nil {
+		out.Revision = relInfo.Version
+		out.Chart = relInfo.Chart.Metadata.Name
+		out.ChartVersion = relInfo.Chart.Metadata.Version
+	}
+	return out
+}
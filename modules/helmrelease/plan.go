@@ -0,0 +1,164 @@
+package helmrelease
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/modules/kubernetes"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+func (m *helmReleaseModule) Plan(_ context.Context, res module.ExpandedResource, act module.ActionRequest) (*module.Plan, error) {
+	switch act.Name {
+	case module.CreateAction, module.UpdateAction:
+		return m.planRelease(res, act)
+	case RollbackAction:
+		return m.planRollback(res, act)
+	case module.DeleteAction:
+		return m.planUninstall(res)
+	default:
+		return nil, errors.ErrInvalid.WithMsgf("action '%s' not supported", act.Name)
+	}
+}
+
+func (m *helmReleaseModule) planRelease(res module.ExpandedResource, act module.ActionRequest) (*module.Plan, error) {
+	r := res.Resource
+	isCreate := act.Name == module.CreateAction
+
+	conf, err := readConfig(r, act.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isCreate {
+		curConf, err := readConfig(r, r.Spec.Configs)
+		if err != nil {
+			return nil, err
+		}
+
+		if conf.ReleaseName != curConf.ReleaseName || conf.Namespace != curConf.Namespace {
+			return nil, errors.ErrInvalid.
+				WithMsgf("release_name & namespace of a helm release cannot be changed")
+		}
+	}
+
+	kubeOut, err := kubernetes.ClusterOutput(res, keyKubeDependency)
+	if err != nil {
+		return nil, err
+	}
+
+	// values are validated against the chart schema upfront so that invalid
+	// values are rejected instead of failing during sync.
+	if err := m.helmClient(kubeOut.Configs).ValidateValues(conf.helmReleaseConfig()); err != nil {
+		return nil, err
+	}
+
+	step, reason := releaseInstall, "helm release installed"
+	if !isCreate {
+		step, reason = releaseUpgrade, "helm release upgraded"
+	}
+
+	output, err := readOutput(r.State.Output)
+	if err != nil {
+		return nil, err
+	}
+	output.Namespace = conf.Namespace
+	output.ReleaseName = conf.ReleaseName
+
+	data, err := readModuleData(r.State.ModuleData)
+	if err != nil {
+		return nil, err
+	}
+
+	r.Spec.Configs = conf.JSON()
+	r.State = resource.State{
+		Status: resource.StatusPending,
+		Output: output.JSON(),
+		ModuleData: moduleData{
+			PendingSteps:   []string{step},
+			ReleaseConfigs: data.ReleaseConfigs,
+		}.JSON(),
+	}
+
+	return &module.Plan{Resource: r, Reason: reason}, nil
+}
+
+func (*helmReleaseModule) planRollback(res module.ExpandedResource, act module.ActionRequest) (*module.Plan, error) {
+	r := res.Resource
+
+	var params struct {
+		Revision int `json:"revision"`
+	}
+	if len(act.Params) > 0 {
+		if err := json.Unmarshal(act.Params, &params); err != nil {
+			return nil, errors.ErrInvalid.WithMsgf("invalid params json: %v", err)
+		}
+	}
+
+	if params.Revision < 0 {
+		return nil, errors.ErrInvalid.WithMsgf("revision must not be negative")
+	}
+
+	output, err := readOutput(r.State.Output)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := readModuleData(r.State.ModuleData)
+	if err != nil {
+		return nil, err
+	}
+
+	// like helm, revision 0 is the one before the current revision.
+	target := params.Revision
+	if target == 0 {
+		target = output.Revision - 1
+	}
+	if target < 1 || target >= output.Revision {
+		return nil, errors.ErrInvalid.
+			WithMsgf("revision %d is not an earlier revision of the release (current: %d)", target, output.Revision)
+	}
+
+	// the configs are restored so that the next update does not apply the
+	// rolled back configs again.
+	configs, found := data.ReleaseConfigs[target]
+	if !found {
+		return nil, errors.ErrInvalid.
+			WithMsgf("configs of revision %d are not recorded, update the release instead", target)
+	}
+
+	r.Spec.Configs = configs
+	r.State = resource.State{
+		Status: resource.StatusPending,
+		Output: r.State.Output,
+		ModuleData: moduleData{
+			PendingSteps:   []string{releaseRollback},
+			RollbackTo:     target,
+			ReleaseConfigs: data.ReleaseConfigs,
+		}.JSON(),
+	}
+
+	return &module.Plan{Resource: r, Reason: "helm release rolled back"}, nil
+}
+
+func (*helmReleaseModule) planUninstall(res module.ExpandedResource) (*module.Plan, error) {
+	r := res.Resource
+
+	data, err := readModuleData(r.State.ModuleData)
+	if err != nil {
+		return nil, err
+	}
+
+	r.State = resource.State{
+		Status: resource.StatusDeleted,
+		Output: r.State.Output,
+		ModuleData: moduleData{
+			PendingSteps:   []string{releaseUninstall},
+			ReleaseConfigs: data.ReleaseConfigs,
+		}.JSON(),
+	}
+
+	return &module.Plan{Resource: r, Reason: "helm release uninstalled"}, nil
+}
@@ -0,0 +1,188 @@
+package helmrelease
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+func TestHelmReleaseModule_Plan(t *testing.T) {
+	t.Parallel()
+
+	res := resource.Resource{
+		URN:     "orn:entropy:helm_release:demo:redis",
+		Kind:    "helm_release",
+		Name:    "redis",
+		Project: "demo",
+		Spec: resource.Spec{
+			Configs: []byte(`{"release_name":"demo-redis","chart":"redis","namespace":"default","timeout":300,"wait":true}`),
+		},
+		State: resource.State{
+			Status: resource.StatusCompleted,
+			Output: []byte(`{"namespace":"default","release_name":"demo-redis","revision":2}`),
+			ModuleData: []byte(`{"pending_steps":[],"release_configs":{
+				"1":{"release_name":"demo-redis","chart":"redis","version":"16.0.0","namespace":"default","timeout":300},
+				"2":{"release_name":"demo-redis","chart":"redis","namespace":"default","timeout":300,"wait":true}
+			}}`),
+		},
+	}
+	releaseConfigs := `"release_configs":{
+		"1":{"release_name":"demo-redis","chart":"redis","version":"16.0.0","namespace":"default","timeout":300},
+		"2":{"release_name":"demo-redis","chart":"redis","namespace":"default","timeout":300,"wait":true}
+	}`
+	deps := map[string]module.ResolvedDependency{
+		keyKubeDependency: {Kind: "kubernetes", Output: []byte(`{"configs":{}}`)},
+	}
+
+	table := []struct {
+		title       string
+		act         module.ActionRequest
+		modify      func(r *resource.Resource)
+		validateErr error
+		want        *resource.Resource
+		wantReason  string
+		wantErr     error
+	}{
+		{
+			title:   "InvalidConfiguration",
+			act:     module.ActionRequest{Name: module.CreateAction, Params: []byte(`{`)},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title:       "ValuesNotMatchingSchema",
+			act:         module.ActionRequest{Name: module.CreateAction, Params: []byte(`{"chart":"redis","values":{"replicas":"one"}}`)},
+			validateErr: errors.ErrInvalid.WithMsgf("values do not match the chart schema"),
+			wantErr:     errors.ErrInvalid,
+		},
+		{
+			title: "Install",
+			act:   module.ActionRequest{Name: module.CreateAction, Params: []byte(`{"repository":"https://charts.bitnami.com/bitnami","chart":"redis"}`)},
+			want: &resource.Resource{
+				Spec: resource.Spec{
+					Configs: []byte(`{"release_name":"demo-redis","repository":"https://charts.bitnami.com/bitnami","chart":"redis","namespace":"default","timeout":300}`),
+				},
+				State: resource.State{
+					Status:     resource.StatusPending,
+					Output:     []byte(`{"namespace":"default","release_name":"demo-redis","revision":2}`),
+					ModuleData: []byte(`{"pending_steps":["release_install"],` + releaseConfigs + `}`),
+				},
+			},
+			wantReason: "helm release installed",
+		},
+		{
+			title: "Upgrade",
+			act:   module.ActionRequest{Name: module.UpdateAction, Params: []byte(`{"chart":"redis","version":"17.0.0","values":{"replicas":2}}`)},
+			want: &resource.Resource{
+				Spec: resource.Spec{
+					Configs: []byte(`{"release_name":"demo-redis","chart":"redis","version":"17.0.0","namespace":"default","values":{"replicas":2},"timeout":300}`),
+				},
+				State: resource.State{
+					Status:     resource.StatusPending,
+					Output:     []byte(`{"namespace":"default","release_name":"demo-redis","revision":2}`),
+					ModuleData: []byte(`{"pending_steps":["release_upgrade"],` + releaseConfigs + `}`),
+				},
+			},
+			wantReason: "helm release upgraded",
+		},
+		{
+			title:   "UpgradeChangingNamespace",
+			act:     module.ActionRequest{Name: module.UpdateAction, Params: []byte(`{"chart":"redis","namespace":"cache"}`)},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title: "Rollback",
+			act:   module.ActionRequest{Name: RollbackAction, Params: []byte(`{"revision":1}`)},
+			want: &resource.Resource{
+				Spec: resource.Spec{
+					Configs: []byte(`{"release_name":"demo-redis","chart":"redis","version":"16.0.0","namespace":"default","timeout":300}`),
+				},
+				State: resource.State{
+					Status:     resource.StatusPending,
+					Output:     res.State.Output,
+					ModuleData: []byte(`{"pending_steps":["release_rollback"],"rollback_to":1,` + releaseConfigs + `}`),
+				},
+			},
+			wantReason: "helm release rolled back",
+		},
+		{
+			title: "RollbackToPrevious",
+			act:   module.ActionRequest{Name: RollbackAction, Params: []byte(`{}`)},
+			want: &resource.Resource{
+				Spec: resource.Spec{
+					Configs: []byte(`{"release_name":"demo-redis","chart":"redis","version":"16.0.0","namespace":"default","timeout":300}`),
+				},
+				State: resource.State{
+					Status:     resource.StatusPending,
+					Output:     res.State.Output,
+					ModuleData: []byte(`{"pending_steps":["release_rollback"],"rollback_to":1,` + releaseConfigs + `}`),
+				},
+			},
+			wantReason: "helm release rolled back",
+		},
+		{
+			title:   "RollbackToCurrent",
+			act:     module.ActionRequest{Name: RollbackAction, Params: []byte(`{"revision":2}`)},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title: "RollbackNotRecorded",
+			act:   module.ActionRequest{Name: RollbackAction, Params: []byte(`{"revision":1}`)},
+			modify: func(r *resource.Resource) {
+				r.State.ModuleData = []byte(`{"pending_steps":[]}`)
+			},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title: "Uninstall",
+			act:   module.ActionRequest{Name: module.DeleteAction},
+			want: &resource.Resource{
+				Spec: res.Spec,
+				State: resource.State{
+					Status:     resource.StatusDeleted,
+					Output:     res.State.Output,
+					ModuleData: []byte(`{"pending_steps":["release_uninstall"],` + releaseConfigs + `}`),
+				},
+			},
+			wantReason: "helm release uninstalled",
+		},
+		{
+			title:   "UnsupportedAction",
+			act:     module.ActionRequest{Name: "scale"},
+			wantErr: errors.ErrInvalid,
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			m := (&fakeHelm{validateErr: tt.validateErr}).module()
+
+			r := res
+			if tt.modify != nil {
+				tt.modify(&r)
+			}
+
+			got, err := m.Plan(context.Background(), module.ExpandedResource{Resource: r, Dependencies: deps}, tt.act)
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				assert.True(t, errors.Is(err, tt.wantErr))
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantReason, got.Reason)
+			assert.JSONEq(t, string(tt.want.Spec.Configs), string(got.Resource.Spec.Configs))
+			assert.Equal(t, tt.want.State.Status, got.Resource.State.Status)
+			assert.JSONEq(t, string(tt.want.State.Output), string(got.Resource.State.Output))
+			assert.JSONEq(t, string(tt.want.State.ModuleData), string(got.Resource.State.ModuleData))
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package helmrelease
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/modules/kubernetes"
+	"github.com/odpf/entropy/pkg/helm"
+)
+
+func (m *helmReleaseModule) Sync(_ context.Context, res module.ExpandedResource) (*resource.State, error) {
+	r := res.Resource
+
+	var data moduleData
+	if err := json.Unmarshal(r.State.ModuleData, &data); err != nil {
+		return nil, err
+	}
+
+	var pendingStep string
+	if len(data.PendingSteps) != 0 {
+		pendingStep = data.PendingSteps[0]
+		data.PendingSteps = data.PendingSteps[1:]
+	}
+
+	conf, err := readConfig(r, r.Spec.Configs)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeOut, err := kubernetes.ClusterOutput(res, keyKubeDependency)
+	if err != nil {
+		return nil, err
+	}
+
+	helmCl := m.helmClient(kubeOut.Configs)
+	hc := conf.helmReleaseConfig()
+
+	var rel *helm.Release
+	switch pendingStep {
+	case releaseInstall:
+		rel, err = helmCl.Create(hc)
+
+	case releaseUpgrade:
+		rel, err = helmCl.Update(hc)
+
+	case releaseRollback:
+		rel, err = helmCl.Rollback(hc, data.RollbackTo)
+
+	case releaseUninstall:
+		if err := helmCl.Delete(hc); err != nil {
+			return nil, err
+		}
+		return &resource.State{
+			Status:     resource.StatusCompleted,
+			Output:     r.State.Output,
+			ModuleData: data.JSON(),
+		}, nil
+
+	default:
+		// nothing to do.
+		return &resource.State{
+			Status:     resource.StatusCompleted,
+			Output:     r.State.Output,
+			ModuleData: data.JSON(),
+		}, nil
+	}
+
+	if rel != nil {
+		if rev := releaseOutput(*conf, rel).Revision; rev > 0 {
+			data.recordConfigs(rev, r.Spec.Configs)
+		}
+	}
+
+	if err != nil {
+		if rel == nil {
+			return nil, err
+		}
+
+		// the release was applied but did not become healthy. retrying the
+		// same step will not help, so the error is reported in the state.
+		return &resource.State{
+			Status:     resource.StatusError,
+			Output:     releaseOutput(*conf, rel).JSON(),
+			ModuleData: data.JSON(),
+		}, nil
+	}
+
+	finalStatus := resource.StatusCompleted
+	if len(data.PendingSteps) > 0 {
+		finalStatus = resource.StatusPending
+	}
+
+	return &resource.State{
+		Status:     finalStatus,
+		Output:     releaseOutput(*conf, rel).JSON(),
+		ModuleData: data.JSON(),
+	}, nil
+}
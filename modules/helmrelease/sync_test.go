@@ -0,0 +1,128 @@
+package helmrelease
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/helm"
+)
+
+func TestHelmReleaseModule_Sync(t *testing.T) {
+	t.Parallel()
+
+	rel := &helm.Release{
+		Output: helm.ReleaseOutput{
+			Status:  helm.StatusSuccess,
+			Release: `{"version":3,"chart":{"metadata":{"name":"redis","version":"17.0.0"}}}`,
+		},
+	}
+
+	newRes := func(moduleData string) module.ExpandedResource {
+		return module.ExpandedResource{
+			Resource: resource.Resource{
+				URN:     "orn:entropy:helm_release:demo:redis",
+				Kind:    "helm_release",
+				Name:    "redis",
+				Project: "demo",
+				Spec: resource.Spec{
+					Configs: []byte(`{"release_name":"demo-redis","chart":"redis","namespace":"default"}`),
+				},
+				State: resource.State{
+					Status:     resource.StatusPending,
+					Output:     []byte(`{"namespace":"default","release_name":"demo-redis"}`),
+					ModuleData: []byte(moduleData),
+				},
+			},
+			Dependencies: map[string]module.ResolvedDependency{
+				keyKubeDependency: {Kind: "kubernetes", Output: []byte(`{"configs":{}}`)},
+			},
+		}
+	}
+
+	table := []struct {
+		title      string
+		moduleData string
+		release    *helm.Release
+		helmErr    error
+		wantCalls  []string
+		wantRev    int
+		wantStatus string
+		wantOutput string
+		wantData   string
+		wantErr    error
+	}{
+		{
+			title:      "Install",
+			moduleData: `{"pending_steps":["release_install"]}`,
+			release:    rel,
+			wantCalls:  []string{"create"},
+			wantStatus: resource.StatusCompleted,
+			wantOutput: `{"namespace":"default","release_name":"demo-redis","chart":"redis","chart_version":"17.0.0","revision":3,"status":"success"}`,
+			wantData:   `{"pending_steps":[],"release_configs":{"3":{"release_name":"demo-redis","chart":"redis","namespace":"default"}}}`,
+		},
+		{
+			title:      "UpgradeFailed",
+			moduleData: `{"pending_steps":["release_upgrade"]}`,
+			helmErr:    errors.ErrInternal.WithMsgf("error while updating release"),
+			wantCalls:  []string{"update"},
+			wantErr:    errors.ErrInternal,
+		},
+		{
+			title:      "UpgradeUnhealthy",
+			moduleData: `{"pending_steps":["release_upgrade"]}`,
+			release: &helm.Release{
+				Output: helm.ReleaseOutput{Status: helm.StatusFailed, Release: `{"version":4}`},
+			},
+			helmErr:    errors.ErrInternal.WithMsgf("helm release updated with failure"),
+			wantCalls:  []string{"update"},
+			wantStatus: resource.StatusError,
+			wantOutput: `{"namespace":"default","release_name":"demo-redis","revision":4,"status":"failed"}`,
+			wantData:   `{"pending_steps":[],"release_configs":{"4":{"release_name":"demo-redis","chart":"redis","namespace":"default"}}}`,
+		},
+		{
+			title:      "Rollback",
+			moduleData: `{"pending_steps":["release_rollback"],"rollback_to":2}`,
+			release:    rel,
+			wantCalls:  []string{"rollback"},
+			wantRev:    2,
+			wantStatus: resource.StatusCompleted,
+			wantOutput: `{"namespace":"default","release_name":"demo-redis","chart":"redis","chart_version":"17.0.0","revision":3,"status":"success"}`,
+			wantData:   `{"pending_steps":[],"rollback_to":2,"release_configs":{"3":{"release_name":"demo-redis","chart":"redis","namespace":"default"}}}`,
+		},
+		{
+			title:      "Uninstall",
+			moduleData: `{"pending_steps":["release_uninstall"]}`,
+			wantCalls:  []string{"delete"},
+			wantStatus: resource.StatusCompleted,
+			wantOutput: `{"namespace":"default","release_name":"demo-redis"}`,
+			wantData:   `{"pending_steps":[]}`,
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			fh := &fakeHelm{release: tt.release, err: tt.helmErr}
+
+			got, err := fh.module().Sync(context.Background(), newRes(tt.moduleData))
+			assert.Equal(t, tt.wantCalls, fh.calls)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, got.Status)
+			assert.JSONEq(t, tt.wantOutput, string(got.Output))
+			assert.JSONEq(t, tt.wantData, string(got.ModuleData))
+			assert.Equal(t, tt.wantRev, fh.revision)
+		})
+	}
+}
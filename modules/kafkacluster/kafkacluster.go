@@ -0,0 +1,87 @@
+package kafkacluster
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+//go:embed config_schema.json
+var configSchema string
+
+var Module = module.Descriptor{
+	Kind: "kafka_cluster",
+	Actions: []module.ActionDesc{
+		{
+			Name:        module.CreateAction,
+			Description: "Registers a kafka cluster.",
+			ParamSchema: configSchema,
+		},
+		{
+			Name:        module.UpdateAction,
+			Description: "Updates the brokers of the kafka cluster.",
+			ParamSchema: configSchema,
+		},
+	},
+	DriverFactory: func(conf json.RawMessage) (module.Driver, error) {
+		return &clusterModule{}, nil
+	},
+}
+
+type clusterModule struct{}
+
+type Config struct {
+	Brokers []string `json:"brokers"`
+}
+
+type Output struct {
+	Brokers []string `json:"brokers"`
+}
+
+func (m *clusterModule) Plan(ctx context.Context, res module.ExpandedResource, act module.ActionRequest) (*module.Plan, error) {
+	res.Resource.Spec = resource.Spec{
+		Configs:      act.Params,
+		Dependencies: nil,
+	}
+
+	output, err := m.Output(ctx, res)
+	if err != nil {
+		return nil, err
+	}
+
+	res.Resource.State = resource.State{
+		Status: resource.StatusCompleted,
+		Output: output,
+	}
+	return &module.Plan{Resource: res.Resource, Reason: "kafka cluster details updated"}, nil
+}
+
+func (*clusterModule) Sync(_ context.Context, res module.ExpandedResource) (*resource.State, error) {
+	return &resource.State{
+		Status: resource.StatusCompleted,
+		Output: res.Resource.State.Output,
+	}, nil
+}
+
+func (*clusterModule) Output(_ context.Context, res module.ExpandedResource) (json.RawMessage, error) {
+	var conf Config
+	if err := json.Unmarshal(res.Spec.Configs, &conf); err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid json config value").WithCausef(err.Error())
+	} else if len(conf.Brokers) == 0 {
+		return nil, errors.ErrInvalid.WithMsgf("at least one broker must be set")
+	}
+
+	return Output{Brokers: conf.Brokers}.JSON(), nil
+}
+
+func (out Output) JSON() []byte {
+	b, err := json.Marshal(out)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
@@ -0,0 +1,78 @@
+package kafkatopic
+
+import (
+	"encoding/json"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/modules/kafkacluster"
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/kafka"
+)
+
+type moduleConfig struct {
+	Brokers           []string          `json:"brokers,omitempty"`
+	Name              string            `json:"name"`
+	Partitions        int               `json:"partitions"`
+	ReplicationFactor int               `json:"replication_factor"`
+	Configs           map[string]string `json:"configs,omitempty"`
+}
+
+func readConfig(r resource.Resource, confJSON json.RawMessage) (*moduleConfig, error) {
+	var conf moduleConfig
+	if err := json.Unmarshal(confJSON, &conf); err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid config json: %v", err)
+	}
+
+	if conf.Name == "" {
+		conf.Name = r.Name
+	}
+	return &conf, nil
+}
+
+func (mc moduleConfig) topicSpec() kafka.TopicSpec {
+	return kafka.TopicSpec{
+		Name:              mc.Name,
+		Partitions:        mc.Partitions,
+		ReplicationFactor: mc.ReplicationFactor,
+		Configs:           mc.Configs,
+	}
+}
+
+func (mc moduleConfig) JSON() []byte {
+	b, err := json.Marshal(mc)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// resolveBrokers returns the brokers from the config, or from the
+// kafka_cluster dependency if the resource has one.
+func resolveBrokers(conf moduleConfig, deps map[string]module.ResolvedDependency) ([]string, error) {
+	dep, hasCluster := deps[keyKafkaDependency]
+	switch {
+	case hasCluster && len(conf.Brokers) > 0:
+		return nil, errors.ErrInvalid.
+			WithMsgf("brokers must not be set when '%s' dependency is used", keyKafkaDependency)
+
+	case hasCluster:
+		if dep.Kind != kafkacluster.Module.Kind {
+			return nil, errors.ErrInvalid.
+				WithMsgf("value for '%s' must be of kind '%s', not '%s'", keyKafkaDependency, kafkacluster.Module.Kind, dep.Kind)
+		}
+
+		var out kafkacluster.Output
+		if err := json.Unmarshal(dep.Output, &out); err != nil {
+			return nil, errors.ErrInvalid.WithMsgf("invalid kafka_cluster output: %v", err)
+		}
+		return out.Brokers, nil
+
+	case len(conf.Brokers) > 0:
+		return conf.Brokers, nil
+
+	default:
+		return nil, errors.ErrInvalid.
+			WithMsgf("either brokers or a '%s' dependency must be set", keyKafkaDependency)
+	}
+}
@@ -0,0 +1,19 @@
+package kafkatopic
+
+import "encoding/json"
+
+type moduleData struct {
+	PendingSteps []string `json:"pending_steps"`
+
+	// RemovedConfigs are the configs removed by an update, which need to
+	// be reset to the defaults of the cluster.
+	RemovedConfigs []string `json:"removed_configs,omitempty"`
+}
+
+func (md moduleData) JSON() json.RawMessage {
+	bytes, err := json.Marshal(md)
+	if err != nil {
+		panic(err)
+	}
+	return bytes
+}
@@ -0,0 +1,63 @@
+package kafkatopic
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/pkg/kafka"
+)
+
+const (
+	stepCreate = "topic_create"
+	stepUpdate = "topic_update"
+	stepDelete = "topic_delete"
+)
+
+// keyKafkaDependency is the optional dependency to a kafka_cluster resource
+// that provides the brokers.
+const keyKafkaDependency = "kafka_cluster"
+
+//go:embed schema/config.json
+var configSchema string
+
+var Module = module.Descriptor{
+	Kind: "kafka_topic",
+	Actions: []module.ActionDesc{
+		{
+			Name:        module.CreateAction,
+			Description: "Creates the kafka topic.",
+			ParamSchema: configSchema,
+		},
+		{
+			Name:        module.UpdateAction,
+			Description: "Increases partitions and updates configs of the kafka topic.",
+			ParamSchema: configSchema,
+		},
+		{
+			Name:        module.DeleteAction,
+			Description: "Deletes the kafka topic.",
+		},
+	},
+	DriverFactory: func(conf json.RawMessage) (module.Driver, error) {
+		return &topicModule{
+			admin: func(brokers []string) topicAdmin {
+				return kafka.NewAdmin(brokers)
+			},
+		}, nil
+	},
+}
+
+// topicAdmin is the subset of kafka.Admin used by the module.
+type topicAdmin interface {
+	GetTopic(ctx context.Context, name string) (*kafka.TopicInfo, error)
+	CreateTopic(ctx context.Context, spec kafka.TopicSpec) error
+	SetPartitions(ctx context.Context, name string, count int) error
+	AlterTopicConfigs(ctx context.Context, name string, set map[string]string, remove []string) error
+	DeleteTopic(ctx context.Context, name string) error
+}
+
+type topicModule struct {
+	admin func(brokers []string) topicAdmin
+}
@@ -0,0 +1,60 @@
+package kafkatopic
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/kafka"
+)
+
+type Output struct {
+	Name              string            `json:"name"`
+	Brokers           []string          `json:"brokers,omitempty"`
+	Partitions        int               `json:"partitions,omitempty"`
+	ReplicationFactor int               `json:"replication_factor,omitempty"`
+	Configs           map[string]string `json:"configs,omitempty"`
+}
+
+func (out Output) JSON() []byte {
+	b, err := json.Marshal(out)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (out *Output) setTopicInfo(info kafka.TopicInfo) {
+	out.Partitions = info.Partitions
+	out.ReplicationFactor = info.ReplicationFactor
+	out.Configs = info.Configs
+}
+
+func (m *topicModule) Output(ctx context.Context, res module.ExpandedResource) (json.RawMessage, error) {
+	r := res.Resource
+	if r.State.Status != resource.StatusCompleted {
+		return r.State.Output, nil
+	}
+
+	conf, err := readConfig(r, r.Spec.Configs)
+	if err != nil {
+		return nil, err
+	}
+
+	brokers, err := resolveBrokers(*conf, res.Dependencies)
+	if err != nil {
+		return nil, err
+	}
+
+	return module.LiveOutput(ctx, res, func(ctx context.Context) (json.RawMessage, error) {
+		info, err := m.admin(brokers).GetTopic(ctx, conf.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		output := Output{Name: conf.Name, Brokers: brokers}
+		output.setTopicInfo(*info)
+		return output.JSON(), nil
+	}), nil
+}
@@ -0,0 +1,68 @@
+package kafkatopic
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/kafka"
+	"github.com/odpf/entropy/pkg/kafka/kafkatest"
+)
+
+func TestTopicModule_Output(t *testing.T) {
+	t.Parallel()
+
+	newRes := func(status string) module.ExpandedResource {
+		var res module.ExpandedResource
+		res.Resource.Spec.Configs = []byte(`{"brokers":["localhost:9092"],"name":"orders","partitions":3,"replication_factor":2}`)
+		res.Resource.State = resource.State{
+			Status: status,
+			Output: []byte(`{"name":"orders","brokers":["localhost:9092"],"partitions":3,"replication_factor":2}`),
+		}
+		return res
+	}
+
+	table := []struct {
+		title   string
+		cluster *kafkatest.Cluster
+		res     module.ExpandedResource
+		want    string
+	}{
+		{
+			title:   "Live",
+			cluster: kafkatest.NewCluster(kafka.TopicInfo{Name: "orders", Partitions: 6, ReplicationFactor: 2}),
+			res:     newRes(resource.StatusCompleted),
+			want:    `{"name":"orders","brokers":["localhost:9092"],"partitions":6,"replication_factor":2}`,
+		},
+		{
+			title:   "Pending",
+			cluster: kafkatest.NewCluster(kafka.TopicInfo{Name: "orders", Partitions: 6, ReplicationFactor: 2}),
+			res:     newRes(resource.StatusPending),
+			want:    `{"name":"orders","brokers":["localhost:9092"],"partitions":3,"replication_factor":2}`,
+		},
+		{
+			title:   "ClusterUnreachable",
+			cluster: &kafkatest.Cluster{Err: assert.AnError},
+			res:     newRes(resource.StatusCompleted),
+			want:    `{"name":"orders","brokers":["localhost:9092"],"partitions":3,"replication_factor":2}`,
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			m := &topicModule{
+				admin: func(_ []string) topicAdmin { return tt.cluster },
+			}
+
+			got, err := m.Output(context.Background(), tt.res)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package kafkatopic
+
+import (
+	"context"
+	"sort"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+func (m *topicModule) Plan(_ context.Context, res module.ExpandedResource, act module.ActionRequest) (*module.Plan, error) {
+	switch act.Name {
+	case module.CreateAction:
+		return m.planCreate(res, act)
+	case module.UpdateAction:
+		return m.planUpdate(res, act)
+	case module.DeleteAction:
+		return m.planDelete(res)
+	default:
+		return nil, errors.ErrInvalid.WithMsgf("action '%s' not supported", act.Name)
+	}
+}
+
+func (*topicModule) planCreate(res module.ExpandedResource, act module.ActionRequest) (*module.Plan, error) {
+	r := res.Resource
+
+	conf, err := readConfig(r, act.Params)
+	if err != nil {
+		return nil, err
+	} else if _, err := resolveBrokers(*conf, res.Dependencies); err != nil {
+		return nil, err
+	}
+
+	r.Spec.Configs = conf.JSON()
+	r.State = resource.State{
+		Status: resource.StatusPending,
+		Output: Output{Name: conf.Name}.JSON(),
+		ModuleData: moduleData{
+			PendingSteps: []string{stepCreate},
+		}.JSON(),
+	}
+	return &module.Plan{Resource: r, Reason: "kafka topic created"}, nil
+}
+
+func (*topicModule) planUpdate(res module.ExpandedResource, act module.ActionRequest) (*module.Plan, error) {
+	r := res.Resource
+
+	curConf, err := readConfig(r, r.Spec.Configs)
+	if err != nil {
+		return nil, err
+	}
+
+	conf, err := readConfig(r, act.Params)
+	if err != nil {
+		return nil, err
+	} else if _, err := resolveBrokers(*conf, res.Dependencies); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case conf.Name != curConf.Name:
+		return nil, errors.ErrInvalid.WithMsgf("name of a kafka topic cannot be changed")
+
+	case conf.ReplicationFactor != curConf.ReplicationFactor:
+		return nil, errors.ErrInvalid.WithMsgf("replication_factor of a kafka topic cannot be changed")
+
+	case conf.Partitions < curConf.Partitions:
+		return nil, errors.ErrInvalid.
+			WithMsgf("partitions cannot be decreased (current: %d)", curConf.Partitions)
+	}
+
+	var removed []string
+	for key := range curConf.Configs {
+		if _, found := conf.Configs[key]; !found {
+			removed = append(removed, key)
+		}
+	}
+	sort.Strings(removed)
+
+	r.Spec.Configs = conf.JSON()
+	r.State = resource.State{
+		Status: resource.StatusPending,
+		Output: r.State.Output,
+		ModuleData: moduleData{
+			PendingSteps:   []string{stepUpdate},
+			RemovedConfigs: removed,
+		}.JSON(),
+	}
+	return &module.Plan{Resource: r, Reason: "kafka topic updated"}, nil
+}
+
+func (*topicModule) planDelete(res module.ExpandedResource) (*module.Plan, error) {
+	r := res.Resource
+	r.State = resource.State{
+		Status: resource.StatusDeleted,
+		Output: r.State.Output,
+		ModuleData: moduleData{
+			PendingSteps: []string{stepDelete},
+		}.JSON(),
+	}
+	return &module.Plan{Resource: r, Reason: "kafka topic deleted"}, nil
+}
@@ -0,0 +1,112 @@
+package kafkatopic
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+func TestTopicModule_Plan(t *testing.T) {
+	t.Parallel()
+
+	res := resource.Resource{
+		URN:     "orn:entropy:kafka_topic:demo:orders",
+		Kind:    "kafka_topic",
+		Name:    "orders",
+		Project: "demo",
+		Spec: resource.Spec{
+			Configs: []byte(`{"brokers":["localhost:9092"],"name":"orders","partitions":3,"replication_factor":2,"configs":{"retention.ms":"3600000"}}`),
+		},
+		State: resource.State{
+			Status: resource.StatusCompleted,
+			Output: []byte(`{"name":"orders"}`),
+		},
+	}
+	clusterDep := map[string]module.ResolvedDependency{
+		keyKafkaDependency: {Kind: "kafka_cluster", Output: []byte(`{"brokers":["kafka:9092"]}`)},
+	}
+
+	table := []struct {
+		title      string
+		deps       map[string]module.ResolvedDependency
+		act        module.ActionRequest
+		wantConfig string
+		wantStatus string
+		wantData   string
+		wantErr    error
+	}{
+		{
+			title:   "InvalidConfiguration",
+			act:     module.ActionRequest{Name: module.CreateAction, Params: []byte(`{`)},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title:   "NoBrokers",
+			act:     module.ActionRequest{Name: module.CreateAction, Params: []byte(`{"partitions":3,"replication_factor":2}`)},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title:   "BrokersAndClusterDependency",
+			deps:    clusterDep,
+			act:     module.ActionRequest{Name: module.CreateAction, Params: []byte(`{"brokers":["localhost:9092"],"partitions":3,"replication_factor":2}`)},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title:      "CreateWithClusterDependency",
+			deps:       clusterDep,
+			act:        module.ActionRequest{Name: module.CreateAction, Params: []byte(`{"partitions":3,"replication_factor":2}`)},
+			wantConfig: `{"name":"orders","partitions":3,"replication_factor":2}`,
+			wantStatus: resource.StatusPending,
+			wantData:   `{"pending_steps":["topic_create"]}`,
+		},
+		{
+			title:   "DecreasePartitions",
+			act:     module.ActionRequest{Name: module.UpdateAction, Params: []byte(`{"brokers":["localhost:9092"],"partitions":2,"replication_factor":2}`)},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title:   "ChangeReplicationFactor",
+			act:     module.ActionRequest{Name: module.UpdateAction, Params: []byte(`{"brokers":["localhost:9092"],"partitions":3,"replication_factor":3}`)},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title:      "Update",
+			act:        module.ActionRequest{Name: module.UpdateAction, Params: []byte(`{"brokers":["localhost:9092"],"partitions":6,"replication_factor":2,"configs":{"cleanup.policy":"compact"}}`)},
+			wantConfig: `{"brokers":["localhost:9092"],"name":"orders","partitions":6,"replication_factor":2,"configs":{"cleanup.policy":"compact"}}`,
+			wantStatus: resource.StatusPending,
+			wantData:   `{"pending_steps":["topic_update"],"removed_configs":["retention.ms"]}`,
+		},
+		{
+			title:      "Delete",
+			act:        module.ActionRequest{Name: module.DeleteAction},
+			wantStatus: resource.StatusDeleted,
+			wantData:   `{"pending_steps":["topic_delete"]}`,
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := (&topicModule{}).Plan(context.Background(), module.ExpandedResource{Resource: res, Dependencies: tt.deps}, tt.act)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, got.Resource.State.Status)
+			assert.JSONEq(t, tt.wantData, string(got.Resource.State.ModuleData))
+			if tt.wantConfig != "" {
+				assert.JSONEq(t, tt.wantConfig, string(got.Resource.Spec.Configs))
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package kafkatopic
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/kafka"
+	"github.com/odpf/entropy/pkg/worker"
+)
+
+const networkErrorRetryDuration = 5 * time.Second
+
+var ErrNetwork = worker.RetryableError{RetryAfter: networkErrorRetryDuration}
+
+func (m *topicModule) Sync(ctx context.Context, res module.ExpandedResource) (*resource.State, error) {
+	r := res.Resource
+
+	var data moduleData
+	if err := json.Unmarshal(r.State.ModuleData, &data); err != nil {
+		return nil, err
+	}
+
+	var pendingStep string
+	if len(data.PendingSteps) != 0 {
+		pendingStep = data.PendingSteps[0]
+		data.PendingSteps = data.PendingSteps[1:]
+	}
+
+	conf, err := readConfig(r, r.Spec.Configs)
+	if err != nil {
+		return nil, err
+	}
+
+	brokers, err := resolveBrokers(*conf, res.Dependencies)
+	if err != nil {
+		return nil, err
+	}
+	admin := m.admin(brokers)
+
+	switch pendingStep {
+	case stepCreate:
+		err = m.createTopic(ctx, admin, *conf)
+
+	case stepUpdate:
+		err = m.updateTopic(ctx, admin, *conf, data.RemovedConfigs)
+		if err == nil {
+			data.RemovedConfigs = nil
+		}
+
+	case stepDelete:
+		if err := admin.DeleteTopic(ctx, conf.Name); err != nil {
+			return nil, ErrNetwork.WithCause(err)
+		}
+		return &resource.State{
+			Status:     resource.StatusCompleted,
+			Output:     r.State.Output,
+			ModuleData: data.JSON(),
+		}, nil
+	}
+
+	if err != nil {
+		if errors.Is(err, errors.ErrInvalid) || errors.Is(err, errors.ErrConflict) {
+			// the cluster rejected the request. retrying will not help until
+			// the config is fixed.
+			return &resource.State{
+				Status:     resource.StatusError,
+				Output:     r.State.Output,
+				ModuleData: data.JSON(),
+			}, nil
+		}
+		return nil, ErrNetwork.WithCause(err)
+	}
+
+	output := Output{Name: conf.Name, Brokers: brokers}
+	if info, err := admin.GetTopic(ctx, conf.Name); err == nil {
+		output.setTopicInfo(*info)
+	}
+
+	finalStatus := resource.StatusCompleted
+	if len(data.PendingSteps) > 0 {
+		finalStatus = resource.StatusPending
+	}
+
+	return &resource.State{
+		Status:     finalStatus,
+		Output:     output.JSON(),
+		ModuleData: data.JSON(),
+	}, nil
+}
+
+func (*topicModule) createTopic(ctx context.Context, admin topicAdmin, conf moduleConfig) error {
+	err := admin.CreateTopic(ctx, conf.topicSpec())
+	if !errors.Is(err, kafka.ErrTopicExists) {
+		return err
+	}
+
+	// topic may have been created by an earlier attempt of this step. it
+	// is taken over only if it matches the spec.
+	info, getErr := admin.GetTopic(ctx, conf.Name)
+	if getErr != nil {
+		return getErr
+	} else if info.Partitions != conf.Partitions || info.ReplicationFactor != conf.ReplicationFactor {
+		return err
+	}
+	return admin.AlterTopicConfigs(ctx, conf.Name, conf.Configs, nil)
+}
+
+func (*topicModule) updateTopic(ctx context.Context, admin topicAdmin, conf moduleConfig, removedConfigs []string) error {
+	info, err := admin.GetTopic(ctx, conf.Name)
+	if err != nil {
+		if errors.Is(err, kafka.ErrTopicNotFound) {
+			return errors.ErrInvalid.WithMsgf("topic '%s' does not exist", conf.Name)
+		}
+		return err
+	}
+
+	if conf.Partitions > info.Partitions {
+		if err := admin.SetPartitions(ctx, conf.Name, conf.Partitions); err != nil {
+			return err
+		}
+	}
+
+	return admin.AlterTopicConfigs(ctx, conf.Name, conf.Configs, removedConfigs)
+}
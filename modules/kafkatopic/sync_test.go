@@ -0,0 +1,128 @@
+package kafkatopic
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/kafka"
+	"github.com/odpf/entropy/pkg/kafka/kafkatest"
+	"github.com/odpf/entropy/pkg/worker"
+)
+
+func TestTopicModule_Sync(t *testing.T) {
+	t.Parallel()
+
+	existing := kafka.TopicInfo{
+		Name:              "orders",
+		Partitions:        3,
+		ReplicationFactor: 2,
+		Configs:           map[string]string{"retention.ms": "3600000"},
+	}
+
+	newRes := func(configs, moduleData string) module.ExpandedResource {
+		return module.ExpandedResource{
+			Resource: resource.Resource{
+				URN:     "orn:entropy:kafka_topic:demo:orders",
+				Kind:    "kafka_topic",
+				Name:    "orders",
+				Project: "demo",
+				Spec:    resource.Spec{Configs: []byte(configs)},
+				State: resource.State{
+					Status:     resource.StatusPending,
+					Output:     []byte(`{"name":"orders"}`),
+					ModuleData: []byte(moduleData),
+				},
+			},
+		}
+	}
+
+	table := []struct {
+		title      string
+		cluster    *kafkatest.Cluster
+		res        module.ExpandedResource
+		wantStatus string
+		wantOutput string
+		wantTopic  *kafka.TopicInfo
+		wantErr    bool
+	}{
+		{
+			title:   "Create",
+			cluster: kafkatest.NewCluster(),
+			res: newRes(`{"brokers":["localhost:9092"],"name":"orders","partitions":3,"replication_factor":2,"configs":{"retention.ms":"3600000"}}`,
+				`{"pending_steps":["topic_create"]}`),
+			wantStatus: resource.StatusCompleted,
+			wantOutput: `{"name":"orders","brokers":["localhost:9092"],"partitions":3,"replication_factor":2,"configs":{"retention.ms":"3600000"}}`,
+			wantTopic:  &existing,
+		},
+		{
+			title:   "CreateAlreadyExistsWithDifferentSpec",
+			cluster: kafkatest.NewCluster(existing),
+			res: newRes(`{"brokers":["localhost:9092"],"name":"orders","partitions":12,"replication_factor":2}`,
+				`{"pending_steps":["topic_create"]}`),
+			wantStatus: resource.StatusError,
+			wantOutput: `{"name":"orders"}`,
+			wantTopic:  &existing,
+		},
+		{
+			title:   "Update",
+			cluster: kafkatest.NewCluster(existing),
+			res: newRes(`{"brokers":["localhost:9092"],"name":"orders","partitions":6,"replication_factor":2,"configs":{"cleanup.policy":"compact"}}`,
+				`{"pending_steps":["topic_update"],"removed_configs":["retention.ms"]}`),
+			wantStatus: resource.StatusCompleted,
+			wantOutput: `{"name":"orders","brokers":["localhost:9092"],"partitions":6,"replication_factor":2,"configs":{"cleanup.policy":"compact"}}`,
+			wantTopic: &kafka.TopicInfo{
+				Name:              "orders",
+				Partitions:        6,
+				ReplicationFactor: 2,
+				Configs:           map[string]string{"cleanup.policy": "compact"},
+			},
+		},
+		{
+			title:   "Delete",
+			cluster: kafkatest.NewCluster(existing),
+			res: newRes(`{"brokers":["localhost:9092"],"name":"orders","partitions":3,"replication_factor":2}`,
+				`{"pending_steps":["topic_delete"]}`),
+			wantStatus: resource.StatusCompleted,
+			wantOutput: `{"name":"orders"}`,
+		},
+		{
+			title:   "ClusterUnreachable",
+			cluster: &kafkatest.Cluster{Err: errors.New("connection refused")},
+			res: newRes(`{"brokers":["localhost:9092"],"name":"orders","partitions":3,"replication_factor":2}`,
+				`{"pending_steps":["topic_create"]}`),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			m := &topicModule{
+				admin: func(_ []string) topicAdmin { return tt.cluster },
+			}
+
+			got, err := m.Sync(context.Background(), tt.res)
+			if tt.wantErr {
+				var retryErr *worker.RetryableError
+				assert.ErrorAs(t, err, &retryErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, got.Status)
+			assert.JSONEq(t, tt.wantOutput, string(got.Output))
+			if tt.wantTopic != nil {
+				assert.Equal(t, tt.wantTopic, tt.cluster.Topics["orders"])
+			} else {
+				assert.Empty(t, tt.cluster.Topics)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package kubecronjob
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/robfig/cron/v3"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/kube"
+)
+
+const (
+	defaultNamespace         = "default"
+	defaultConcurrencyPolicy = "Allow"
+	labelManagedBy           = "app.kubernetes.io/managed-by"
+	managedBy                = "entropy"
+
+	// maxNameLength is the maximum length of a cron job name, as the
+	// controller appends a suffix of 11 characters to the names of jobs.
+	maxNameLength = 52
+)
+
+var (
+	invalidNameChars    = regexp.MustCompile(`[^a-z0-9-]+`)
+	concurrencyPolicies = []string{"Allow", "Forbid", "Replace"}
+)
+
+type moduleConfig struct {
+	Namespace       string            `json:"namespace"`
+	Schedule        string            `json:"schedule"`
+	Image           string            `json:"image"`
+	Command         []string          `json:"command,omitempty"`
+	Args            []string          `json:"args,omitempty"`
+	Env             map[string]string `json:"env,omitempty"`
+	Retries         int32             `json:"retries"`
+	DeadlineSeconds int64             `json:"deadline_seconds,omitempty"`
+
+	ConcurrencyPolicy          string `json:"concurrency_policy"`
+	SuccessfulJobsHistoryLimit *int32 `json:"successful_jobs_history_limit"`
+	FailedJobsHistoryLimit     *int32 `json:"failed_jobs_history_limit"`
+}
+
+func readConfig(confJSON json.RawMessage) (*moduleConfig, error) {
+	var conf moduleConfig
+	if err := json.Unmarshal(confJSON, &conf); err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid config json: %v", err)
+	}
+
+	// defaults are same as that of kubernetes.
+	if conf.Namespace == "" {
+		conf.Namespace = defaultNamespace
+	}
+	if conf.ConcurrencyPolicy == "" {
+		conf.ConcurrencyPolicy = defaultConcurrencyPolicy
+	}
+	if conf.SuccessfulJobsHistoryLimit == nil {
+		limit := int32(3)
+		conf.SuccessfulJobsHistoryLimit = &limit
+	}
+	if conf.FailedJobsHistoryLimit == nil {
+		limit := int32(1)
+		conf.FailedJobsHistoryLimit = &limit
+	}
+	return &conf, nil
+}
+
+func (mc moduleConfig) validate() error {
+	if _, err := cron.ParseStandard(mc.Schedule); err != nil {
+		return errors.ErrInvalid.WithMsgf("invalid schedule '%s': %v", mc.Schedule, err)
+	}
+
+	if strings.TrimSpace(mc.Image) == "" {
+		return errors.ErrInvalid.WithMsgf("image must be set")
+	}
+
+	for name := range mc.Env {
+		if errs := validation.IsEnvVarName(name); len(errs) > 0 {
+			return errors.ErrInvalid.WithMsgf("invalid env variable name '%s': %s", name, strings.Join(errs, ", "))
+		}
+	}
+
+	isValidPolicy := false
+	for _, policy := range concurrencyPolicies {
+		isValidPolicy = isValidPolicy || mc.ConcurrencyPolicy == policy
+	}
+	if !isValidPolicy {
+		return errors.ErrInvalid.WithMsgf("concurrency_policy must be one of %s", strings.Join(concurrencyPolicies, ", "))
+	}
+	return nil
+}
+
+func (mc moduleConfig) cronJobSpec(name string, suspend bool) kube.CronJobSpec {
+	return kube.CronJobSpec{
+		JobSpec: kube.JobSpec{
+			Name:      name,
+			Namespace: mc.Namespace,
+			Labels: map[string]string{
+				"app":          name,
+				labelManagedBy: managedBy,
+			},
+			Image:           mc.Image,
+			Command:         mc.Command,
+			Args:            mc.Args,
+			Env:             mc.Env,
+			Retries:         mc.Retries,
+			DeadlineSeconds: mc.DeadlineSeconds,
+		},
+		Schedule:                   mc.Schedule,
+		ConcurrencyPolicy:          mc.ConcurrencyPolicy,
+		SuccessfulJobsHistoryLimit: *mc.SuccessfulJobsHistoryLimit,
+		FailedJobsHistoryLimit:     *mc.FailedJobsHistoryLimit,
+		Suspend:                    suspend,
+	}
+}
+
+func (mc moduleConfig) JSON() []byte {
+	b, err := json.Marshal(mc)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// cronJobName returns the name of the kubernetes cron job of the resource,
+// i.e., '<project>-<name>' turned into a valid name.
+func cronJobName(r resource.Resource) string {
+	name := invalidNameChars.ReplaceAllString(strings.ToLower(fmt.Sprintf("%s-%s", r.Project, r.Name)), "-")
+	if len(name) > maxNameLength {
+		name = name[:maxNameLength]
+	}
+	return strings.Trim(name, "-")
+}
+
+// triggeredJobName returns the name of the job created by the given
+// trigger of the cron job.
+func triggeredJobName(cronJobName string, trigger int) string {
+	suffix := fmt.Sprintf("-manual-%d", trigger)
+	if maxLen := validation.DNS1123LabelMaxLength - len(suffix); len(cronJobName) > maxLen {
+		cronJobName = strings.Trim(cronJobName[:maxLen], "-")
+	}
+	return cronJobName + suffix
+}
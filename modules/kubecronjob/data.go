@@ -0,0 +1,36 @@
+package kubecronjob
+
+import "encoding/json"
+
+type moduleData struct {
+	PendingSteps []string `json:"pending_steps"`
+
+	// Name & Namespace identify the kubernetes cron job, once applied.
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+
+	Suspended bool `json:"suspended"`
+
+	// Triggers is the number of times the job has been run using the
+	// trigger action, including the pending one.
+	Triggers int `json:"triggers"`
+}
+
+func (md moduleData) JSON() json.RawMessage {
+	bytes, err := json.Marshal(md)
+	if err != nil {
+		panic(err)
+	}
+	return bytes
+}
+
+func readModuleData(data json.RawMessage) (*moduleData, error) {
+	var md moduleData
+	if len(data) == 0 {
+		return &md, nil
+	}
+	if err := json.Unmarshal(data, &md); err != nil {
+		return nil, err
+	}
+	return &md, nil
+}
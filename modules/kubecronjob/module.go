@@ -0,0 +1,83 @@
+package kubecronjob
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/modules/kubernetes"
+	"github.com/odpf/entropy/pkg/kube"
+)
+
+const (
+	SuspendAction = "suspend"
+	ResumeAction  = "resume"
+	TriggerAction = "trigger"
+)
+
+const (
+	stepApply   = "cronjob_apply"
+	stepTrigger = "cronjob_trigger"
+	stepDelete  = "cronjob_delete"
+)
+
+const keyKubeDependency = "kube_cluster"
+
+//go:embed schema/config.json
+var configSchema string
+
+var Module = module.Descriptor{
+	Kind: "kube_cronjob",
+	Dependencies: map[string]string{
+		keyKubeDependency: kubernetes.Module.Kind,
+	},
+	Actions: []module.ActionDesc{
+		{
+			Name:        module.CreateAction,
+			Description: "Creates the cron job.",
+			ParamSchema: configSchema,
+		},
+		{
+			Name:        module.UpdateAction,
+			Description: "Updates the cron job.",
+			ParamSchema: configSchema,
+		},
+		{
+			Name:        SuspendAction,
+			Description: "Suspends the cron job. No new jobs are scheduled until it is resumed.",
+		},
+		{
+			Name:        ResumeAction,
+			Description: "Resumes the suspended cron job.",
+		},
+		{
+			Name:        TriggerAction,
+			Description: "Runs the job immediately, irrespective of the schedule.",
+		},
+		{
+			Name:        module.DeleteAction,
+			Description: "Deletes the cron job along with its jobs.",
+		},
+	},
+	DriverFactory: func(conf json.RawMessage) (module.Driver, error) {
+		return &cronJobModule{
+			kubeClient: func(kubeConf kube.Config) kubeClient {
+				return kube.NewClient(kubeConf)
+			},
+		}, nil
+	},
+}
+
+// kubeClient is the subset of kube.Client used by the module.
+type kubeClient interface {
+	ApplyCronJob(ctx context.Context, spec kube.CronJobSpec) error
+	TriggerCronJob(ctx context.Context, namespace, name, jobName string) error
+	GetCronJobStatus(ctx context.Context, namespace, name string) (*kube.CronJobStatus, error)
+	DeleteCronJob(ctx context.Context, namespace, name string) error
+	StreamLogs(ctx context.Context, namespace string, filter map[string]string) (<-chan kube.LogChunk, error)
+}
+
+type cronJobModule struct {
+	kubeClient func(kubeConf kube.Config) kubeClient
+}
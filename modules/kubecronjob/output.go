@@ -0,0 +1,45 @@
+package kubecronjob
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/modules/kubernetes"
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/kube"
+)
+
+type Output struct {
+	CronJob *kube.CronJobStatus `json:"cron_job,omitempty"`
+}
+
+func (out Output) JSON() []byte {
+	b, err := json.Marshal(out)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (m *cronJobModule) Output(ctx context.Context, res module.ExpandedResource) (json.RawMessage, error) {
+	data, err := readModuleData(res.Resource.State.ModuleData)
+	if err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid module data: %v", err)
+	} else if data.Name == "" {
+		return res.Resource.State.Output, nil
+	}
+
+	return module.LiveOutput(ctx, res, func(ctx context.Context) (json.RawMessage, error) {
+		kubeOut, err := kubernetes.ClusterOutput(res, keyKubeDependency)
+		if err != nil {
+			return nil, err
+		}
+
+		status, err := m.kubeClient(kubeOut.Configs).GetCronJobStatus(ctx, data.Namespace, data.Name)
+		if err != nil {
+			return nil, err
+		}
+		return Output{CronJob: status}.JSON(), nil
+	}), nil
+}
@@ -0,0 +1,56 @@
+package kubecronjob
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/pkg/kube"
+	"github.com/odpf/entropy/pkg/kube/kubetest"
+)
+
+func TestCronJobModule_Output(t *testing.T) {
+	t.Parallel()
+
+	res := module.ExpandedResource{
+		Dependencies: map[string]module.ResolvedDependency{
+			keyKubeDependency: {Kind: "kubernetes", Output: []byte(`{"configs":{}}`)},
+		},
+	}
+	res.Resource.State.Output = []byte(`{"cron_job":{"name":"demo-report","namespace":"jobs","schedule":"0 * * * *","suspended":false,"runs":[]}}`)
+	res.Resource.State.ModuleData = []byte(`{"name":"demo-report","namespace":"jobs","suspended":true}`)
+
+	table := []struct {
+		title   string
+		cluster *kubetest.Cluster
+		want    string
+	}{
+		{
+			title:   "Live",
+			cluster: &kubetest.Cluster{CronJobs: map[string]kube.CronJobSpec{"jobs/demo-report": {Schedule: "0 * * * *", Suspend: true}}},
+			want:    `{"cron_job":{"name":"demo-report","namespace":"jobs","schedule":"0 * * * *","suspended":true,"runs":[]}}`,
+		},
+		{
+			title:   "ClusterUnreachable",
+			cluster: &kubetest.Cluster{Err: assert.AnError},
+			want:    `{"cron_job":{"name":"demo-report","namespace":"jobs","schedule":"0 * * * *","suspended":false,"runs":[]}}`,
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			m := &cronJobModule{
+				kubeClient: func(_ kube.Config) kubeClient { return tt.cluster },
+			}
+
+			got, err := m.Output(context.Background(), res)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
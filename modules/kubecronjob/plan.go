@@ -0,0 +1,108 @@
+package kubecronjob
+
+import (
+	"context"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+func (m *cronJobModule) Plan(_ context.Context, res module.ExpandedResource, act module.ActionRequest) (*module.Plan, error) {
+	switch act.Name {
+	case module.CreateAction:
+		return m.planCreate(res, act)
+	case module.DeleteAction:
+		return m.planDelete(res)
+	default:
+		return m.planChange(res, act)
+	}
+}
+
+func (*cronJobModule) planCreate(res module.ExpandedResource, act module.ActionRequest) (*module.Plan, error) {
+	r := res.Resource
+
+	conf, err := readConfig(act.Params)
+	if err != nil {
+		return nil, err
+	} else if err := conf.validate(); err != nil {
+		return nil, err
+	}
+
+	r.Spec.Configs = conf.JSON()
+	r.State = resource.State{
+		Status: resource.StatusPending,
+		ModuleData: moduleData{
+			PendingSteps: []string{stepApply},
+		}.JSON(),
+	}
+	return &module.Plan{Resource: r, Reason: "kube cronjob created"}, nil
+}
+
+func (*cronJobModule) planChange(res module.ExpandedResource, act module.ActionRequest) (*module.Plan, error) {
+	r := res.Resource
+
+	data, err := readModuleData(r.State.ModuleData)
+	if err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid module data: %v", err)
+	}
+
+	var reason string
+	switch act.Name {
+	case module.UpdateAction:
+		conf, err := readConfig(act.Params)
+		if err != nil {
+			return nil, err
+		} else if err := conf.validate(); err != nil {
+			return nil, err
+		}
+		r.Spec.Configs = conf.JSON()
+		data.PendingSteps = []string{stepApply}
+		reason = "kube cronjob updated"
+
+	case SuspendAction:
+		data.Suspended = true
+		data.PendingSteps = []string{stepApply}
+		reason = "kube cronjob suspended"
+
+	case ResumeAction:
+		data.Suspended = false
+		data.PendingSteps = []string{stepApply}
+		reason = "kube cronjob resumed"
+
+	case TriggerAction:
+		if data.Name == "" {
+			return nil, errors.ErrInvalid.WithMsgf("cron job has not been applied yet")
+		}
+		data.Triggers++
+		data.PendingSteps = []string{stepTrigger}
+		reason = "kube cronjob triggered"
+
+	default:
+		return nil, errors.ErrInvalid.WithMsgf("action '%s' not supported", act.Name)
+	}
+
+	r.State = resource.State{
+		Status:     resource.StatusPending,
+		Output:     r.State.Output,
+		ModuleData: data.JSON(),
+	}
+	return &module.Plan{Resource: r, Reason: reason}, nil
+}
+
+func (*cronJobModule) planDelete(res module.ExpandedResource) (*module.Plan, error) {
+	r := res.Resource
+
+	data, err := readModuleData(r.State.ModuleData)
+	if err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid module data: %v", err)
+	}
+	data.PendingSteps = []string{stepDelete}
+
+	r.State = resource.State{
+		Status:     resource.StatusDeleted,
+		Output:     r.State.Output,
+		ModuleData: data.JSON(),
+	}
+	return &module.Plan{Resource: r, Reason: "kube cronjob deleted"}, nil
+}
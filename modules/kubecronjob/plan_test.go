@@ -0,0 +1,131 @@
+package kubecronjob
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+func TestCronJobModule_Plan(t *testing.T) {
+	t.Parallel()
+
+	res := resource.Resource{
+		URN:     "orn:entropy:kube_cronjob:demo:report",
+		Kind:    "kube_cronjob",
+		Name:    "report",
+		Project: "demo",
+		Spec: resource.Spec{
+			Configs: []byte(`{"namespace":"jobs","schedule":"0 * * * *","image":"busybox","retries":0,"concurrency_policy":"Forbid","successful_jobs_history_limit":3,"failed_jobs_history_limit":1}`),
+		},
+		State: resource.State{
+			Status:     resource.StatusCompleted,
+			Output:     []byte(`{"cron_job":{"name":"demo-report","namespace":"jobs","schedule":"0 * * * *","suspended":false,"runs":[]}}`),
+			ModuleData: []byte(`{"pending_steps":[],"name":"demo-report","namespace":"jobs","suspended":false,"triggers":1}`),
+		},
+	}
+
+	table := []struct {
+		title      string
+		res        resource.Resource
+		act        module.ActionRequest
+		wantConfig string
+		wantStatus string
+		wantData   string
+		wantErr    error
+	}{
+		{
+			title:   "InvalidConfiguration",
+			act:     module.ActionRequest{Name: module.CreateAction, Params: []byte(`{`)},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title:   "InvalidSchedule",
+			act:     module.ActionRequest{Name: module.CreateAction, Params: []byte(`{"schedule":"every hour","image":"busybox"}`)},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title:   "InvalidConcurrencyPolicy",
+			act:     module.ActionRequest{Name: module.CreateAction, Params: []byte(`{"schedule":"@hourly","image":"busybox","concurrency_policy":"Never"}`)},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title:      "CreateWithDefaults",
+			act:        module.ActionRequest{Name: module.CreateAction, Params: []byte(`{"schedule":"*/5 * * * *","image":"busybox","failed_jobs_history_limit":0}`)},
+			wantConfig: `{"namespace":"default","schedule":"*/5 * * * *","image":"busybox","retries":0,"concurrency_policy":"Allow","successful_jobs_history_limit":3,"failed_jobs_history_limit":0}`,
+			wantStatus: resource.StatusPending,
+			wantData:   `{"pending_steps":["cronjob_apply"],"suspended":false,"triggers":0}`,
+		},
+		{
+			title:      "Update",
+			act:        module.ActionRequest{Name: module.UpdateAction, Params: []byte(`{"namespace":"jobs","schedule":"@daily","image":"busybox"}`)},
+			wantConfig: `{"namespace":"jobs","schedule":"@daily","image":"busybox","retries":0,"concurrency_policy":"Allow","successful_jobs_history_limit":3,"failed_jobs_history_limit":1}`,
+			wantStatus: resource.StatusPending,
+			wantData:   `{"pending_steps":["cronjob_apply"],"name":"demo-report","namespace":"jobs","suspended":false,"triggers":1}`,
+		},
+		{
+			title:      "Suspend",
+			act:        module.ActionRequest{Name: SuspendAction},
+			wantStatus: resource.StatusPending,
+			wantData:   `{"pending_steps":["cronjob_apply"],"name":"demo-report","namespace":"jobs","suspended":true,"triggers":1}`,
+		},
+		{
+			title:      "Resume",
+			act:        module.ActionRequest{Name: ResumeAction},
+			wantStatus: resource.StatusPending,
+			wantData:   `{"pending_steps":["cronjob_apply"],"name":"demo-report","namespace":"jobs","suspended":false,"triggers":1}`,
+		},
+		{
+			title:      "Trigger",
+			act:        module.ActionRequest{Name: TriggerAction},
+			wantStatus: resource.StatusPending,
+			wantData:   `{"pending_steps":["cronjob_trigger"],"name":"demo-report","namespace":"jobs","suspended":false,"triggers":2}`,
+		},
+		{
+			title: "TriggerBeforeApply",
+			res: func() resource.Resource {
+				r := res
+				r.State = resource.State{Status: resource.StatusError, ModuleData: []byte(`{"pending_steps":[]}`)}
+				return r
+			}(),
+			act:     module.ActionRequest{Name: TriggerAction},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title:      "Delete",
+			act:        module.ActionRequest{Name: module.DeleteAction},
+			wantStatus: resource.StatusDeleted,
+			wantData:   `{"pending_steps":["cronjob_delete"],"name":"demo-report","namespace":"jobs","suspended":false,"triggers":1}`,
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			r := res
+			if tt.res.URN != "" {
+				r = tt.res
+			}
+
+			got, err := (&cronJobModule{}).Plan(context.Background(), module.ExpandedResource{Resource: r}, tt.act)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, got.Resource.State.Status)
+			assert.JSONEq(t, tt.wantData, string(got.Resource.State.ModuleData))
+			if tt.wantConfig != "" {
+				assert.JSONEq(t, tt.wantConfig, string(got.Resource.Spec.Configs))
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package kubecronjob
+
+import (
+	"context"
+	"time"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/modules/kubernetes"
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/kube"
+	"github.com/odpf/entropy/pkg/worker"
+)
+
+const kubeAPIRetryBackoffDuration = 30 * time.Second
+
+var ErrKubeAPI = worker.RetryableError{RetryAfter: kubeAPIRetryBackoffDuration}
+
+func (m *cronJobModule) Sync(ctx context.Context, res module.ExpandedResource) (*resource.State, error) {
+	r := res.Resource
+
+	data, err := readModuleData(r.State.ModuleData)
+	if err != nil {
+		return nil, err
+	}
+
+	var pendingStep string
+	if len(data.PendingSteps) != 0 {
+		pendingStep = data.PendingSteps[0]
+		data.PendingSteps = data.PendingSteps[1:]
+	}
+
+	conf, err := readConfig(r.Spec.Configs)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeOut, err := kubernetes.ClusterOutput(res, keyKubeDependency)
+	if err != nil {
+		return nil, err
+	}
+	kubeCl := m.kubeClient(kubeOut.Configs)
+
+	errorState := &resource.State{
+		Status:     resource.StatusError,
+		Output:     r.State.Output,
+		ModuleData: data.JSON(),
+	}
+
+	switch pendingStep {
+	case stepApply:
+		// the cron job would be left behind in the old namespace.
+		if data.Name != "" && data.Namespace != conf.Namespace {
+			if err := kubeCl.DeleteCronJob(ctx, data.Namespace, data.Name); err != nil {
+				return nil, ErrKubeAPI.WithCause(err)
+			}
+		}
+
+		name := cronJobName(r)
+		if err := kubeCl.ApplyCronJob(ctx, conf.cronJobSpec(name, data.Suspended)); err != nil {
+			if errors.Is(err, errors.ErrInvalid) {
+				// retrying will not help until the config is fixed.
+				return errorState, nil
+			}
+			return nil, ErrKubeAPI.WithCause(err)
+		}
+		data.Name, data.Namespace = name, conf.Namespace
+
+	case stepTrigger:
+		err := kubeCl.TriggerCronJob(ctx, data.Namespace, data.Name, triggeredJobName(data.Name, data.Triggers))
+		if err != nil && !errors.Is(err, kube.ErrJobExists) {
+			if errors.Is(err, kube.ErrCronJobNotFound) {
+				// the cron job was removed from outside.
+				return errorState, nil
+			}
+			return nil, ErrKubeAPI.WithCause(err)
+		}
+
+	case stepDelete:
+		if data.Name != "" {
+			if err := kubeCl.DeleteCronJob(ctx, data.Namespace, data.Name); err != nil {
+				return nil, ErrKubeAPI.WithCause(err)
+			}
+		}
+		return &resource.State{
+			Status:     resource.StatusCompleted,
+			Output:     r.State.Output,
+			ModuleData: data.JSON(),
+		}, nil
+	}
+
+	status, err := kubeCl.GetCronJobStatus(ctx, data.Namespace, data.Name)
+	if err != nil {
+		return nil, ErrKubeAPI.WithCause(err)
+	}
+
+	finalStatus := resource.StatusCompleted
+	if len(data.PendingSteps) > 0 {
+		finalStatus = resource.StatusPending
+	}
+
+	return &resource.State{
+		Status:     finalStatus,
+		Output:     Output{CronJob: status}.JSON(),
+		ModuleData: data.JSON(),
+	}, nil
+}
@@ -0,0 +1,170 @@
+package kubecronjob
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/kube"
+	"github.com/odpf/entropy/pkg/kube/kubetest"
+)
+
+func TestCronJobModule_Sync(t *testing.T) {
+	t.Parallel()
+
+	applied := func() map[string]kube.CronJobSpec {
+		return map[string]kube.CronJobSpec{"jobs/demo-report": {Schedule: "0 * * * *"}}
+	}
+
+	newRes := func(moduleData string) module.ExpandedResource {
+		return module.ExpandedResource{
+			Resource: resource.Resource{
+				URN:     "orn:entropy:kube_cronjob:demo:report",
+				Kind:    "kube_cronjob",
+				Name:    "report",
+				Project: "demo",
+				Spec: resource.Spec{
+					Configs: []byte(`{"namespace":"jobs","schedule":"0 * * * *","image":"busybox","env":{"MODE":"full"},"retries":2,"concurrency_policy":"Forbid","successful_jobs_history_limit":5,"failed_jobs_history_limit":2}`),
+				},
+				State: resource.State{
+					Status:     resource.StatusPending,
+					Output:     []byte(`{}`),
+					ModuleData: []byte(moduleData),
+				},
+			},
+			Dependencies: map[string]module.ResolvedDependency{
+				keyKubeDependency: {Kind: "kubernetes", Output: []byte(`{"configs":{}}`)},
+			},
+		}
+	}
+
+	table := []struct {
+		title         string
+		cluster       *kubetest.Cluster
+		res           module.ExpandedResource
+		wantStatus    string
+		wantData      string
+		wantCronJobs  []string
+		wantSuspended bool
+		wantTriggered []string
+		wantDeleted   []kube.ObjectRef
+		wantRuns      int
+	}{
+		{
+			title:        "Apply",
+			cluster:      &kubetest.Cluster{},
+			res:          newRes(`{"pending_steps":["cronjob_apply"],"suspended":false,"triggers":0}`),
+			wantStatus:   resource.StatusCompleted,
+			wantData:     `{"pending_steps":[],"name":"demo-report","namespace":"jobs","suspended":false,"triggers":0}`,
+			wantCronJobs: []string{"jobs/demo-report"},
+		},
+		{
+			title:         "ApplySuspended",
+			cluster:       &kubetest.Cluster{CronJobs: applied()},
+			res:           newRes(`{"pending_steps":["cronjob_apply"],"name":"demo-report","namespace":"jobs","suspended":true,"triggers":0}`),
+			wantStatus:    resource.StatusCompleted,
+			wantData:      `{"pending_steps":[],"name":"demo-report","namespace":"jobs","suspended":true,"triggers":0}`,
+			wantCronJobs:  []string{"jobs/demo-report"},
+			wantSuspended: true,
+		},
+		{
+			title:        "ApplyInNewNamespace",
+			cluster:      &kubetest.Cluster{CronJobs: map[string]kube.CronJobSpec{"default/demo-report": {}}},
+			res:          newRes(`{"pending_steps":["cronjob_apply"],"name":"demo-report","namespace":"default","suspended":false,"triggers":0}`),
+			wantStatus:   resource.StatusCompleted,
+			wantData:     `{"pending_steps":[],"name":"demo-report","namespace":"jobs","suspended":false,"triggers":0}`,
+			wantCronJobs: []string{"jobs/demo-report"},
+			wantDeleted:  []kube.ObjectRef{{APIVersion: "batch/v1", Kind: "CronJob", Namespace: "default", Name: "demo-report"}},
+		},
+		{
+			title:      "ApplyRejected",
+			cluster:    &kubetest.Cluster{Rejected: map[string]error{"CronJob": errors.ErrInvalid.WithMsgf("invalid cron job")}},
+			res:        newRes(`{"pending_steps":["cronjob_apply"],"suspended":false,"triggers":0}`),
+			wantStatus: resource.StatusError,
+			wantData:   `{"pending_steps":[],"suspended":false,"triggers":0}`,
+		},
+		{
+			title:         "Trigger",
+			cluster:       &kubetest.Cluster{CronJobs: applied()},
+			res:           newRes(`{"pending_steps":["cronjob_trigger"],"name":"demo-report","namespace":"jobs","suspended":false,"triggers":3}`),
+			wantStatus:    resource.StatusCompleted,
+			wantData:      `{"pending_steps":[],"name":"demo-report","namespace":"jobs","suspended":false,"triggers":3}`,
+			wantCronJobs:  []string{"jobs/demo-report"},
+			wantTriggered: []string{"demo-report-manual-3"},
+			wantRuns:      1,
+		},
+		{
+			title:      "TriggerRemovedCronJob",
+			cluster:    &kubetest.Cluster{},
+			res:        newRes(`{"pending_steps":["cronjob_trigger"],"name":"demo-report","namespace":"jobs","suspended":false,"triggers":3}`),
+			wantStatus: resource.StatusError,
+			wantData:   `{"pending_steps":[],"name":"demo-report","namespace":"jobs","suspended":false,"triggers":3}`,
+		},
+		{
+			title:       "Delete",
+			cluster:     &kubetest.Cluster{CronJobs: applied()},
+			res:         newRes(`{"pending_steps":["cronjob_delete"],"name":"demo-report","namespace":"jobs","suspended":false,"triggers":0}`),
+			wantStatus:  resource.StatusCompleted,
+			wantData:    `{"pending_steps":[],"name":"demo-report","namespace":"jobs","suspended":false,"triggers":0}`,
+			wantDeleted: []kube.ObjectRef{{APIVersion: "batch/v1", Kind: "CronJob", Namespace: "jobs", Name: "demo-report"}},
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			m := &cronJobModule{
+				kubeClient: func(_ kube.Config) kubeClient { return tt.cluster },
+			}
+
+			got, err := m.Sync(context.Background(), tt.res)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, got.Status)
+			assert.JSONEq(t, tt.wantData, string(got.ModuleData))
+			assert.Equal(t, tt.wantTriggered, tt.cluster.Triggered)
+			assert.Equal(t, tt.wantDeleted, tt.cluster.Deleted)
+
+			var cronJobs []string
+			for key, spec := range tt.cluster.CronJobs {
+				cronJobs = append(cronJobs, key)
+				if spec.Name == "" {
+					continue // not applied during this sync.
+				}
+				assert.Equal(t, "0 * * * *", spec.Schedule)
+				assert.Equal(t, "Forbid", spec.ConcurrencyPolicy)
+				assert.Equal(t, int32(5), spec.SuccessfulJobsHistoryLimit)
+				assert.Equal(t, int32(2), spec.FailedJobsHistoryLimit)
+				assert.Equal(t, tt.wantSuspended, spec.Suspend)
+				assert.Equal(t, "demo-report", spec.Labels["app"])
+			}
+			assert.Equal(t, tt.wantCronJobs, cronJobs)
+
+			if tt.wantStatus == resource.StatusCompleted && len(tt.wantDeleted) == 0 || tt.wantRuns > 0 {
+				var out Output
+				require.NoError(t, json.Unmarshal(got.Output, &out))
+				require.NotNil(t, out.CronJob)
+				assert.Equal(t, "demo-report", out.CronJob.Name)
+				assert.Equal(t, tt.wantSuspended, out.CronJob.Suspended)
+				assert.Len(t, out.CronJob.Runs, tt.wantRuns)
+			}
+		})
+	}
+}
+
+func TestTriggeredJobName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "demo-report-manual-12", triggeredJobName("demo-report", 12))
+
+	long := triggeredJobName("a-very-long-project-name-with-an-even-longer-cron-name", 123)
+	assert.LessOrEqual(t, len(long), 63)
+	assert.Equal(t, "a-very-long-project-name-with-an-even-longer-cron-na-manual-123", long)
+}
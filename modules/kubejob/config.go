@@ -0,0 +1,94 @@
+package kubejob
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/kube"
+)
+
+const (
+	defaultNamespace = "default"
+	labelManagedBy   = "app.kubernetes.io/managed-by"
+	managedBy        = "entropy"
+)
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+type moduleConfig struct {
+	Namespace       string            `json:"namespace"`
+	Image           string            `json:"image"`
+	Command         []string          `json:"command,omitempty"`
+	Args            []string          `json:"args,omitempty"`
+	Env             map[string]string `json:"env,omitempty"`
+	Retries         int32             `json:"retries"`
+	DeadlineSeconds int64             `json:"deadline_seconds,omitempty"`
+}
+
+func readConfig(confJSON json.RawMessage) (*moduleConfig, error) {
+	var conf moduleConfig
+	if err := json.Unmarshal(confJSON, &conf); err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid config json: %v", err)
+	}
+
+	if conf.Namespace == "" {
+		conf.Namespace = defaultNamespace
+	}
+	return &conf, nil
+}
+
+func (mc moduleConfig) validate() error {
+	if strings.TrimSpace(mc.Image) == "" {
+		return errors.ErrInvalid.WithMsgf("image must be set")
+	}
+
+	for name := range mc.Env {
+		if errs := validation.IsEnvVarName(name); len(errs) > 0 {
+			return errors.ErrInvalid.WithMsgf("invalid env variable name '%s': %s", name, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
+func (mc moduleConfig) jobSpec(jobName string) kube.JobSpec {
+	return kube.JobSpec{
+		Name:      jobName,
+		Namespace: mc.Namespace,
+		Labels: map[string]string{
+			"app":          jobName,
+			labelManagedBy: managedBy,
+		},
+		Image:           mc.Image,
+		Command:         mc.Command,
+		Args:            mc.Args,
+		Env:             mc.Env,
+		Retries:         mc.Retries,
+		DeadlineSeconds: mc.DeadlineSeconds,
+	}
+}
+
+func (mc moduleConfig) JSON() []byte {
+	b, err := json.Marshal(mc)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// jobName returns the name of the kubernetes job for the given run of the
+// resource, i.e., '<project>-<name>-<run>' turned into a valid DNS label.
+func jobName(r resource.Resource, run int) string {
+	suffix := fmt.Sprintf("-%d", run)
+
+	base := invalidNameChars.ReplaceAllString(strings.ToLower(fmt.Sprintf("%s-%s", r.Project, r.Name)), "-")
+	if maxLen := validation.DNS1123LabelMaxLength - len(suffix); len(base) > maxLen {
+		base = base[:maxLen]
+	}
+	return strings.Trim(base, "-") + suffix
+}
@@ -0,0 +1,34 @@
+package kubejob
+
+import "encoding/json"
+
+type moduleData struct {
+	PendingSteps []string `json:"pending_steps"`
+
+	// Run is the number of times the job has been started, including
+	// the pending one.
+	Run int `json:"run"`
+
+	// JobName & Namespace identify the kubernetes job of the latest run.
+	JobName   string `json:"job_name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+func (md moduleData) JSON() json.RawMessage {
+	bytes, err := json.Marshal(md)
+	if err != nil {
+		panic(err)
+	}
+	return bytes
+}
+
+func readModuleData(data json.RawMessage) (*moduleData, error) {
+	var md moduleData
+	if len(data) == 0 {
+		return &md, nil
+	}
+	if err := json.Unmarshal(data, &md); err != nil {
+		return nil, err
+	}
+	return &md, nil
+}
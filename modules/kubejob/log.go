@@ -0,0 +1,51 @@
+package kubejob
+
+import (
+	"context"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/modules/kubernetes"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+func (m *jobModule) Log(ctx context.Context, res module.ExpandedResource, filter map[string]string) (<-chan module.LogChunk, error) {
+	data, err := readModuleData(res.Resource.State.ModuleData)
+	if err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid module data: %v", err)
+	} else if data.JobName == "" {
+		return nil, errors.ErrInvalid.WithMsgf("job has not been started yet")
+	}
+
+	kubeOut, err := kubernetes.ClusterOutput(res, keyKubeDependency)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter == nil {
+		filter = make(map[string]string)
+	}
+	filter["app"] = data.JobName
+
+	logs, err := m.kubeClient(kubeOut.Configs).StreamLogs(ctx, data.Namespace, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	mappedLogs := make(chan module.LogChunk)
+	go func() {
+		defer close(mappedLogs)
+		for {
+			select {
+			case log, ok := <-logs:
+				if !ok {
+					return
+				}
+				mappedLogs <- module.LogChunk{Data: log.Data, Labels: log.Labels}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return mappedLogs, nil
+}
@@ -0,0 +1,82 @@
+package kubejob
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"time"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/modules/kubernetes"
+	"github.com/odpf/entropy/pkg/kube"
+)
+
+const RerunAction = "rerun"
+
+const (
+	stepStart  = "job_start"
+	stepWait   = "job_wait"
+	stepDelete = "job_delete"
+)
+
+const keyKubeDependency = "kube_cluster"
+
+const (
+	// waitTimeout is the maximum duration a sync waits for the job to
+	// finish. Progress is saved to the state after each wait.
+	waitTimeout  = 30 * time.Second
+	pollInterval = 2 * time.Second
+)
+
+//go:embed schema/config.json
+var configSchema string
+
+var Module = module.Descriptor{
+	Kind: "kube_job",
+	Dependencies: map[string]string{
+		keyKubeDependency: kubernetes.Module.Kind,
+	},
+	Actions: []module.ActionDesc{
+		{
+			Name:        module.CreateAction,
+			Description: "Creates the job and runs it.",
+			ParamSchema: configSchema,
+		},
+		{
+			Name:        module.UpdateAction,
+			Description: "Updates the job config used by the next rerun.",
+			ParamSchema: configSchema,
+		},
+		{
+			Name:        RerunAction,
+			Description: "Runs the job again.",
+		},
+		{
+			Name:        module.DeleteAction,
+			Description: "Deletes the job.",
+		},
+	},
+	DriverFactory: func(conf json.RawMessage) (module.Driver, error) {
+		return &jobModule{
+			kubeClient: func(kubeConf kube.Config) kubeClient {
+				return kube.NewClient(kubeConf)
+			},
+			waitTimeout:  waitTimeout,
+			pollInterval: pollInterval,
+		}, nil
+	},
+}
+
+// kubeClient is the subset of kube.Client used by the module.
+type kubeClient interface {
+	CreateJob(ctx context.Context, spec kube.JobSpec) error
+	GetJobStatus(ctx context.Context, namespace, name string) (*kube.JobStatus, error)
+	DeleteJob(ctx context.Context, namespace, name string) error
+	StreamLogs(ctx context.Context, namespace string, filter map[string]string) (<-chan kube.LogChunk, error)
+}
+
+type jobModule struct {
+	kubeClient   func(kubeConf kube.Config) kubeClient
+	waitTimeout  time.Duration
+	pollInterval time.Duration
+}
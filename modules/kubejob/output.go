@@ -0,0 +1,46 @@
+package kubejob
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/modules/kubernetes"
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/kube"
+)
+
+type Output struct {
+	Run int             `json:"run"`
+	Job *kube.JobStatus `json:"job,omitempty"`
+}
+
+func (out Output) JSON() []byte {
+	b, err := json.Marshal(out)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (m *jobModule) Output(ctx context.Context, res module.ExpandedResource) (json.RawMessage, error) {
+	data, err := readModuleData(res.Resource.State.ModuleData)
+	if err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid module data: %v", err)
+	} else if data.JobName == "" {
+		return res.Resource.State.Output, nil
+	}
+
+	return module.LiveOutput(ctx, res, func(ctx context.Context) (json.RawMessage, error) {
+		kubeOut, err := kubernetes.ClusterOutput(res, keyKubeDependency)
+		if err != nil {
+			return nil, err
+		}
+
+		status, err := m.kubeClient(kubeOut.Configs).GetJobStatus(ctx, data.Namespace, data.JobName)
+		if err != nil {
+			return nil, err
+		}
+		return Output{Run: data.Run, Job: status}.JSON(), nil
+	}), nil
+}
@@ -0,0 +1,56 @@
+package kubejob
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/pkg/kube"
+	"github.com/odpf/entropy/pkg/kube/kubetest"
+)
+
+func TestJobModule_Output(t *testing.T) {
+	t.Parallel()
+
+	res := module.ExpandedResource{
+		Dependencies: map[string]module.ResolvedDependency{
+			keyKubeDependency: {Kind: "kubernetes", Output: []byte(`{"configs":{}}`)},
+		},
+	}
+	res.Resource.State.Output = []byte(`{"run":2,"job":{"name":"demo-backfill-2","namespace":"jobs","phase":"PENDING"}}`)
+	res.Resource.State.ModuleData = []byte(`{"run":2,"job_name":"demo-backfill-2","namespace":"jobs"}`)
+
+	table := []struct {
+		title   string
+		cluster *kubetest.Cluster
+		want    string
+	}{
+		{
+			title:   "Live",
+			cluster: &kubetest.Cluster{Jobs: map[string][]kube.JobStatus{"jobs/demo-backfill-2": {{Phase: kube.JobSucceeded, Succeeded: 1}}}},
+			want:    `{"run":2,"job":{"name":"demo-backfill-2","namespace":"jobs","phase":"SUCCEEDED","active":0,"succeeded":1,"failed":0}}`,
+		},
+		{
+			title:   "ClusterUnreachable",
+			cluster: &kubetest.Cluster{Err: assert.AnError},
+			want:    `{"run":2,"job":{"name":"demo-backfill-2","namespace":"jobs","phase":"PENDING"}}`,
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			m := &jobModule{
+				kubeClient: func(_ kube.Config) kubeClient { return tt.cluster },
+			}
+
+			got, err := m.Output(context.Background(), res)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package kubejob
+
+import (
+	"context"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+func (m *jobModule) Plan(_ context.Context, res module.ExpandedResource, act module.ActionRequest) (*module.Plan, error) {
+	switch act.Name {
+	case module.CreateAction:
+		return m.planCreate(res, act)
+	case module.UpdateAction:
+		return m.planUpdate(res, act)
+	case RerunAction:
+		return m.planRerun(res)
+	case module.DeleteAction:
+		return m.planDelete(res)
+	default:
+		return nil, errors.ErrInvalid.WithMsgf("action '%s' not supported", act.Name)
+	}
+}
+
+func (*jobModule) planCreate(res module.ExpandedResource, act module.ActionRequest) (*module.Plan, error) {
+	r := res.Resource
+
+	conf, err := readConfig(act.Params)
+	if err != nil {
+		return nil, err
+	} else if err := conf.validate(); err != nil {
+		return nil, err
+	}
+
+	r.Spec.Configs = conf.JSON()
+	r.State = resource.State{
+		Status: resource.StatusPending,
+		Output: Output{Run: 1}.JSON(),
+		ModuleData: moduleData{
+			PendingSteps: []string{stepStart, stepWait},
+			Run:          1,
+		}.JSON(),
+	}
+	return &module.Plan{Resource: r, Reason: "kube job created"}, nil
+}
+
+// planUpdate only updates the config. The job is not run until a rerun
+// is requested.
+func (*jobModule) planUpdate(res module.ExpandedResource, act module.ActionRequest) (*module.Plan, error) {
+	r := res.Resource
+
+	conf, err := readConfig(act.Params)
+	if err != nil {
+		return nil, err
+	} else if err := conf.validate(); err != nil {
+		return nil, err
+	}
+
+	r.Spec.Configs = conf.JSON()
+	return &module.Plan{Resource: r, Reason: "kube job updated"}, nil
+}
+
+func (*jobModule) planRerun(res module.ExpandedResource) (*module.Plan, error) {
+	r := res.Resource
+
+	data, err := readModuleData(r.State.ModuleData)
+	if err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid module data: %v", err)
+	}
+	data.Run++
+	data.PendingSteps = []string{stepStart, stepWait}
+
+	r.State = resource.State{
+		Status:     resource.StatusPending,
+		Output:     Output{Run: data.Run}.JSON(),
+		ModuleData: data.JSON(),
+	}
+	return &module.Plan{Resource: r, Reason: "kube job rerun"}, nil
+}
+
+func (*jobModule) planDelete(res module.ExpandedResource) (*module.Plan, error) {
+	r := res.Resource
+
+	data, err := readModuleData(r.State.ModuleData)
+	if err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid module data: %v", err)
+	}
+	data.PendingSteps = []string{stepDelete}
+
+	r.State = resource.State{
+		Status:     resource.StatusDeleted,
+		Output:     r.State.Output,
+		ModuleData: data.JSON(),
+	}
+	return &module.Plan{Resource: r, Reason: "kube job deleted"}, nil
+}
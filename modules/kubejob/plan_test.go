@@ -0,0 +1,104 @@
+package kubejob
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+func TestJobModule_Plan(t *testing.T) {
+	t.Parallel()
+
+	res := resource.Resource{
+		URN:     "orn:entropy:kube_job:demo:backfill",
+		Kind:    "kube_job",
+		Name:    "backfill",
+		Project: "demo",
+		Spec: resource.Spec{
+			Configs: []byte(`{"namespace":"jobs","image":"busybox","command":["echo","hi"],"retries":0}`),
+		},
+		State: resource.State{
+			Status:     resource.StatusError,
+			Output:     []byte(`{"run":2,"job":{"name":"demo-backfill-2","namespace":"jobs","phase":"FAILED","active":0,"succeeded":0,"failed":1}}`),
+			ModuleData: []byte(`{"pending_steps":[],"run":2,"job_name":"demo-backfill-2","namespace":"jobs"}`),
+		},
+	}
+
+	table := []struct {
+		title      string
+		act        module.ActionRequest
+		wantConfig string
+		wantOutput string
+		wantStatus string
+		wantData   string
+		wantErr    error
+	}{
+		{
+			title:   "InvalidConfiguration",
+			act:     module.ActionRequest{Name: module.CreateAction, Params: []byte(`{`)},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title:   "InvalidEnvName",
+			act:     module.ActionRequest{Name: module.CreateAction, Params: []byte(`{"image":"busybox","env":{"1BAD":"x"}}`)},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title:      "Create",
+			act:        module.ActionRequest{Name: module.CreateAction, Params: []byte(`{"image":"busybox","args":["date"]}`)},
+			wantConfig: `{"namespace":"default","image":"busybox","args":["date"],"retries":0}`,
+			wantOutput: `{"run":1}`,
+			wantStatus: resource.StatusPending,
+			wantData:   `{"pending_steps":["job_start","job_wait"],"run":1}`,
+		},
+		{
+			title:      "UpdateDoesNotRun",
+			act:        module.ActionRequest{Name: module.UpdateAction, Params: []byte(`{"namespace":"jobs","image":"busybox:1.35"}`)},
+			wantConfig: `{"namespace":"jobs","image":"busybox:1.35","retries":0}`,
+			wantOutput: string(res.State.Output),
+			wantStatus: resource.StatusError,
+			wantData:   string(res.State.ModuleData),
+		},
+		{
+			title:      "Rerun",
+			act:        module.ActionRequest{Name: RerunAction},
+			wantOutput: `{"run":3}`,
+			wantStatus: resource.StatusPending,
+			wantData:   `{"pending_steps":["job_start","job_wait"],"run":3,"job_name":"demo-backfill-2","namespace":"jobs"}`,
+		},
+		{
+			title:      "Delete",
+			act:        module.ActionRequest{Name: module.DeleteAction},
+			wantOutput: string(res.State.Output),
+			wantStatus: resource.StatusDeleted,
+			wantData:   `{"pending_steps":["job_delete"],"run":2,"job_name":"demo-backfill-2","namespace":"jobs"}`,
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := (&jobModule{}).Plan(context.Background(), module.ExpandedResource{Resource: res}, tt.act)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, got.Resource.State.Status)
+			assert.JSONEq(t, tt.wantData, string(got.Resource.State.ModuleData))
+			assert.JSONEq(t, tt.wantOutput, string(got.Resource.State.Output))
+			if tt.wantConfig != "" {
+				assert.JSONEq(t, tt.wantConfig, string(got.Resource.Spec.Configs))
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package kubejob
+
+import (
+	"context"
+	"time"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/modules/kubernetes"
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/kube"
+	"github.com/odpf/entropy/pkg/worker"
+)
+
+const kubeAPIRetryBackoffDuration = 30 * time.Second
+
+var ErrKubeAPI = worker.RetryableError{RetryAfter: kubeAPIRetryBackoffDuration}
+
+func (m *jobModule) Sync(ctx context.Context, res module.ExpandedResource) (*resource.State, error) {
+	r := res.Resource
+
+	data, err := readModuleData(r.State.ModuleData)
+	if err != nil {
+		return nil, err
+	}
+
+	var pendingStep string
+	if len(data.PendingSteps) != 0 {
+		pendingStep = data.PendingSteps[0]
+		data.PendingSteps = data.PendingSteps[1:]
+	}
+
+	conf, err := readConfig(r.Spec.Configs)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeOut, err := kubernetes.ClusterOutput(res, keyKubeDependency)
+	if err != nil {
+		return nil, err
+	}
+	kubeCl := m.kubeClient(kubeOut.Configs)
+
+	output := Output{Run: data.Run}
+	switch pendingStep {
+	case stepStart:
+		// the job of the previous run is not needed anymore.
+		name := jobName(r, data.Run)
+		if data.JobName != "" && data.JobName != name {
+			if err := kubeCl.DeleteJob(ctx, data.Namespace, data.JobName); err != nil {
+				return nil, ErrKubeAPI.WithCause(err)
+			}
+		}
+
+		err := kubeCl.CreateJob(ctx, conf.jobSpec(name))
+		if err != nil && !errors.Is(err, kube.ErrJobExists) {
+			if errors.Is(err, errors.ErrInvalid) {
+				// retrying will not help until the config is fixed.
+				output.Job = &kube.JobStatus{Name: name, Namespace: conf.Namespace, Phase: kube.JobFailed, Message: err.Error()}
+				data.PendingSteps = nil
+				return &resource.State{
+					Status:     resource.StatusError,
+					Output:     output.JSON(),
+					ModuleData: data.JSON(),
+				}, nil
+			}
+			return nil, ErrKubeAPI.WithCause(err)
+		}
+		data.JobName, data.Namespace = name, conf.Namespace
+		output.Job = &kube.JobStatus{Name: name, Namespace: conf.Namespace, Phase: kube.JobPending}
+
+	case stepWait:
+		status, err := m.waitForJob(ctx, kubeCl, data.Namespace, data.JobName)
+		if err != nil {
+			if errors.Is(err, kube.ErrJobNotFound) {
+				// the job was removed from outside.
+				output.Job = &kube.JobStatus{Name: data.JobName, Namespace: data.Namespace, Phase: kube.JobFailed, Message: "job not found"}
+				return &resource.State{
+					Status:     resource.StatusError,
+					Output:     output.JSON(),
+					ModuleData: data.JSON(),
+				}, nil
+			}
+			return nil, ErrKubeAPI.WithCause(err)
+		}
+		output.Job = status
+
+		switch status.Phase {
+		case kube.JobFailed:
+			return &resource.State{
+				Status:     resource.StatusError,
+				Output:     output.JSON(),
+				ModuleData: data.JSON(),
+			}, nil
+
+		case kube.JobPending, kube.JobRunning:
+			// save the progress and continue waiting in the next sync.
+			data.PendingSteps = append([]string{stepWait}, data.PendingSteps...)
+		}
+
+	case stepDelete:
+		if data.JobName != "" {
+			if err := kubeCl.DeleteJob(ctx, data.Namespace, data.JobName); err != nil {
+				return nil, ErrKubeAPI.WithCause(err)
+			}
+		}
+		return &resource.State{
+			Status:     resource.StatusCompleted,
+			Output:     r.State.Output,
+			ModuleData: data.JSON(),
+		}, nil
+	}
+
+	finalStatus := resource.StatusCompleted
+	if len(data.PendingSteps) > 0 {
+		finalStatus = resource.StatusPending
+	}
+
+	return &resource.State{
+		Status:     finalStatus,
+		Output:     output.JSON(),
+		ModuleData: data.JSON(),
+	}, nil
+}
+
+// waitForJob waits for the job to finish, for at most waitTimeout, and
+// returns its latest status.
+func (m *jobModule) waitForJob(ctx context.Context, kubeCl kubeClient, namespace, name string) (*kube.JobStatus, error) {
+	timeout := time.NewTimer(m.waitTimeout)
+	defer timeout.Stop()
+
+	for {
+		status, err := kubeCl.GetJobStatus(ctx, namespace, name)
+		if err != nil || status.IsFinished() {
+			return status, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return status, nil
+		case <-timeout.C:
+			return status, nil
+		case <-time.After(m.pollInterval):
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package kubejob
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/kube"
+	"github.com/odpf/entropy/pkg/kube/kubetest"
+)
+
+func TestJobModule_Sync(t *testing.T) {
+	t.Parallel()
+
+	var (
+		exitCode    = int32(2)
+		previousRun = kube.ObjectRef{APIVersion: "batch/v1", Kind: "Job", Namespace: "jobs", Name: "demo-backfill-1"}
+		run         = kube.ObjectRef{APIVersion: "batch/v1", Kind: "Job", Namespace: "jobs", Name: "demo-backfill-2"}
+	)
+
+	newRes := func(moduleData string) module.ExpandedResource {
+		return module.ExpandedResource{
+			Resource: resource.Resource{
+				URN:     "orn:entropy:kube_job:demo:backfill",
+				Kind:    "kube_job",
+				Name:    "backfill",
+				Project: "demo",
+				Spec: resource.Spec{
+					Configs: []byte(`{"namespace":"jobs","image":"busybox","command":["sh","-c","exit 2"],"env":{"MODE":"full"},"retries":1}`),
+				},
+				State: resource.State{
+					Status:     resource.StatusPending,
+					Output:     []byte(`{"run":2}`),
+					ModuleData: []byte(moduleData),
+				},
+			},
+			Dependencies: map[string]module.ResolvedDependency{
+				keyKubeDependency: {Kind: "kubernetes", Output: []byte(`{"configs":{}}`)},
+			},
+		}
+	}
+
+	table := []struct {
+		title       string
+		cluster     *kubetest.Cluster
+		res         module.ExpandedResource
+		wantStatus  string
+		wantPhase   string
+		wantData    string
+		wantCreated []string
+		wantDeleted []kube.ObjectRef
+	}{
+		{
+			title:       "StartDeletesPreviousRun",
+			cluster:     &kubetest.Cluster{},
+			res:         newRes(`{"pending_steps":["job_start","job_wait"],"run":2,"job_name":"demo-backfill-1","namespace":"jobs"}`),
+			wantStatus:  resource.StatusPending,
+			wantPhase:   kube.JobPending,
+			wantData:    `{"pending_steps":["job_wait"],"run":2,"job_name":"demo-backfill-2","namespace":"jobs"}`,
+			wantCreated: []string{"demo-backfill-2"},
+			wantDeleted: []kube.ObjectRef{previousRun},
+		},
+		{
+			title:       "StartInvalidJob",
+			cluster:     &kubetest.Cluster{Rejected: map[string]error{"Job": errors.ErrInvalid.WithMsgf("invalid job")}},
+			res:         newRes(`{"pending_steps":["job_start","job_wait"],"run":2,"job_name":"demo-backfill-1","namespace":"jobs"}`),
+			wantStatus:  resource.StatusError,
+			wantPhase:   kube.JobFailed,
+			wantData:    `{"pending_steps":null,"run":2,"job_name":"demo-backfill-1","namespace":"jobs"}`,
+			wantDeleted: []kube.ObjectRef{previousRun},
+		},
+		{
+			title: "WaitSavesProgress",
+			cluster: &kubetest.Cluster{Jobs: map[string][]kube.JobStatus{
+				"jobs/demo-backfill-2": {{Phase: kube.JobRunning, Active: 1}},
+			}},
+			res:        newRes(`{"pending_steps":["job_wait"],"run":2,"job_name":"demo-backfill-2","namespace":"jobs"}`),
+			wantStatus: resource.StatusPending,
+			wantPhase:  kube.JobRunning,
+			wantData:   `{"pending_steps":["job_wait"],"run":2,"job_name":"demo-backfill-2","namespace":"jobs"}`,
+		},
+		{
+			title: "WaitUntilSucceeded",
+			cluster: &kubetest.Cluster{Jobs: map[string][]kube.JobStatus{
+				"jobs/demo-backfill-2": {{Phase: kube.JobRunning, Active: 1}, {Phase: kube.JobSucceeded, Succeeded: 1}},
+			}},
+			res:        newRes(`{"pending_steps":["job_wait"],"run":2,"job_name":"demo-backfill-2","namespace":"jobs"}`),
+			wantStatus: resource.StatusCompleted,
+			wantPhase:  kube.JobSucceeded,
+			wantData:   `{"pending_steps":[],"run":2,"job_name":"demo-backfill-2","namespace":"jobs"}`,
+		},
+		{
+			title: "Failed",
+			cluster: &kubetest.Cluster{Jobs: map[string][]kube.JobStatus{
+				"jobs/demo-backfill-2": {{Phase: kube.JobFailed, Failed: 2, Reason: "BackoffLimitExceeded", ExitCode: &exitCode, ExitReason: "Error"}},
+			}},
+			res:        newRes(`{"pending_steps":["job_wait"],"run":2,"job_name":"demo-backfill-2","namespace":"jobs"}`),
+			wantStatus: resource.StatusError,
+			wantPhase:  kube.JobFailed,
+			wantData:   `{"pending_steps":[],"run":2,"job_name":"demo-backfill-2","namespace":"jobs"}`,
+		},
+		{
+			title:      "JobRemovedFromOutside",
+			cluster:    &kubetest.Cluster{},
+			res:        newRes(`{"pending_steps":["job_wait"],"run":2,"job_name":"demo-backfill-2","namespace":"jobs"}`),
+			wantStatus: resource.StatusError,
+			wantPhase:  kube.JobFailed,
+			wantData:   `{"pending_steps":[],"run":2,"job_name":"demo-backfill-2","namespace":"jobs"}`,
+		},
+		{
+			title:       "Delete",
+			cluster:     &kubetest.Cluster{},
+			res:         newRes(`{"pending_steps":["job_delete"],"run":2,"job_name":"demo-backfill-2","namespace":"jobs"}`),
+			wantStatus:  resource.StatusCompleted,
+			wantData:    `{"pending_steps":[],"run":2,"job_name":"demo-backfill-2","namespace":"jobs"}`,
+			wantDeleted: []kube.ObjectRef{run},
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			m := &jobModule{
+				kubeClient:   func(_ kube.Config) kubeClient { return tt.cluster },
+				waitTimeout:  50 * time.Millisecond,
+				pollInterval: time.Millisecond,
+			}
+
+			got, err := m.Sync(context.Background(), tt.res)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, got.Status)
+			assert.JSONEq(t, tt.wantData, string(got.ModuleData))
+			assert.Equal(t, tt.wantDeleted, tt.cluster.Deleted)
+
+			var created []string
+			for _, spec := range tt.cluster.Created {
+				created = append(created, spec.Name)
+				assert.Equal(t, "jobs", spec.Namespace)
+				assert.Equal(t, int32(1), spec.Retries)
+				assert.Equal(t, spec.Name, spec.Labels["app"])
+			}
+			assert.Equal(t, tt.wantCreated, created)
+
+			if tt.wantPhase != "" {
+				var out Output
+				require.NoError(t, json.Unmarshal(got.Output, &out))
+				assert.Equal(t, 2, out.Run)
+				assert.Equal(t, tt.wantPhase, out.Job.Phase)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package kubemanifests
+
+import (
+	"encoding/json"
+
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/kube"
+)
+
+const defaultNamespace = "default"
+
+type moduleConfig struct {
+	Namespace string                   `json:"namespace"`
+	Manifests []map[string]interface{} `json:"manifests"`
+}
+
+func readConfig(confJSON json.RawMessage) (*moduleConfig, error) {
+	var conf moduleConfig
+	if err := json.Unmarshal(confJSON, &conf); err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid config json: %v", err)
+	}
+
+	if conf.Namespace == "" {
+		conf.Namespace = defaultNamespace
+	}
+	return &conf, nil
+}
+
+func (mc moduleConfig) validate() error {
+	seen := map[kube.ObjectRef]bool{}
+	for i, obj := range mc.Manifests {
+		ref := mc.refOf(obj)
+		if ref.APIVersion == "" || ref.Kind == "" || ref.Name == "" {
+			return errors.ErrInvalid.
+				WithMsgf("manifest at index %d must have apiVersion, kind & metadata.name", i)
+		} else if seen[ref] {
+			return errors.ErrInvalid.WithMsgf("manifest for %s is listed more than once", ref)
+		}
+		seen[ref] = true
+	}
+	return nil
+}
+
+// refs returns references to all the objects in the manifests. Objects
+// without a namespace are assumed to be in the default namespace.
+func (mc moduleConfig) refs() []kube.ObjectRef {
+	refs := make([]kube.ObjectRef, 0, len(mc.Manifests))
+	for _, obj := range mc.Manifests {
+		refs = append(refs, mc.refOf(obj))
+	}
+	return refs
+}
+
+func (mc moduleConfig) refOf(obj map[string]interface{}) kube.ObjectRef {
+	ref := kube.RefOf(obj)
+	if ref.Namespace == "" {
+		ref.Namespace = mc.Namespace
+	}
+	return ref
+}
+
+func (mc moduleConfig) JSON() []byte {
+	b, err := json.Marshal(mc)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
@@ -0,0 +1,65 @@
+package kubemanifests
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/modules/kubernetes"
+	"github.com/odpf/entropy/pkg/kube"
+)
+
+const (
+	stepApply  = "apply"
+	stepDelete = "delete"
+)
+
+const keyKubeDependency = "kube_cluster"
+
+// fieldManager is the field manager used for server-side apply.
+const fieldManager = "entropy"
+
+//go:embed schema/config.json
+var configSchema string
+
+var Module = module.Descriptor{
+	Kind: "kube_manifests",
+	Dependencies: map[string]string{
+		keyKubeDependency: kubernetes.Module.Kind,
+	},
+	Actions: []module.ActionDesc{
+		{
+			Name:        module.CreateAction,
+			Description: "Applies the kubernetes objects.",
+			ParamSchema: configSchema,
+		},
+		{
+			Name:        module.UpdateAction,
+			Description: "Applies the kubernetes objects and removes the ones no longer listed.",
+			ParamSchema: configSchema,
+		},
+		{
+			Name:        module.DeleteAction,
+			Description: "Deletes all the kubernetes objects.",
+		},
+	},
+	DriverFactory: func(conf json.RawMessage) (module.Driver, error) {
+		return &manifestsModule{
+			kubeClient: func(kubeConf kube.Config) kubeClient {
+				return kube.NewClient(kubeConf)
+			},
+		}, nil
+	},
+}
+
+// kubeClient is the subset of kube.Client used by the module.
+type kubeClient interface {
+	ApplyObjects(ctx context.Context, fieldManager, defaultNamespace string, objs []map[string]interface{}) ([]kube.ObjectRef, error)
+	DeleteObjects(ctx context.Context, refs []kube.ObjectRef) error
+	GetObjectStatuses(ctx context.Context, refs []kube.ObjectRef) ([]kube.ObjectStatus, error)
+}
+
+type manifestsModule struct {
+	kubeClient func(kubeConf kube.Config) kubeClient
+}
@@ -0,0 +1,58 @@
+package kubemanifests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/pkg/kube"
+	"github.com/odpf/entropy/pkg/kube/kubetest"
+)
+
+func TestManifestsModule_Output(t *testing.T) {
+	t.Parallel()
+
+	foo := kube.ObjectRef{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "foo"}
+
+	res := module.ExpandedResource{
+		Dependencies: map[string]module.ResolvedDependency{
+			keyKubeDependency: {Kind: "kubernetes", Output: []byte(`{"configs":{}}`)},
+		},
+	}
+	res.Resource.State.Output = []byte(`{"objects":[]}`)
+	res.Resource.State.ModuleData = []byte(`{"applied":[{"api_version":"v1","kind":"ConfigMap","namespace":"default","name":"foo"}]}`)
+
+	table := []struct {
+		title   string
+		cluster *kubetest.Cluster
+		want    string
+	}{
+		{
+			title:   "Live",
+			cluster: &kubetest.Cluster{Objects: map[kube.ObjectRef]map[string]interface{}{foo: {}}},
+			want:    `{"objects":[{"api_version":"v1","kind":"ConfigMap","namespace":"default","name":"foo","exists":true}]}`,
+		},
+		{
+			title:   "ClusterUnreachable",
+			cluster: &kubetest.Cluster{Err: assert.AnError},
+			want:    `{"objects":[]}`,
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			m := &manifestsModule{
+				kubeClient: func(_ kube.Config) kubeClient { return tt.cluster },
+			}
+
+			got, err := m.Output(context.Background(), res)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package kubemanifests
+
+import (
+	"context"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+func (m *manifestsModule) Plan(_ context.Context, res module.ExpandedResource, act module.ActionRequest) (*module.Plan, error) {
+	switch act.Name {
+	case module.CreateAction, module.UpdateAction:
+		return m.planApply(res, act)
+	case module.DeleteAction:
+		return m.planDelete(res)
+	default:
+		return nil, errors.ErrInvalid.WithMsgf("action '%s' not supported", act.Name)
+	}
+}
+
+func (*manifestsModule) planApply(res module.ExpandedResource, act module.ActionRequest) (*module.Plan, error) {
+	r := res.Resource
+
+	conf, err := readConfig(act.Params)
+	if err != nil {
+		return nil, err
+	} else if err := conf.validate(); err != nil {
+		return nil, err
+	}
+
+	data, err := readModuleData(r.State.ModuleData)
+	if err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid module data: %v", err)
+	}
+	data.PendingSteps = []string{stepApply}
+
+	reason := "kubernetes objects applied"
+	if act.Name == module.UpdateAction {
+		reason = "kubernetes objects updated"
+	}
+
+	r.Spec.Configs = conf.JSON()
+	r.State = resource.State{
+		Status:     resource.StatusPending,
+		Output:     r.State.Output,
+		ModuleData: data.JSON(),
+	}
+	return &module.Plan{Resource: r, Reason: reason}, nil
+}
+
+func (*manifestsModule) planDelete(res module.ExpandedResource) (*module.Plan, error) {
+	r := res.Resource
+
+	data, err := readModuleData(r.State.ModuleData)
+	if err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid module data: %v", err)
+	}
+	data.PendingSteps = []string{stepDelete}
+
+	r.State = resource.State{
+		Status:     resource.StatusDeleted,
+		Output:     r.State.Output,
+		ModuleData: data.JSON(),
+	}
+	return &module.Plan{Resource: r, Reason: "kubernetes objects deleted"}, nil
+}
@@ -0,0 +1,96 @@
+package kubemanifests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+func TestManifestsModule_Plan(t *testing.T) {
+	t.Parallel()
+
+	res := resource.Resource{
+		URN:     "orn:entropy:kube_manifests:demo:configs",
+		Kind:    "kube_manifests",
+		Name:    "configs",
+		Project: "demo",
+		State: resource.State{
+			Status:     resource.StatusCompleted,
+			ModuleData: []byte(`{"pending_steps":[],"applied":[{"api_version":"v1","kind":"ConfigMap","namespace":"default","name":"foo"}]}`),
+		},
+	}
+
+	table := []struct {
+		title      string
+		act        module.ActionRequest
+		wantConfig string
+		wantStatus string
+		wantData   string
+		wantReason string
+		wantErr    error
+	}{
+		{
+			title:   "InvalidConfiguration",
+			act:     module.ActionRequest{Name: module.CreateAction, Params: []byte(`{`)},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title:   "MissingName",
+			act:     module.ActionRequest{Name: module.CreateAction, Params: []byte(`{"manifests":[{"apiVersion":"v1","kind":"ConfigMap","metadata":{}}]}`)},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title: "DuplicateObjects",
+			act: module.ActionRequest{
+				Name:   module.CreateAction,
+				Params: []byte(`{"manifests":[{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"foo"}},{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"foo","namespace":"default"}}]}`),
+			},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title: "Update",
+			act: module.ActionRequest{
+				Name:   module.UpdateAction,
+				Params: []byte(`{"manifests":[{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"bar"}}]}`),
+			},
+			wantConfig: `{"namespace":"default","manifests":[{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"bar"}}]}`,
+			wantStatus: resource.StatusPending,
+			wantData:   `{"pending_steps":["apply"],"applied":[{"api_version":"v1","kind":"ConfigMap","namespace":"default","name":"foo"}]}`,
+			wantReason: "kubernetes objects updated",
+		},
+		{
+			title:      "Delete",
+			act:        module.ActionRequest{Name: module.DeleteAction},
+			wantStatus: resource.StatusDeleted,
+			wantData:   `{"pending_steps":["delete"],"applied":[{"api_version":"v1","kind":"ConfigMap","namespace":"default","name":"foo"}]}`,
+			wantReason: "kubernetes objects deleted",
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := (&manifestsModule{}).Plan(context.Background(), module.ExpandedResource{Resource: res}, tt.act)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantReason, got.Reason)
+			assert.Equal(t, tt.wantStatus, got.Resource.State.Status)
+			assert.JSONEq(t, tt.wantData, string(got.Resource.State.ModuleData))
+			if tt.wantConfig != "" {
+				assert.JSONEq(t, tt.wantConfig, string(got.Resource.Spec.Configs))
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package kubemanifests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/kube"
+	"github.com/odpf/entropy/pkg/kube/kubetest"
+)
+
+func TestManifestsModule_Sync(t *testing.T) {
+	t.Parallel()
+
+	foo := kube.ObjectRef{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "foo"}
+	bar := kube.ObjectRef{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "bar"}
+
+	newRes := func(manifests, moduleData string) module.ExpandedResource {
+		return module.ExpandedResource{
+			Resource: resource.Resource{
+				URN:     "orn:entropy:kube_manifests:demo:configs",
+				Kind:    "kube_manifests",
+				Name:    "configs",
+				Project: "demo",
+				Spec: resource.Spec{
+					Configs: []byte(`{"namespace":"default","manifests":` + manifests + `}`),
+				},
+				State: resource.State{
+					Status:     resource.StatusPending,
+					ModuleData: []byte(moduleData),
+				},
+			},
+			Dependencies: map[string]module.ResolvedDependency{
+				keyKubeDependency: {Kind: "kubernetes", Output: []byte(`{"configs":{}}`)},
+			},
+		}
+	}
+
+	table := []struct {
+		title       string
+		manifests   string
+		moduleData  string
+		cluster     *kubetest.Cluster
+		wantStatus  string
+		wantData    string
+		wantDeleted []kube.ObjectRef
+	}{
+		{
+			title:       "ApplyAndPrune",
+			manifests:   `[{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"bar"}}]`,
+			moduleData:  `{"pending_steps":["apply"],"applied":[{"api_version":"v1","kind":"ConfigMap","namespace":"default","name":"foo"}]}`,
+			cluster:     &kubetest.Cluster{},
+			wantStatus:  resource.StatusCompleted,
+			wantData:    `{"pending_steps":[],"applied":[{"api_version":"v1","kind":"ConfigMap","namespace":"default","name":"bar"}]}`,
+			wantDeleted: []kube.ObjectRef{foo},
+		},
+		{
+			title:      "UnknownKind",
+			manifests:  `[{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"bar"}},{"apiVersion":"example.com/v1","kind":"Widget","metadata":{"name":"baz"}}]`,
+			moduleData: `{"pending_steps":["apply"],"applied":[{"api_version":"v1","kind":"ConfigMap","namespace":"default","name":"foo"}]}`,
+			cluster:    &kubetest.Cluster{Rejected: map[string]error{"Widget": kube.ErrUnknownKind}},
+			wantStatus: resource.StatusError,
+			wantData:   `{"pending_steps":[],"applied":[{"api_version":"v1","kind":"ConfigMap","namespace":"default","name":"foo"},{"api_version":"v1","kind":"ConfigMap","namespace":"default","name":"bar"}]}`,
+		},
+		{
+			title:       "Delete",
+			manifests:   `[{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"bar"}}]`,
+			moduleData:  `{"pending_steps":["delete"],"applied":[{"api_version":"v1","kind":"ConfigMap","namespace":"default","name":"foo"}]}`,
+			cluster:     &kubetest.Cluster{},
+			wantStatus:  resource.StatusCompleted,
+			wantData:    `{"pending_steps":[],"applied":[{"api_version":"v1","kind":"ConfigMap","namespace":"default","name":"foo"}]}`,
+			wantDeleted: []kube.ObjectRef{foo, bar},
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			m := &manifestsModule{
+				kubeClient: func(_ kube.Config) kubeClient { return tt.cluster },
+			}
+
+			got, err := m.Sync(context.Background(), newRes(tt.manifests, tt.moduleData))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, got.Status)
+			assert.JSONEq(t, tt.wantData, string(got.ModuleData))
+			assert.Equal(t, tt.wantDeleted, tt.cluster.Deleted)
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package kubenamespace
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	kuberesource "k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/kube"
+)
+
+const (
+	// objectName is the name of the ResourceQuota and LimitRange objects
+	// created in the namespace.
+	objectName = "entropy"
+
+	labelManagedBy = "app.kubernetes.io/managed-by"
+)
+
+var invalidLabelChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+type moduleConfig struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+	Quota  map[string]string `json:"quota,omitempty"`
+	Limits *limits           `json:"limits,omitempty"`
+}
+
+// limits are the constraints on the resources of each container in the
+// namespace.
+type limits struct {
+	Default        map[string]string `json:"default,omitempty"`
+	DefaultRequest map[string]string `json:"default_request,omitempty"`
+	Max            map[string]string `json:"max,omitempty"`
+	Min            map[string]string `json:"min,omitempty"`
+}
+
+func readConfig(r resource.Resource, confJSON json.RawMessage) (*moduleConfig, error) {
+	var conf moduleConfig
+	if err := json.Unmarshal(confJSON, &conf); err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid config json: %v", err)
+	}
+
+	if conf.Name == "" {
+		conf.Name = namespaceName(r)
+	}
+	return &conf, nil
+}
+
+func (mc moduleConfig) validate() error {
+	if errs := validation.IsDNS1123Label(mc.Name); len(errs) > 0 {
+		return errors.ErrInvalid.WithMsgf("invalid namespace name '%s': %s", mc.Name, strings.Join(errs, ", "))
+	}
+
+	for key, val := range mc.Labels {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return errors.ErrInvalid.WithMsgf("invalid label key '%s': %s", key, strings.Join(errs, ", "))
+		} else if errs := validation.IsValidLabelValue(val); len(errs) > 0 {
+			return errors.ErrInvalid.WithMsgf("invalid value for label '%s': %s", key, strings.Join(errs, ", "))
+		}
+	}
+
+	quantities := map[string]map[string]string{"quota": mc.Quota}
+	if mc.Limits != nil {
+		quantities["limits.default"] = mc.Limits.Default
+		quantities["limits.default_request"] = mc.Limits.DefaultRequest
+		quantities["limits.max"] = mc.Limits.Max
+		quantities["limits.min"] = mc.Limits.Min
+	}
+	for field, values := range quantities {
+		for name, val := range values {
+			if _, err := kuberesource.ParseQuantity(val); err != nil {
+				return errors.ErrInvalid.WithMsgf("invalid quantity '%s' for '%s' in %s", val, name, field)
+			}
+		}
+	}
+	return nil
+}
+
+// objects returns the kubernetes objects for the namespace. The namespace
+// is listed first so that it is created before the objects inside it.
+func (mc moduleConfig) objects() []map[string]interface{} {
+	labels := map[string]interface{}{}
+	for key, val := range mc.Labels {
+		labels[key] = val
+	}
+	labels[labelManagedBy] = fieldManager
+
+	objs := []map[string]interface{}{
+		{
+			"apiVersion": "v1",
+			"kind":       "Namespace",
+			"metadata": map[string]interface{}{
+				"name":   mc.Name,
+				"labels": labels,
+			},
+		},
+	}
+
+	if len(mc.Quota) > 0 {
+		objs = append(objs, map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ResourceQuota",
+			"metadata":   mc.objectMeta(),
+			"spec": map[string]interface{}{
+				"hard": toObject(mc.Quota),
+			},
+		})
+	}
+
+	if mc.Limits != nil {
+		item := map[string]interface{}{"type": "Container"}
+		for key, values := range map[string]map[string]string{
+			"default":        mc.Limits.Default,
+			"defaultRequest": mc.Limits.DefaultRequest,
+			"max":            mc.Limits.Max,
+			"min":            mc.Limits.Min,
+		} {
+			if len(values) > 0 {
+				item[key] = toObject(values)
+			}
+		}
+
+		objs = append(objs, map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "LimitRange",
+			"metadata":   mc.objectMeta(),
+			"spec": map[string]interface{}{
+				"limits": []interface{}{item},
+			},
+		})
+	}
+	return objs
+}
+
+func (mc moduleConfig) namespaceRef() kube.ObjectRef {
+	return kube.ObjectRef{APIVersion: "v1", Kind: "Namespace", Name: mc.Name}
+}
+
+func (mc moduleConfig) objectMeta() map[string]interface{} {
+	return map[string]interface{}{
+		"name":      objectName,
+		"namespace": mc.Name,
+	}
+}
+
+func (mc moduleConfig) JSON() []byte {
+	b, err := json.Marshal(mc)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// namespaceName returns '<project>-<name>' turned into a valid DNS label.
+func namespaceName(r resource.Resource) string {
+	name := invalidLabelChars.ReplaceAllString(strings.ToLower(fmt.Sprintf("%s-%s", r.Project, r.Name)), "-")
+	if len(name) > validation.DNS1123LabelMaxLength {
+		name = name[:validation.DNS1123LabelMaxLength]
+	}
+	return strings.Trim(name, "-")
+}
+
+func toObject(m map[string]string) map[string]interface{} {
+	obj := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		obj[k] = v
+	}
+	return obj
+}
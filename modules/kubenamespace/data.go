@@ -0,0 +1,50 @@
+package kubenamespace
+
+import (
+	"encoding/json"
+
+	"github.com/odpf/entropy/pkg/kube"
+)
+
+type moduleData struct {
+	PendingSteps []string `json:"pending_steps"`
+
+	// Applied is the set of objects applied to the cluster by the last
+	// successful sync.
+	Applied []kube.ObjectRef `json:"applied,omitempty"`
+}
+
+func (md moduleData) JSON() json.RawMessage {
+	bytes, err := json.Marshal(md)
+	if err != nil {
+		panic(err)
+	}
+	return bytes
+}
+
+func readModuleData(data json.RawMessage) (*moduleData, error) {
+	var md moduleData
+	if len(data) == 0 {
+		return &md, nil
+	}
+	if err := json.Unmarshal(data, &md); err != nil {
+		return nil, err
+	}
+	return &md, nil
+}
+
+// subtract returns the refs in 'from' that are not in 'refs'.
+func subtract(from, refs []kube.ObjectRef) []kube.ObjectRef {
+	exclude := map[kube.ObjectRef]bool{}
+	for _, ref := range refs {
+		exclude[ref] = true
+	}
+
+	var res []kube.ObjectRef
+	for _, ref := range from {
+		if !exclude[ref] {
+			res = append(res, ref)
+		}
+	}
+	return res
+}
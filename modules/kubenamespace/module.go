@@ -0,0 +1,65 @@
+package kubenamespace
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/modules/kubernetes"
+	"github.com/odpf/entropy/pkg/kube"
+)
+
+const (
+	stepApply  = "namespace_apply"
+	stepDelete = "namespace_delete"
+)
+
+const keyKubeDependency = "kube_cluster"
+
+// fieldManager is the field manager used for server-side apply.
+const fieldManager = "entropy"
+
+//go:embed schema/config.json
+var configSchema string
+
+var Module = module.Descriptor{
+	Kind: "kube_namespace",
+	Dependencies: map[string]string{
+		keyKubeDependency: kubernetes.Module.Kind,
+	},
+	Actions: []module.ActionDesc{
+		{
+			Name:        module.CreateAction,
+			Description: "Creates the namespace with its quota & limits.",
+			ParamSchema: configSchema,
+		},
+		{
+			Name:        module.UpdateAction,
+			Description: "Updates the labels, quota & limits of the namespace.",
+			ParamSchema: configSchema,
+		},
+		{
+			Name:        module.DeleteAction,
+			Description: "Deletes the namespace and everything in it.",
+		},
+	},
+	DriverFactory: func(conf json.RawMessage) (module.Driver, error) {
+		return &namespaceModule{
+			kubeClient: func(kubeConf kube.Config) kubeClient {
+				return kube.NewClient(kubeConf)
+			},
+		}, nil
+	},
+}
+
+// kubeClient is the subset of kube.Client used by the module.
+type kubeClient interface {
+	ApplyObjects(ctx context.Context, fieldManager, defaultNamespace string, objs []map[string]interface{}) ([]kube.ObjectRef, error)
+	DeleteObjects(ctx context.Context, refs []kube.ObjectRef) error
+	GetObjectStatuses(ctx context.Context, refs []kube.ObjectRef) ([]kube.ObjectStatus, error)
+}
+
+type namespaceModule struct {
+	kubeClient func(kubeConf kube.Config) kubeClient
+}
@@ -0,0 +1,55 @@
+package kubenamespace
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/modules/kubernetes"
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/kube"
+)
+
+type Output struct {
+	Namespace string              `json:"namespace"`
+	Objects   []kube.ObjectStatus `json:"objects,omitempty"`
+}
+
+func newOutput(namespace string, statuses []kube.ObjectStatus) Output {
+	return Output{Namespace: namespace, Objects: statuses}
+}
+
+func (out Output) JSON() []byte {
+	b, err := json.Marshal(out)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (m *namespaceModule) Output(ctx context.Context, res module.ExpandedResource) (json.RawMessage, error) {
+	data, err := readModuleData(res.Resource.State.ModuleData)
+	if err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid module data: %v", err)
+	} else if len(data.Applied) == 0 {
+		return res.Resource.State.Output, nil
+	}
+
+	var out Output
+	if err := json.Unmarshal(res.Resource.State.Output, &out); err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid output json: %v", err)
+	}
+
+	return module.LiveOutput(ctx, res, func(ctx context.Context) (json.RawMessage, error) {
+		kubeOut, err := kubernetes.ClusterOutput(res, keyKubeDependency)
+		if err != nil {
+			return nil, err
+		}
+
+		statuses, err := m.kubeClient(kubeOut.Configs).GetObjectStatuses(ctx, data.Applied)
+		if err != nil {
+			return nil, err
+		}
+		return newOutput(out.Namespace, statuses).JSON(), nil
+	}), nil
+}
@@ -0,0 +1,92 @@
+package kubenamespace
+
+import (
+	"context"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+func (m *namespaceModule) Plan(_ context.Context, res module.ExpandedResource, act module.ActionRequest) (*module.Plan, error) {
+	switch act.Name {
+	case module.CreateAction:
+		return m.planCreate(res, act)
+	case module.UpdateAction:
+		return m.planUpdate(res, act)
+	case module.DeleteAction:
+		return m.planDelete(res)
+	default:
+		return nil, errors.ErrInvalid.WithMsgf("action '%s' not supported", act.Name)
+	}
+}
+
+func (*namespaceModule) planCreate(res module.ExpandedResource, act module.ActionRequest) (*module.Plan, error) {
+	r := res.Resource
+
+	conf, err := readConfig(r, act.Params)
+	if err != nil {
+		return nil, err
+	} else if err := conf.validate(); err != nil {
+		return nil, err
+	}
+
+	r.Spec.Configs = conf.JSON()
+	r.State = resource.State{
+		Status: resource.StatusPending,
+		Output: Output{Namespace: conf.Name}.JSON(),
+		ModuleData: moduleData{
+			PendingSteps: []string{stepApply},
+		}.JSON(),
+	}
+	return &module.Plan{Resource: r, Reason: "kube namespace created"}, nil
+}
+
+func (*namespaceModule) planUpdate(res module.ExpandedResource, act module.ActionRequest) (*module.Plan, error) {
+	r := res.Resource
+
+	curConf, err := readConfig(r, r.Spec.Configs)
+	if err != nil {
+		return nil, err
+	}
+
+	conf, err := readConfig(r, act.Params)
+	if err != nil {
+		return nil, err
+	} else if err := conf.validate(); err != nil {
+		return nil, err
+	} else if conf.Name != curConf.Name {
+		return nil, errors.ErrInvalid.WithMsgf("name of a kube namespace cannot be changed")
+	}
+
+	data, err := readModuleData(r.State.ModuleData)
+	if err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid module data: %v", err)
+	}
+	data.PendingSteps = []string{stepApply}
+
+	r.Spec.Configs = conf.JSON()
+	r.State = resource.State{
+		Status:     resource.StatusPending,
+		Output:     r.State.Output,
+		ModuleData: data.JSON(),
+	}
+	return &module.Plan{Resource: r, Reason: "kube namespace updated"}, nil
+}
+
+func (*namespaceModule) planDelete(res module.ExpandedResource) (*module.Plan, error) {
+	r := res.Resource
+
+	data, err := readModuleData(r.State.ModuleData)
+	if err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid module data: %v", err)
+	}
+	data.PendingSteps = []string{stepDelete}
+
+	r.State = resource.State{
+		Status:     resource.StatusDeleted,
+		Output:     r.State.Output,
+		ModuleData: data.JSON(),
+	}
+	return &module.Plan{Resource: r, Reason: "kube namespace deleted"}, nil
+}
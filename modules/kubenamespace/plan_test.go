@@ -0,0 +1,107 @@
+package kubenamespace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+func TestNamespaceModule_Plan(t *testing.T) {
+	t.Parallel()
+
+	res := resource.Resource{
+		URN:     "orn:entropy:kube_namespace:Demo_X:team",
+		Kind:    "kube_namespace",
+		Name:    "team",
+		Project: "Demo_X",
+		Spec: resource.Spec{
+			Configs: []byte(`{"name":"demo-x-team","quota":{"cpu":"4"}}`),
+		},
+		State: resource.State{
+			Status:     resource.StatusCompleted,
+			Output:     []byte(`{"namespace":"demo-x-team"}`),
+			ModuleData: []byte(`{"pending_steps":[],"applied":[{"api_version":"v1","kind":"Namespace","name":"demo-x-team"}]}`),
+		},
+	}
+
+	table := []struct {
+		title      string
+		act        module.ActionRequest
+		wantConfig string
+		wantOutput string
+		wantStatus string
+		wantData   string
+		wantErr    error
+	}{
+		{
+			title:   "InvalidConfiguration",
+			act:     module.ActionRequest{Name: module.CreateAction, Params: []byte(`{`)},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title:   "InvalidQuantity",
+			act:     module.ActionRequest{Name: module.CreateAction, Params: []byte(`{"quota":{"cpu":"four"}}`)},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title:   "InvalidLabel",
+			act:     module.ActionRequest{Name: module.CreateAction, Params: []byte(`{"labels":{"team":"a b"}}`)},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title:      "CreateWithDefaultName",
+			act:        module.ActionRequest{Name: module.CreateAction, Params: []byte(`{"labels":{"team":"data"}}`)},
+			wantConfig: `{"name":"demo-x-team","labels":{"team":"data"}}`,
+			wantOutput: `{"namespace":"demo-x-team"}`,
+			wantStatus: resource.StatusPending,
+			wantData:   `{"pending_steps":["namespace_apply"]}`,
+		},
+		{
+			title:   "ChangeName",
+			act:     module.ActionRequest{Name: module.UpdateAction, Params: []byte(`{"name":"other"}`)},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title:      "Update",
+			act:        module.ActionRequest{Name: module.UpdateAction, Params: []byte(`{"limits":{"default":{"cpu":"500m"}}}`)},
+			wantConfig: `{"name":"demo-x-team","limits":{"default":{"cpu":"500m"}}}`,
+			wantOutput: `{"namespace":"demo-x-team"}`,
+			wantStatus: resource.StatusPending,
+			wantData:   `{"pending_steps":["namespace_apply"],"applied":[{"api_version":"v1","kind":"Namespace","name":"demo-x-team"}]}`,
+		},
+		{
+			title:      "Delete",
+			act:        module.ActionRequest{Name: module.DeleteAction},
+			wantOutput: `{"namespace":"demo-x-team"}`,
+			wantStatus: resource.StatusDeleted,
+			wantData:   `{"pending_steps":["namespace_delete"],"applied":[{"api_version":"v1","kind":"Namespace","name":"demo-x-team"}]}`,
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := (&namespaceModule{}).Plan(context.Background(), module.ExpandedResource{Resource: res}, tt.act)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, got.Resource.State.Status)
+			assert.JSONEq(t, tt.wantData, string(got.Resource.State.ModuleData))
+			assert.JSONEq(t, tt.wantOutput, string(got.Resource.State.Output))
+			if tt.wantConfig != "" {
+				assert.JSONEq(t, tt.wantConfig, string(got.Resource.Spec.Configs))
+			}
+		})
+	}
+}
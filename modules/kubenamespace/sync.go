@@ -0,0 +1,95 @@
+package kubenamespace
+
+import (
+	"context"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/modules/kubernetes"
+	"github.com/odpf/entropy/pkg/kube"
+	"github.com/odpf/entropy/pkg/worker"
+)
+
+const kubeAPIRetryBackoffDuration = 30 * time.Second
+
+var ErrKubeAPI = worker.RetryableError{RetryAfter: kubeAPIRetryBackoffDuration}
+
+func (m *namespaceModule) Sync(ctx context.Context, res module.ExpandedResource) (*resource.State, error) {
+	r := res.Resource
+
+	data, err := readModuleData(r.State.ModuleData)
+	if err != nil {
+		return nil, err
+	}
+
+	var pendingStep string
+	if len(data.PendingSteps) != 0 {
+		pendingStep = data.PendingSteps[0]
+		data.PendingSteps = data.PendingSteps[1:]
+	}
+
+	conf, err := readConfig(r, r.Spec.Configs)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeOut, err := kubernetes.ClusterOutput(res, keyKubeDependency)
+	if err != nil {
+		return nil, err
+	}
+	kubeCl := m.kubeClient(kubeOut.Configs)
+
+	switch pendingStep {
+	case stepApply:
+		applied, err := kubeCl.ApplyObjects(ctx, fieldManager, conf.Name, conf.objects())
+		if err != nil {
+			if apierrors.IsInvalid(err) || apierrors.IsBadRequest(err) {
+				// e.g., unknown resource names in quota. retrying will not
+				// help until the config is fixed.
+				data.Applied = append(data.Applied, subtract(applied, data.Applied)...)
+				return &resource.State{
+					Status:     resource.StatusError,
+					Output:     r.State.Output,
+					ModuleData: data.JSON(),
+				}, nil
+			}
+			return nil, ErrKubeAPI.WithCause(err)
+		}
+
+		// quota or limits removed by an update.
+		if err := kubeCl.DeleteObjects(ctx, subtract(data.Applied, applied)); err != nil {
+			return nil, ErrKubeAPI.WithCause(err)
+		}
+		data.Applied = applied
+
+	case stepDelete:
+		// deleting the namespace deletes everything in it.
+		if err := kubeCl.DeleteObjects(ctx, []kube.ObjectRef{conf.namespaceRef()}); err != nil {
+			return nil, ErrKubeAPI.WithCause(err)
+		}
+		return &resource.State{
+			Status:     resource.StatusCompleted,
+			Output:     r.State.Output,
+			ModuleData: data.JSON(),
+		}, nil
+	}
+
+	statuses, err := kubeCl.GetObjectStatuses(ctx, data.Applied)
+	if err != nil {
+		return nil, ErrKubeAPI.WithCause(err)
+	}
+
+	finalStatus := resource.StatusCompleted
+	if len(data.PendingSteps) > 0 {
+		finalStatus = resource.StatusPending
+	}
+
+	return &resource.State{
+		Status:     finalStatus,
+		Output:     newOutput(conf.Name, statuses).JSON(),
+		ModuleData: data.JSON(),
+	}, nil
+}
@@ -0,0 +1,122 @@
+package kubenamespace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/kube"
+	"github.com/odpf/entropy/pkg/kube/kubetest"
+	"github.com/odpf/entropy/pkg/worker"
+)
+
+func TestNamespaceModule_Sync(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ns         = kube.ObjectRef{APIVersion: "v1", Kind: "Namespace", Name: "team"}
+		quota      = kube.ObjectRef{APIVersion: "v1", Kind: "ResourceQuota", Namespace: "team", Name: "entropy"}
+		limitRange = kube.ObjectRef{APIVersion: "v1", Kind: "LimitRange", Namespace: "team", Name: "entropy"}
+	)
+
+	newRes := func(configs, moduleData string) module.ExpandedResource {
+		return module.ExpandedResource{
+			Resource: resource.Resource{
+				URN:     "orn:entropy:kube_namespace:demo:team",
+				Kind:    "kube_namespace",
+				Name:    "team",
+				Project: "demo",
+				Spec:    resource.Spec{Configs: []byte(configs)},
+				State: resource.State{
+					Status:     resource.StatusPending,
+					Output:     []byte(`{"namespace":"team"}`),
+					ModuleData: []byte(moduleData),
+				},
+			},
+			Dependencies: map[string]module.ResolvedDependency{
+				keyKubeDependency: {Kind: "kubernetes", Output: []byte(`{"configs":{}}`)},
+			},
+		}
+	}
+
+	table := []struct {
+		title       string
+		cluster     *kubetest.Cluster
+		res         module.ExpandedResource
+		wantStatus  string
+		wantApplied []kube.ObjectRef
+		wantDeleted []kube.ObjectRef
+		wantErr     bool
+	}{
+		{
+			title:       "ApplyWithQuotaAndLimits",
+			cluster:     &kubetest.Cluster{},
+			res:         newRes(`{"name":"team","labels":{"team":"data"},"quota":{"cpu":"4"},"limits":{"default":{"cpu":"500m"}}}`, `{"pending_steps":["namespace_apply"]}`),
+			wantStatus:  resource.StatusCompleted,
+			wantApplied: []kube.ObjectRef{ns, quota, limitRange},
+		},
+		{
+			title:       "PruneRemovedQuota",
+			cluster:     &kubetest.Cluster{},
+			res:         newRes(`{"name":"team"}`, `{"pending_steps":["namespace_apply"],"applied":[{"api_version":"v1","kind":"Namespace","name":"team"},{"api_version":"v1","kind":"ResourceQuota","namespace":"team","name":"entropy"}]}`),
+			wantStatus:  resource.StatusCompleted,
+			wantApplied: []kube.ObjectRef{ns},
+			wantDeleted: []kube.ObjectRef{quota},
+		},
+		{
+			title: "InvalidQuota",
+			cluster: &kubetest.Cluster{
+				Rejected: map[string]error{"ResourceQuota": apierrors.NewInvalid(schema.GroupKind{Kind: "ResourceQuota"}, "entropy", nil)},
+			},
+			res:        newRes(`{"name":"team","quota":{"unknown":"1"}}`, `{"pending_steps":["namespace_apply"]}`),
+			wantStatus: resource.StatusError,
+		},
+		{
+			title:       "Delete",
+			cluster:     &kubetest.Cluster{},
+			res:         newRes(`{"name":"team","quota":{"cpu":"4"}}`, `{"pending_steps":["namespace_delete"]}`),
+			wantStatus:  resource.StatusCompleted,
+			wantDeleted: []kube.ObjectRef{ns},
+		},
+		{
+			title:   "ClusterUnreachable",
+			cluster: &kubetest.Cluster{Err: assert.AnError},
+			res:     newRes(`{"name":"team"}`, `{"pending_steps":["namespace_apply"]}`),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			m := &namespaceModule{
+				kubeClient: func(_ kube.Config) kubeClient { return tt.cluster },
+			}
+
+			got, err := m.Sync(context.Background(), tt.res)
+			if tt.wantErr {
+				var retryErr *worker.RetryableError
+				assert.ErrorAs(t, err, &retryErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, got.Status)
+			assert.Equal(t, tt.wantDeleted, tt.cluster.Deleted)
+
+			if tt.wantApplied != nil {
+				data, err := readModuleData(got.ModuleData)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantApplied, data.Applied)
+				assert.Contains(t, tt.cluster.Objects, ns)
+			}
+		})
+	}
+}
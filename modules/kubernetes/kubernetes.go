@@ -14,11 +14,17 @@ import (
 	"github.com/odpf/entropy/pkg/kube"
 )
 
-//go:embed config_schema.json
-var configSchema string
+var (
+	//go:embed config_schema.json
+	configSchema string
+
+	//go:embed output_schema.json
+	outputSchema string
+)
 
 var Module = module.Descriptor{
-	Kind: "kubernetes",
+	Kind:         "kubernetes",
+	OutputSchema: outputSchema,
 	Actions: []module.ActionDesc{
 		{
 			Name:        module.CreateAction,
@@ -89,6 +95,16 @@ func (*kubeModule) Output(_ context.Context, res module.ExpandedResource) (json.
 	}.JSON(), nil
 }
 
+// ClusterOutput returns the output of the kubernetes resource that res
+// depends on using the given dependency key.
+func ClusterOutput(res module.ExpandedResource, key string) (*Output, error) {
+	var out Output
+	if err := json.Unmarshal(res.Dependencies[key].Output, &out); err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid %s output: %v", key, err)
+	}
+	return &out, nil
+}
+
 func (out Output) JSON() []byte {
 	b, err := json.Marshal(out)
 	if err != nil {
@@ -0,0 +1,453 @@
+package plugin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+const (
+	handshakeTimeout = 10 * time.Second
+	validateTimeout  = 10 * time.Second
+
+	minRestartBackoff = 1 * time.Second
+	maxRestartBackoff = 1 * time.Minute
+)
+
+// Client manages a plugin process and provides the module descriptor for
+// the driver it serves.
+type Client struct {
+	path string
+	desc module.Descriptor
+
+	mu     sync.RWMutex
+	proc   *process
+	closed bool
+}
+
+// process is a running instance of the plugin executable.
+type process struct {
+	cmd    *exec.Cmd
+	stdin  io.Closer
+	conn   *grpc.ClientConn
+	exited chan struct{}
+	err    error // exit error, set before exited is closed.
+}
+
+// Load starts the plugin executable at path, performs the handshake and
+// returns a client for it. The plugin process exits when the client is
+// closed or the current process exits.
+func Load(ctx context.Context, path string) (*Client, error) {
+	proc, resp, err := start(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{path: path, proc: proc}
+	c.desc = c.descriptor(*resp)
+	return c, nil
+}
+
+// LoadDir loads all the executables in the directory as plugins.
+func LoadDir(ctx context.Context, dir string) ([]*Client, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var clients []*Client
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		} else if !info.Mode().IsRegular() || info.Mode().Perm()&0o111 == 0 {
+			continue
+		}
+
+		c, err := Load(ctx, filepath.Join(dir, entry.Name()))
+		if err != nil {
+			for _, loaded := range clients {
+				_ = loaded.Close()
+			}
+			return nil, err
+		}
+		clients = append(clients, c)
+	}
+	return clients, nil
+}
+
+// Descriptor returns the module descriptor for the plugin. DriverFactory of
+// the descriptor returns drivers that delegate to the plugin.
+func (c *Client) Descriptor() module.Descriptor { return c.desc }
+
+// Supervise restarts the plugin process whenever it exits, until ctx is
+// cancelled or the client is closed. Calls to the plugin fail while it is
+// not running. onExit (if not nil) is invoked with the reason every time
+// the process exits or fails to restart.
+func (c *Client) Supervise(ctx context.Context, onExit func(err error)) {
+	notify := func(err error) {
+		if onExit != nil {
+			onExit(err)
+		}
+	}
+
+	backoff := minRestartBackoff
+	for {
+		if proc := c.running(); proc != nil {
+			select {
+			case <-ctx.Done():
+				return
+
+			case <-proc.exited:
+				if !c.detach(proc) {
+					return
+				}
+				notify(fmt.Errorf("plugin '%s' exited: %v", c.path, proc.err))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-time.After(backoff):
+		}
+
+		proc, err := c.restart(ctx)
+		if err != nil {
+			notify(err)
+			backoff *= 2
+			if backoff > maxRestartBackoff {
+				backoff = maxRestartBackoff
+			}
+			continue
+		} else if proc == nil {
+			return
+		}
+		backoff = minRestartBackoff
+	}
+}
+
+// Close terminates the plugin process.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	proc := c.proc
+	c.proc = nil
+	c.closed = true
+	c.mu.Unlock()
+
+	if proc == nil {
+		return nil
+	}
+	return proc.close()
+}
+
+func (c *Client) running() *process {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.proc
+}
+
+// detach marks the plugin as not running after proc has exited. Returns
+// false if the client was closed.
+func (c *Client) detach(proc *process) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.proc == proc {
+		c.proc = nil
+	}
+	_ = proc.conn.Close()
+	return !c.closed
+}
+
+// restart starts a new plugin process. Returns nil process if the client
+// was closed in the meantime.
+func (c *Client) restart(ctx context.Context) (*process, error) {
+	proc, resp, err := start(ctx, c.path)
+	if err != nil {
+		return nil, err
+	} else if resp.Kind != c.desc.Kind {
+		_ = proc.close()
+		return nil, fmt.Errorf("plugin '%s' now serves kind '%s', expected '%s'", c.path, resp.Kind, c.desc.Kind)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		_ = proc.close()
+		return nil, nil
+	}
+	c.proc = proc
+	return proc, nil
+}
+
+func (c *Client) invoke(ctx context.Context, method string, req, resp interface{}) error {
+	proc := c.running()
+	if proc == nil {
+		return errors.ErrInternal.WithCausef("plugin '%s' is not running", c.path)
+	}
+	return fromStatus(proc.conn.Invoke(ctx, fullMethod(method), req, resp))
+}
+
+func (c *Client) descriptor(resp describeResponse) module.Descriptor {
+	validated := &sync.Map{}
+	return module.Descriptor{
+		Kind:         resp.Kind,
+		Actions:      resp.Actions,
+		Dependencies: resp.Dependencies,
+		OutputSchema: resp.OutputSchema,
+		DriverFactory: func(conf json.RawMessage) (module.Driver, error) {
+			key := string(conf)
+			if _, ok := validated.Load(key); !ok {
+				ctx, cancel := context.WithTimeout(context.Background(), validateTimeout)
+				defer cancel()
+
+				req := &validateRequest{Configs: conf}
+				if err := c.invoke(ctx, "Validate", req, &validateResponse{}); err != nil {
+					return nil, err
+				}
+				validated.Store(key, true)
+			}
+			return &driverClient{client: c, configs: conf}, nil
+		},
+	}
+}
+
+// start starts the plugin executable at path, and returns the process
+// along with the description of the module it serves.
+func start(ctx context.Context, path string) (*process, *describeResponse, error) {
+	cmd := exec.Command(path) // nolint
+	cmd.Env = append(os.Environ(), cookieKey+"="+cookieValue)
+	cmd.Stderr = os.Stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stdoutPipe, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, nil, fmt.Errorf("failed to start plugin '%s': %w", path, err)
+	}
+
+	stdout := bufio.NewReader(stdoutPipe)
+	addr, err := readHandshake(stdout, handshakeTimeout)
+	if err != nil {
+		_ = stdin.Close()
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return nil, nil, fmt.Errorf("handshake with plugin '%s' failed: %w", path, err)
+	}
+
+	proc := &process{cmd: cmd, stdin: stdin, exited: make(chan struct{})}
+
+	// anything else the plugin writes to stdout is treated as logs. the
+	// same reader is used since it may have buffered some of it already.
+	go func() {
+		_, _ = io.Copy(os.Stderr, stdout)
+		proc.err = cmd.Wait()
+		close(proc.exited)
+	}()
+
+	conn, err := grpc.DialContext(ctx, addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(jsonCodec{})),
+	)
+	if err != nil {
+		_ = proc.close()
+		return nil, nil, err
+	}
+	proc.conn = conn
+
+	resp, err := describe(ctx, conn)
+	if err != nil {
+		_ = proc.close()
+		return nil, nil, fmt.Errorf("failed to describe plugin '%s': %w", path, err)
+	}
+	return proc, resp, nil
+}
+
+func (proc *process) close() error {
+	if proc.conn != nil {
+		_ = proc.conn.Close()
+	}
+	_ = proc.stdin.Close()
+
+	select {
+	case <-proc.exited:
+		return nil
+
+	case <-time.After(handshakeTimeout):
+		return proc.cmd.Process.Kill()
+	}
+}
+
+func readHandshake(r *bufio.Reader, timeout time.Duration) (string, error) {
+	type result struct {
+		line string
+		err  error
+	}
+
+	lineCh := make(chan result, 1)
+	go func() {
+		line, err := r.ReadString('\n')
+		lineCh <- result{line: line, err: err}
+	}()
+
+	select {
+	case <-time.After(timeout):
+		return "", fmt.Errorf("timed out after %s", timeout)
+
+	case res := <-lineCh:
+		if res.err != nil {
+			return "", res.err
+		}
+		return parseHandshake(res.line)
+	}
+}
+
+func parseHandshake(line string) (string, error) {
+	parts := strings.Split(strings.TrimSpace(line), "|")
+	if len(parts) != 4 || parts[0] != handshakePrefix {
+		return "", fmt.Errorf("unexpected handshake line '%s'", strings.TrimSpace(line))
+	}
+
+	version, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("invalid protocol version '%s'", parts[1])
+	} else if version != ProtocolVersion {
+		return "", fmt.Errorf("plugin speaks protocol version %d, expected %d", version, ProtocolVersion)
+	} else if parts[2] != "tcp" {
+		return "", fmt.Errorf("unsupported network '%s'", parts[2])
+	}
+	return parts[3], nil
+}
+
+func describe(ctx context.Context, conn *grpc.ClientConn) (*describeResponse, error) {
+	var resp describeResponse
+	if err := conn.Invoke(ctx, fullMethod("Describe"), &describeRequest{}, &resp); err != nil {
+		return nil, fromStatus(err)
+	} else if resp.ProtocolVersion != ProtocolVersion {
+		return nil, fmt.Errorf("plugin speaks protocol version %d, expected %d",
+			resp.ProtocolVersion, ProtocolVersion)
+	}
+	return &resp, nil
+}
+
+// driverClient implements module.Driver & module.Loggable by delegating to
+// the plugin. Plugins that do not support logs return ErrUnsupported.
+type driverClient struct {
+	client  *Client
+	configs json.RawMessage
+}
+
+func (dc *driverClient) Plan(ctx context.Context, res module.ExpandedResource, act module.ActionRequest) (*module.Plan, error) {
+	var plan module.Plan
+	if err := dc.invoke(ctx, "Plan", dc.request(res, act), &plan); err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+func (dc *driverClient) Sync(ctx context.Context, res module.ExpandedResource) (*resource.State, error) {
+	var state resource.State
+	if err := dc.invoke(ctx, "Sync", dc.request(res, module.ActionRequest{}), &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+func (dc *driverClient) Output(ctx context.Context, res module.ExpandedResource) (json.RawMessage, error) {
+	var resp outputResponse
+	if err := dc.invoke(ctx, "Output", dc.request(res, module.ActionRequest{}), &resp); err != nil {
+		return nil, err
+	}
+	return resp.Output, nil
+}
+
+func (dc *driverClient) Log(ctx context.Context, res module.ExpandedResource, filter map[string]string) (<-chan module.LogChunk, error) {
+	proc := dc.client.running()
+	if proc == nil {
+		return nil, errors.ErrInternal.WithCausef("plugin '%s' is not running", dc.client.path)
+	}
+
+	streamDesc := &grpc.StreamDesc{StreamName: "Log", ServerStreams: true}
+	stream, err := proc.conn.NewStream(ctx, streamDesc, fullMethod("Log"))
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+
+	req := dc.request(res, module.ActionRequest{})
+	req.Filter = filter
+	if err := stream.SendMsg(req); err != nil {
+		return nil, fromStatus(err)
+	} else if err := stream.CloseSend(); err != nil {
+		return nil, fromStatus(err)
+	}
+
+	// receive the first chunk eagerly so that errors (e.g., unsupported)
+	// are returned to the caller directly.
+	var first module.LogChunk
+	if err := stream.RecvMsg(&first); err != nil {
+		if errors.Is(err, io.EOF) {
+			ch := make(chan module.LogChunk)
+			close(ch)
+			return ch, nil
+		}
+		return nil, fromStatus(err)
+	}
+
+	ch := make(chan module.LogChunk)
+	go func() {
+		defer close(ch)
+
+		chunk := first
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ch <- chunk:
+			}
+
+			chunk = module.LogChunk{}
+			if err := stream.RecvMsg(&chunk); err != nil {
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (dc *driverClient) request(res module.ExpandedResource, act module.ActionRequest) *driverRequest {
+	return &driverRequest{
+		Configs:  dc.configs,
+		Resource: res,
+		Secrets:  res.Secrets,
+		Action:   act,
+	}
+}
+
+func (dc *driverClient) invoke(ctx context.Context, method string, req, resp interface{}) error {
+	return dc.client.invoke(ctx, method, req, resp)
+}
@@ -0,0 +1,238 @@
+package plugin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+// servePluginEnv makes the test binary act as a plugin serving testModule.
+const servePluginEnv = "ENTROPY_PLUGIN_TEST_SERVE"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(servePluginEnv) == "1" {
+		if err := Serve(testModule); err != nil {
+			log.Fatal(err)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+var testModule = module.Descriptor{
+	Kind: "echo",
+	Actions: []module.ActionDesc{
+		{Name: module.CreateAction, Description: "create an echo"},
+	},
+	Dependencies: map[string]string{"cluster": "kubernetes"},
+	DriverFactory: func(conf json.RawMessage) (module.Driver, error) {
+		var cfg struct {
+			Prefix string `json:"prefix"`
+		}
+		if err := json.Unmarshal(conf, &cfg); err != nil {
+			return nil, err
+		}
+		return &echoDriver{prefix: cfg.Prefix}, nil
+	},
+}
+
+type echoDriver struct {
+	prefix string
+}
+
+func (ed *echoDriver) Plan(_ context.Context, res module.ExpandedResource, act module.ActionRequest) (*module.Plan, error) {
+	if act.Name != module.CreateAction {
+		return nil, errors.ErrInvalid.WithMsgf("action '%s' not supported", act.Name)
+	}
+	res.Resource.Spec.Configs = act.Params
+	res.Resource.State = resource.State{Status: resource.StatusPending}
+	return &module.Plan{Resource: res.Resource, Reason: "echo"}, nil
+}
+
+func (ed *echoDriver) Sync(_ context.Context, res module.ExpandedResource) (*resource.State, error) {
+	output, _ := json.Marshal(map[string]string{"token": res.Secrets["token"]})
+	return &resource.State{Status: resource.StatusCompleted, Output: output}, nil
+}
+
+func (ed *echoDriver) Output(_ context.Context, res module.ExpandedResource) (json.RawMessage, error) {
+	return json.Marshal(map[string]string{"echo": ed.prefix + res.Name})
+}
+
+func (ed *echoDriver) Log(_ context.Context, _ module.ExpandedResource, filter map[string]string) (<-chan module.LogChunk, error) {
+	ch := make(chan module.LogChunk, 2)
+	ch <- module.LogChunk{Data: []byte("line-1"), Labels: filter}
+	ch <- module.LogChunk{Data: []byte("line-2"), Labels: filter}
+	close(ch)
+	return ch, nil
+}
+
+func TestLoad(t *testing.T) {
+	t.Setenv(servePluginEnv, "1")
+
+	ctx := context.Background()
+	c, err := Load(ctx, os.Args[0])
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, c.Close()) }()
+
+	desc := c.Descriptor()
+	assert.Equal(t, "echo", desc.Kind)
+	assert.Equal(t, testModule.Dependencies, desc.Dependencies)
+	require.Len(t, desc.Actions, 1)
+	assert.Equal(t, "create an echo", desc.Actions[0].Description)
+
+	t.Run("InvalidConfigs", func(t *testing.T) {
+		_, err := desc.DriverFactory(json.RawMessage(`[]`))
+		assert.True(t, errors.Is(err, errors.ErrInvalid))
+	})
+
+	driver, err := desc.DriverFactory(json.RawMessage(`{"prefix": "hello-"}`))
+	require.NoError(t, err)
+
+	res := module.ExpandedResource{
+		Resource: resource.Resource{URN: "orn:entropy:echo:foo:bar", Kind: "echo", Name: "bar", Project: "foo"},
+		Secrets:  map[string]string{"token": "s3cr3t"},
+	}
+
+	t.Run("Plan", func(t *testing.T) {
+		plan, err := driver.Plan(ctx, res, module.ActionRequest{
+			Name:   module.CreateAction,
+			Params: json.RawMessage(`{"a":1}`),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "echo", plan.Reason)
+		assert.Equal(t, resource.StatusPending, plan.Resource.State.Status)
+		assert.JSONEq(t, `{"a":1}`, string(plan.Resource.Spec.Configs))
+	})
+
+	t.Run("Plan_InvalidAction", func(t *testing.T) {
+		_, err := driver.Plan(ctx, res, module.ActionRequest{Name: "scale"})
+		assert.True(t, errors.Is(err, errors.ErrInvalid))
+		assert.Equal(t, "action 'scale' not supported", err.Error())
+	})
+
+	t.Run("Sync_WithSecrets", func(t *testing.T) {
+		state, err := driver.Sync(ctx, res)
+		require.NoError(t, err)
+		assert.Equal(t, resource.StatusCompleted, state.Status)
+		assert.JSONEq(t, `{"token":"s3cr3t"}`, string(state.Output))
+	})
+
+	t.Run("Output", func(t *testing.T) {
+		output, err := driver.Output(ctx, res)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"echo":"hello-bar"}`, string(output))
+	})
+
+	t.Run("Log", func(t *testing.T) {
+		lg, ok := driver.(module.Loggable)
+		require.True(t, ok)
+
+		chunks, err := lg.Log(ctx, res, map[string]string{"pod": "p1"})
+		require.NoError(t, err)
+
+		var lines []string
+		for chunk := range chunks {
+			assert.Equal(t, map[string]string{"pod": "p1"}, chunk.Labels)
+			lines = append(lines, string(chunk.Data))
+		}
+		assert.Equal(t, []string{"line-1", "line-2"}, lines)
+	})
+}
+
+func TestClient_Supervise(t *testing.T) {
+	t.Setenv(servePluginEnv, "1")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c, err := Load(ctx, os.Args[0])
+	require.NoError(t, err)
+
+	driver, err := c.Descriptor().DriverFactory(json.RawMessage(`{"prefix": "hello-"}`))
+	require.NoError(t, err)
+	res := module.ExpandedResource{Resource: resource.Resource{Name: "bar"}}
+
+	exits := make(chan error, 10)
+	go c.Supervise(ctx, func(err error) { exits <- err })
+
+	require.NoError(t, c.running().cmd.Process.Kill())
+	select {
+	case err := <-exits:
+		assert.Contains(t, err.Error(), "exited")
+	case <-time.After(5 * time.Second):
+		t.Fatal("exit of the plugin was not reported")
+	}
+
+	assert.Eventually(t, func() bool {
+		_, err := driver.Output(ctx, res)
+		return err == nil
+	}, 10*time.Second, 100*time.Millisecond)
+
+	require.NoError(t, c.Close())
+	_, err = driver.Output(ctx, res)
+	assert.True(t, errors.Is(err, errors.ErrInternal))
+}
+
+func TestReadHandshake(t *testing.T) {
+	t.Parallel()
+
+	r := bufio.NewReader(strings.NewReader("entropy-plugin|1|tcp|127.0.0.1:4000\nplugin started\n"))
+
+	addr, err := readHandshake(r, time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:4000", addr)
+
+	// output after the handshake line must not be lost to buffering.
+	rest, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "plugin started\n", string(rest))
+}
+
+func TestServe_NotFromEntropy(t *testing.T) {
+	t.Parallel()
+	assert.Error(t, Serve(testModule))
+}
+
+func TestParseHandshake(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		title   string
+		line    string
+		want    string
+		wantErr bool
+	}{
+		{title: "Valid", line: "entropy-plugin|1|tcp|127.0.0.1:4000\n", want: "127.0.0.1:4000"},
+		{title: "NotAPlugin", line: "hello world\n", wantErr: true},
+		{title: "VersionMismatch", line: "entropy-plugin|2|tcp|127.0.0.1:4000\n", wantErr: true},
+		{title: "UnsupportedNetwork", line: "entropy-plugin|1|unix|/tmp/plugin.sock\n", wantErr: true},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseHandshake(tt.line)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
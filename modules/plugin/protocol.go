@@ -0,0 +1,238 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+// ProtocolVersion is the version of the plugin protocol. Entropy refuses to
+// load plugins that speak a different version.
+const ProtocolVersion = 1
+
+const (
+	// cookieKey & cookieValue are set in the environment of the plugin
+	// process by Entropy. Serve refuses to run without these to prevent
+	// plugins from being executed directly.
+	cookieKey   = "ENTROPY_PLUGIN_COOKIE"
+	cookieValue = "f9b6e2d3-entropy-module-driver"
+
+	// handshakePrefix is the prefix of the first line a plugin writes to
+	// stdout: 'entropy-plugin|<version>|<network>|<address>'.
+	handshakePrefix = "entropy-plugin"
+
+	serviceName = "entropy.plugin.v1.Driver"
+)
+
+type describeRequest struct{}
+
+type describeResponse struct {
+	ProtocolVersion int                 `json:"protocol_version"`
+	Kind            string              `json:"kind"`
+	Actions         []module.ActionDesc `json:"actions"`
+	Dependencies    map[string]string   `json:"dependencies"`
+	OutputSchema    string              `json:"output_schema"`
+}
+
+type validateRequest struct {
+	Configs json.RawMessage `json:"configs"`
+}
+
+type validateResponse struct{}
+
+// driverRequest is the request for all driver methods. Configs are the
+// module configs the driver should be initialised with.
+type driverRequest struct {
+	Configs  json.RawMessage         `json:"configs"`
+	Resource module.ExpandedResource `json:"resource"`
+	Secrets  map[string]string       `json:"secrets,omitempty"`
+	Action   module.ActionRequest    `json:"action"`
+	Filter   map[string]string       `json:"filter,omitempty"`
+}
+
+type outputResponse struct {
+	Output json.RawMessage `json:"output"`
+}
+
+// driverServer is implemented by the plugin side of the protocol. It
+// mirrors module.Driver & module.Loggable, with Describe & Validate for
+// the handshake and module config validation.
+type driverServer interface {
+	Describe(ctx context.Context, req *describeRequest) (*describeResponse, error)
+	Validate(ctx context.Context, req *validateRequest) (*validateResponse, error)
+	Plan(ctx context.Context, req *driverRequest) (*module.Plan, error)
+	Sync(ctx context.Context, req *driverRequest) (*resource.State, error)
+	Output(ctx context.Context, req *driverRequest) (*outputResponse, error)
+	Log(req *driverRequest, stream grpc.ServerStream) error
+}
+
+// jsonCodec encodes the messages of the plugin protocol as JSON, so that
+// the protocol does not need generated protobuf types.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error)      { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error { return json.Unmarshal(data, v) }
+func (jsonCodec) Name() string                               { return "json" }
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*driverServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Describe",
+			Handler: unaryHandler("Describe", func(srv driverServer, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+				var req describeRequest
+				if err := dec(&req); err != nil {
+					return nil, err
+				}
+				return srv.Describe(ctx, &req)
+			}),
+		},
+		{
+			MethodName: "Validate",
+			Handler: unaryHandler("Validate", func(srv driverServer, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+				var req validateRequest
+				if err := dec(&req); err != nil {
+					return nil, err
+				}
+				return srv.Validate(ctx, &req)
+			}),
+		},
+		{
+			MethodName: "Plan",
+			Handler: unaryHandler("Plan", func(srv driverServer, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+				var req driverRequest
+				if err := dec(&req); err != nil {
+					return nil, err
+				}
+				return srv.Plan(ctx, &req)
+			}),
+		},
+		{
+			MethodName: "Sync",
+			Handler: unaryHandler("Sync", func(srv driverServer, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+				var req driverRequest
+				if err := dec(&req); err != nil {
+					return nil, err
+				}
+				return srv.Sync(ctx, &req)
+			}),
+		},
+		{
+			MethodName: "Output",
+			Handler: unaryHandler("Output", func(srv driverServer, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+				var req driverRequest
+				if err := dec(&req); err != nil {
+					return nil, err
+				}
+				return srv.Output(ctx, &req)
+			}),
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Log",
+			ServerStreams: true,
+			Handler: func(srv interface{}, stream grpc.ServerStream) error {
+				var req driverRequest
+				if err := stream.RecvMsg(&req); err != nil {
+					return err
+				}
+				return srv.(driverServer).Log(&req, stream)
+			},
+		},
+	},
+}
+
+type unaryFn func(srv driverServer, ctx context.Context, dec func(interface{}) error) (interface{}, error)
+
+func unaryHandler(method string, fn unaryFn) func(interface{}, context.Context, func(interface{}) error, grpc.UnaryServerInterceptor) (interface{}, error) {
+	return func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+		if interceptor == nil {
+			return fn(srv.(driverServer), ctx, dec)
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     srv,
+			FullMethod: fullMethod(method),
+		}
+		return interceptor(ctx, nil, info, func(ctx context.Context, _ interface{}) (interface{}, error) {
+			return fn(srv.(driverServer), ctx, dec)
+		})
+	}
+}
+
+func fullMethod(method string) string {
+	return "/" + serviceName + "/" + method
+}
+
+// toStatus converts the error into a gRPC status, retaining the error
+// code & message so that the client can restore the error.
+func toStatus(e error) error {
+	if e == nil {
+		return nil
+	}
+	err := errors.E(e)
+
+	var code codes.Code
+	switch {
+	case errors.Is(err, errors.ErrNotFound):
+		code = codes.NotFound
+
+	case errors.Is(err, errors.ErrConflict):
+		code = codes.AlreadyExists
+
+	case errors.Is(err, errors.ErrInvalid):
+		code = codes.InvalidArgument
+
+	case errors.Is(err, errors.ErrUnsupported):
+		code = codes.Unimplemented
+
+	default:
+		code = codes.Internal
+	}
+
+	b, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		return status.Error(code, err.Error())
+	}
+	return status.Error(code, string(b))
+}
+
+// fromStatus is the reverse of toStatus.
+func fromStatus(e error) error {
+	if e == nil {
+		return nil
+	}
+
+	st, ok := status.FromError(e)
+	if !ok {
+		return errors.ErrInternal.WithCausef(e.Error())
+	}
+
+	var err errors.Error
+	if jsonErr := json.Unmarshal([]byte(st.Message()), &err); jsonErr == nil && err.Code != "" {
+		return err
+	}
+
+	switch st.Code() {
+	case codes.NotFound:
+		return errors.ErrNotFound.WithCausef(st.Message())
+
+	case codes.InvalidArgument:
+		return errors.ErrInvalid.WithCausef(st.Message())
+
+	case codes.Unimplemented:
+		return errors.ErrUnsupported.WithCausef(st.Message())
+
+	default:
+		return errors.ErrInternal.WithCausef(st.Message())
+	}
+}
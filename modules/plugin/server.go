@@ -0,0 +1,156 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+
+	"google.golang.org/grpc"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+// Serve runs the driver described by desc as an Entropy plugin. This must be
+// invoked from the main() of the plugin executable and blocks until Entropy
+// (i.e., the parent process) exits.
+//
+//	func main() {
+//		if err := plugin.Serve(myModule); err != nil {
+//			log.Fatal(err)
+//		}
+//	}
+func Serve(desc module.Descriptor) error {
+	if os.Getenv(cookieKey) != cookieValue {
+		return fmt.Errorf("this is an entropy plugin and is not meant to be executed directly")
+	}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return err
+	}
+
+	srv := newGRPCServer(desc)
+
+	// stdin of the plugin is held open by Entropy. EOF indicates that the
+	// parent has exited and the plugin should stop.
+	go func() {
+		_, _ = io.Copy(io.Discard, os.Stdin)
+		srv.GracefulStop()
+	}()
+
+	fmt.Printf("%s|%d|%s|%s\n", handshakePrefix, ProtocolVersion, lis.Addr().Network(), lis.Addr().String())
+	return srv.Serve(lis)
+}
+
+func newGRPCServer(desc module.Descriptor) *grpc.Server {
+	srv := grpc.NewServer(grpc.ForceServerCodec(jsonCodec{}))
+	srv.RegisterService(&serviceDesc, &driverService{desc: desc})
+	return srv
+}
+
+// driverService implements the plugin side of the protocol by delegating
+// to the drivers created using the descriptor.
+type driverService struct {
+	desc module.Descriptor
+
+	// drivers caches the drivers created for each module config.
+	drivers sync.Map
+}
+
+func (ds *driverService) Describe(_ context.Context, _ *describeRequest) (*describeResponse, error) {
+	return &describeResponse{
+		ProtocolVersion: ProtocolVersion,
+		Kind:            ds.desc.Kind,
+		Actions:         ds.desc.Actions,
+		Dependencies:    ds.desc.Dependencies,
+		OutputSchema:    ds.desc.OutputSchema,
+	}, nil
+}
+
+func (ds *driverService) Validate(_ context.Context, req *validateRequest) (*validateResponse, error) {
+	if _, err := ds.driver(req.Configs); err != nil {
+		return nil, toStatus(err)
+	}
+	return &validateResponse{}, nil
+}
+
+func (ds *driverService) Plan(ctx context.Context, req *driverRequest) (*module.Plan, error) {
+	driver, err := ds.driver(req.Configs)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	plan, err := driver.Plan(ctx, req.Resource, req.Action)
+	return plan, toStatus(err)
+}
+
+func (ds *driverService) Sync(ctx context.Context, req *driverRequest) (*resource.State, error) {
+	driver, err := ds.driver(req.Configs)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	res := req.Resource
+	res.Secrets = req.Secrets
+	state, err := driver.Sync(ctx, res)
+	return state, toStatus(err)
+}
+
+func (ds *driverService) Output(ctx context.Context, req *driverRequest) (*outputResponse, error) {
+	driver, err := ds.driver(req.Configs)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	output, err := driver.Output(ctx, req.Resource)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &outputResponse{Output: output}, nil
+}
+
+func (ds *driverService) Log(req *driverRequest, stream grpc.ServerStream) error {
+	driver, err := ds.driver(req.Configs)
+	if err != nil {
+		return toStatus(err)
+	}
+
+	lg, supported := driver.(module.Loggable)
+	if !supported {
+		return toStatus(errors.ErrUnsupported.WithMsgf("log streaming not supported for kind '%s'", ds.desc.Kind))
+	}
+
+	chunks, err := lg.Log(stream.Context(), req.Resource, req.Filter)
+	if err != nil {
+		return toStatus(err)
+	}
+
+	for chunk := range chunks {
+		chunk := chunk
+		if err := stream.SendMsg(&chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ds *driverService) driver(configs []byte) (module.Driver, error) {
+	key := string(configs)
+	if d, found := ds.drivers.Load(key); found {
+		return d.(module.Driver), nil
+	}
+
+	driver, err := ds.desc.DriverFactory(configs)
+	if err != nil {
+		return nil, errors.ErrInvalid.
+			WithMsgf("failed to initialise module").
+			WithCausef(err.Error())
+	}
+	ds.drivers.Store(key, driver)
+	return driver, nil
+}
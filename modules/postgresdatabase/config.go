@@ -0,0 +1,166 @@
+package postgresdatabase
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/core/secret"
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/postgres"
+)
+
+// maxIdentifierLen is the maximum length of identifiers in PostgreSQL.
+const maxIdentifierLen = 63
+
+var invalidIdentifierChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+type moduleConfig struct {
+	Server   serverConfig `json:"server"`
+	Database string       `json:"database"`
+	Owner    string       `json:"owner"`
+	Grants   []grant      `json:"grants,omitempty"`
+}
+
+type serverConfig struct {
+	Host     string        `json:"host"`
+	Port     int           `json:"port,omitempty"`
+	User     string        `json:"user"`
+	Password passwordValue `json:"password"`
+	SSLMode  string        `json:"ssl_mode,omitempty"`
+}
+
+type grant struct {
+	Role       string   `json:"role"`
+	Privileges []string `json:"privileges"`
+}
+
+func readConfig(r resource.Resource, confJSON json.RawMessage) (*moduleConfig, error) {
+	var conf moduleConfig
+	if err := json.Unmarshal(confJSON, &conf); err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid config json: %v", err)
+	}
+
+	if conf.Database == "" {
+		conf.Database = identifier(fmt.Sprintf("%s_%s", r.Project, r.Name))
+	}
+	if conf.Owner == "" {
+		conf.Owner = identifier(conf.Database + "_owner")
+	}
+	if conf.Server.Port == 0 {
+		conf.Server.Port = 5432
+	}
+
+	seen := map[string]bool{conf.Owner: true}
+	for _, g := range conf.Grants {
+		if seen[g.Role] {
+			return nil, errors.ErrInvalid.
+				WithMsgf("role '%s' must be listed in grants at most once, and not be the owner", g.Role)
+		}
+		seen[g.Role] = true
+	}
+	return &conf, nil
+}
+
+// serverConfig returns the admin connection config with the password
+// resolved using the given secret values.
+func (mc moduleConfig) serverConfig(secrets map[string]string) (*postgres.ServerConfig, error) {
+	password := mc.Server.Password.Value
+	if ref := mc.Server.Password.SecretRef; ref != "" {
+		val, found := secrets[ref]
+		if !found {
+			return nil, errors.ErrInvalid.
+				WithMsgf("secret '%s' referred by server password is not available", ref)
+		}
+		password = val
+	}
+
+	return &postgres.ServerConfig{
+		Host:     mc.Server.Host,
+		Port:     mc.Server.Port,
+		User:     mc.Server.User,
+		Password: password,
+		SSLMode:  mc.Server.SSLMode,
+	}, nil
+}
+
+// revokedRoles returns the roles that have grants in the current config
+// but not in the given new one.
+func (mc moduleConfig) revokedRoles(newConf moduleConfig) []string {
+	retained := map[string]bool{}
+	for _, g := range newConf.Grants {
+		retained[g.Role] = true
+	}
+
+	var revoked []string
+	for _, g := range mc.Grants {
+		if !retained[g.Role] {
+			revoked = append(revoked, g.Role)
+		}
+	}
+	sort.Strings(revoked)
+	return revoked
+}
+
+func (mc moduleConfig) JSON() []byte {
+	b, err := json.Marshal(mc)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// identifier turns the given string into a valid unquoted identifier.
+func identifier(s string) string {
+	s = invalidIdentifierChars.ReplaceAllString(strings.ToLower(s), "_")
+	if len(s) > maxIdentifierLen {
+		s = s[:maxIdentifierLen]
+	}
+	return s
+}
+
+func generatePassword() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// passwordValue is either a plain string or a reference to a project
+// secret (i.e., {"secret_ref": "<name>"}).
+type passwordValue struct {
+	Value     string
+	SecretRef string
+}
+
+func (pv passwordValue) MarshalJSON() ([]byte, error) {
+	if pv.SecretRef != "" {
+		return json.Marshal(secret.Ref{Name: pv.SecretRef})
+	}
+	return json.Marshal(pv.Value)
+}
+
+func (pv *passwordValue) UnmarshalJSON(b []byte) error {
+	if trimmed := bytes.TrimSpace(b); len(trimmed) > 0 && trimmed[0] == '{' {
+		var ref secret.Ref
+		if err := json.Unmarshal(trimmed, &ref); err != nil {
+			return err
+		}
+		*pv = passwordValue{SecretRef: ref.Name}
+		return nil
+	}
+
+	var val string
+	if err := json.Unmarshal(b, &val); err != nil {
+		return err
+	}
+	*pv = passwordValue{Value: val}
+	return nil
+}
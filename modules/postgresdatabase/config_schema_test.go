@@ -0,0 +1,62 @@
+package postgresdatabase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+func TestModule_PostgresDatabaseJSONSchema(t *testing.T) {
+	tests := []struct {
+		title         string
+		Case          string
+		shouldBeValid bool
+	}{
+		{
+			title:         "PasswordSecretRef",
+			Case:          `{"server": {"host": "localhost", "user": "postgres", "password": {"secret_ref": "pg-admin"}}}`,
+			shouldBeValid: true,
+		},
+		{
+			title: "PlainPasswordWithGrants",
+			Case: `{
+				"server": {"host": "localhost", "port": 6432, "user": "postgres", "password": "pass", "ssl_mode": "require"},
+				"database": "orders",
+				"grants": [{"role": "readers", "privileges": ["CONNECT", "TEMPORARY"]}]
+			}`,
+			shouldBeValid: true,
+		},
+		{
+			title:         "MissingServer",
+			Case:          `{"database": "orders"}`,
+			shouldBeValid: false,
+		},
+		{
+			title:         "InvalidDatabaseName",
+			Case:          `{"server": {"host": "localhost", "user": "postgres", "password": "pass"}, "database": "Orders;DROP"}`,
+			shouldBeValid: false,
+		},
+		{
+			title:         "UnknownPrivilege",
+			Case:          `{"server": {"host": "localhost", "user": "postgres", "password": "pass"}, "grants": [{"role": "readers", "privileges": ["SUPERUSER"]}]}`,
+			shouldBeValid: false,
+		},
+	}
+
+	schema, err := gojsonschema.NewSchema(gojsonschema.NewStringLoader(configSchema))
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			c := gojsonschema.NewStringLoader(tt.Case)
+			result, err := schema.Validate(c)
+			require.NoError(t, err)
+			assert.Equal(t, tt.shouldBeValid, result.Valid())
+		})
+	}
+}
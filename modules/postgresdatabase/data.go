@@ -0,0 +1,19 @@
+package postgresdatabase
+
+import "encoding/json"
+
+type moduleData struct {
+	PendingSteps []string `json:"pending_steps"`
+
+	// RevokedRoles are the roles removed from the grants by an update,
+	// whose privileges on the database need to be revoked.
+	RevokedRoles []string `json:"revoked_roles,omitempty"`
+}
+
+func (md moduleData) JSON() json.RawMessage {
+	bytes, err := json.Marshal(md)
+	if err != nil {
+		panic(err)
+	}
+	return bytes
+}
@@ -0,0 +1,68 @@
+package postgresdatabase
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/pkg/postgres"
+)
+
+const (
+	stepCreate = "database_create"
+	stepUpdate = "database_update"
+	stepDelete = "database_delete"
+)
+
+var (
+	//go:embed schema/config.json
+	configSchema string
+
+	//go:embed schema/output.json
+	outputSchema string
+)
+
+var Module = module.Descriptor{
+	Kind:         "postgres_database",
+	OutputSchema: outputSchema,
+	Actions: []module.ActionDesc{
+		{
+			Name:        module.CreateAction,
+			Description: "Creates the database and its owner role.",
+			ParamSchema: configSchema,
+		},
+		{
+			Name:        module.UpdateAction,
+			Description: "Updates the grants on the database.",
+			ParamSchema: configSchema,
+		},
+		{
+			Name:        module.DeleteAction,
+			Description: "Drops the database and its owner role.",
+		},
+	},
+	DriverFactory: func(conf json.RawMessage) (module.Driver, error) {
+		return &databaseModule{
+			connect: func(ctx context.Context, conf postgres.ServerConfig) (serverAdmin, error) {
+				return postgres.Connect(ctx, conf)
+			},
+			generatePassword: generatePassword,
+		}, nil
+	},
+}
+
+// serverAdmin is the subset of postgres.Admin used by the module.
+type serverAdmin interface {
+	EnsureRole(ctx context.Context, name, password string) error
+	EnsureDatabase(ctx context.Context, name, owner string) error
+	SetGrants(ctx context.Context, database, role string, privileges []string) error
+	DropDatabase(ctx context.Context, name string) error
+	DropRole(ctx context.Context, name string) error
+	Close() error
+}
+
+type databaseModule struct {
+	connect          func(ctx context.Context, conf postgres.ServerConfig) (serverAdmin, error)
+	generatePassword func() (string, error)
+}
@@ -0,0 +1,60 @@
+package postgresdatabase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+
+	"github.com/odpf/entropy/core/module"
+)
+
+// Output has the details to connect to the database as its owner. Password
+// is marked as a secret in the output schema, so it is redacted when the
+// resource is rendered. Dependent resources receive it as is.
+type Output struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Database string `json:"database"`
+	Username string `json:"username"`
+	Password string `json:"password,omitempty"`
+	SSLMode  string `json:"ssl_mode,omitempty"`
+	JDBCURL  string `json:"jdbc_url"`
+}
+
+func newOutput(conf moduleConfig, password string) Output {
+	jdbcURL := url.URL{
+		Scheme: "postgresql",
+		Host:   net.JoinHostPort(conf.Server.Host, strconv.Itoa(conf.Server.Port)),
+		Path:   "/" + conf.Database,
+	}
+	if conf.Server.SSLMode != "" {
+		jdbcURL.RawQuery = url.Values{"sslmode": []string{conf.Server.SSLMode}}.Encode()
+	}
+
+	return Output{
+		Host:     conf.Server.Host,
+		Port:     conf.Server.Port,
+		Database: conf.Database,
+		Username: conf.Owner,
+		Password: password,
+		SSLMode:  conf.Server.SSLMode,
+		JDBCURL:  fmt.Sprintf("jdbc:%s", jdbcURL.String()),
+	}
+}
+
+func (out Output) JSON() []byte {
+	b, err := json.Marshal(out)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Output returns the output of the last sync. Connecting to the server
+// needs the admin password, which is available only during sync.
+func (*databaseModule) Output(_ context.Context, res module.ExpandedResource) (json.RawMessage, error) {
+	return res.State.Output, nil
+}
@@ -0,0 +1,89 @@
+package postgresdatabase
+
+import (
+	"context"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+func (m *databaseModule) Plan(_ context.Context, res module.ExpandedResource, act module.ActionRequest) (*module.Plan, error) {
+	switch act.Name {
+	case module.CreateAction:
+		return m.planCreate(res, act)
+	case module.UpdateAction:
+		return m.planUpdate(res, act)
+	case module.DeleteAction:
+		return m.planDelete(res)
+	default:
+		return nil, errors.ErrInvalid.WithMsgf("action '%s' not supported", act.Name)
+	}
+}
+
+func (*databaseModule) planCreate(res module.ExpandedResource, act module.ActionRequest) (*module.Plan, error) {
+	r := res.Resource
+
+	conf, err := readConfig(r, act.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	r.Spec.Configs = conf.JSON()
+	r.State = resource.State{
+		Status: resource.StatusPending,
+		Output: newOutput(*conf, "").JSON(),
+		ModuleData: moduleData{
+			PendingSteps: []string{stepCreate},
+		}.JSON(),
+	}
+	return &module.Plan{Resource: r, Reason: "postgres database created"}, nil
+}
+
+func (*databaseModule) planUpdate(res module.ExpandedResource, act module.ActionRequest) (*module.Plan, error) {
+	r := res.Resource
+
+	curConf, err := readConfig(r, r.Spec.Configs)
+	if err != nil {
+		return nil, err
+	}
+
+	conf, err := readConfig(r, act.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case conf.Server.Host != curConf.Server.Host || conf.Server.Port != curConf.Server.Port:
+		return nil, errors.ErrInvalid.WithMsgf("server of a postgres database cannot be changed")
+
+	case conf.Database != curConf.Database:
+		return nil, errors.ErrInvalid.WithMsgf("name of a postgres database cannot be changed")
+
+	case conf.Owner != curConf.Owner:
+		return nil, errors.ErrInvalid.WithMsgf("owner of a postgres database cannot be changed")
+	}
+
+	r.Spec.Configs = conf.JSON()
+	r.State = resource.State{
+		Status: resource.StatusPending,
+		Output: r.State.Output,
+		ModuleData: moduleData{
+			PendingSteps: []string{stepUpdate},
+			RevokedRoles: curConf.revokedRoles(*conf),
+		}.JSON(),
+	}
+	return &module.Plan{Resource: r, Reason: "postgres database updated"}, nil
+}
+
+func (*databaseModule) planDelete(res module.ExpandedResource) (*module.Plan, error) {
+	r := res.Resource
+	r.State = resource.State{
+		Status: resource.StatusDeleted,
+		Output: r.State.Output,
+		ModuleData: moduleData{
+			PendingSteps: []string{stepDelete},
+		}.JSON(),
+	}
+	return &module.Plan{Resource: r, Reason: "postgres database deleted"}, nil
+}
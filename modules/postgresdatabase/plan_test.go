@@ -0,0 +1,106 @@
+package postgresdatabase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+func TestDatabaseModule_Plan(t *testing.T) {
+	t.Parallel()
+
+	res := resource.Resource{
+		URN:     "orn:entropy:postgres_database:demo:orders",
+		Kind:    "postgres_database",
+		Name:    "orders",
+		Project: "demo-x",
+		Spec: resource.Spec{
+			Configs: []byte(`{"server":{"host":"localhost","port":5432,"user":"postgres","password":{"secret_ref":"pg-admin"}},"database":"demo_x_orders","owner":"demo_x_orders_owner","grants":[{"role":"readers","privileges":["CONNECT"]},{"role":"etl","privileges":["ALL"]}]}`),
+		},
+		State: resource.State{
+			Status: resource.StatusCompleted,
+			Output: []byte(`{"host":"localhost","port":5432,"database":"demo_x_orders","username":"demo_x_orders_owner","password":"generated","jdbc_url":"jdbc:postgresql://localhost:5432/demo_x_orders"}`),
+		},
+	}
+
+	table := []struct {
+		title      string
+		act        module.ActionRequest
+		wantConfig string
+		wantOutput string
+		wantStatus string
+		wantData   string
+		wantErr    error
+	}{
+		{
+			title:   "InvalidConfiguration",
+			act:     module.ActionRequest{Name: module.CreateAction, Params: []byte(`{`)},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title:   "DuplicateGrants",
+			act:     module.ActionRequest{Name: module.CreateAction, Params: []byte(`{"server":{"host":"localhost","user":"postgres","password":"pass"},"grants":[{"role":"demo_x_orders_owner","privileges":["CONNECT"]}]}`)},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title:      "CreateWithDefaults",
+			act:        module.ActionRequest{Name: module.CreateAction, Params: []byte(`{"server":{"host":"localhost","user":"postgres","password":{"secret_ref":"pg-admin"}}}`)},
+			wantConfig: `{"server":{"host":"localhost","port":5432,"user":"postgres","password":{"secret_ref":"pg-admin"}},"database":"demo_x_orders","owner":"demo_x_orders_owner"}`,
+			wantOutput: `{"host":"localhost","port":5432,"database":"demo_x_orders","username":"demo_x_orders_owner","jdbc_url":"jdbc:postgresql://localhost:5432/demo_x_orders"}`,
+			wantStatus: resource.StatusPending,
+			wantData:   `{"pending_steps":["database_create"]}`,
+		},
+		{
+			title:   "ChangeDatabase",
+			act:     module.ActionRequest{Name: module.UpdateAction, Params: []byte(`{"server":{"host":"localhost","user":"postgres","password":{"secret_ref":"pg-admin"}},"database":"orders"}`)},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title:   "ChangeServer",
+			act:     module.ActionRequest{Name: module.UpdateAction, Params: []byte(`{"server":{"host":"db.local","user":"postgres","password":{"secret_ref":"pg-admin"}}}`)},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title:      "Update",
+			act:        module.ActionRequest{Name: module.UpdateAction, Params: []byte(`{"server":{"host":"localhost","user":"postgres","password":{"secret_ref":"pg-admin"}},"grants":[{"role":"readers","privileges":["CONNECT","TEMPORARY"]}]}`)},
+			wantConfig: `{"server":{"host":"localhost","port":5432,"user":"postgres","password":{"secret_ref":"pg-admin"}},"database":"demo_x_orders","owner":"demo_x_orders_owner","grants":[{"role":"readers","privileges":["CONNECT","TEMPORARY"]}]}`,
+			wantOutput: string(res.State.Output),
+			wantStatus: resource.StatusPending,
+			wantData:   `{"pending_steps":["database_update"],"revoked_roles":["etl"]}`,
+		},
+		{
+			title:      "Delete",
+			act:        module.ActionRequest{Name: module.DeleteAction},
+			wantOutput: string(res.State.Output),
+			wantStatus: resource.StatusDeleted,
+			wantData:   `{"pending_steps":["database_delete"]}`,
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := newFakeServer().module().Plan(context.Background(), module.ExpandedResource{Resource: res}, tt.act)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, got.Resource.State.Status)
+			assert.JSONEq(t, tt.wantData, string(got.Resource.State.ModuleData))
+			assert.JSONEq(t, tt.wantOutput, string(got.Resource.State.Output))
+			if tt.wantConfig != "" {
+				assert.JSONEq(t, tt.wantConfig, string(got.Resource.Spec.Configs))
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package postgresdatabase
+
+import (
+	"context"
+
+	"github.com/odpf/entropy/pkg/postgres"
+)
+
+// fakeServer is an in-memory stand-in for a postgres server.
+type fakeServer struct {
+	roles     map[string]string
+	databases map[string]string
+	grants    map[string][]string
+
+	connErr error
+	conf    postgres.ServerConfig
+}
+
+func newFakeServer() *fakeServer {
+	return &fakeServer{
+		roles:     map[string]string{},
+		databases: map[string]string{},
+		grants:    map[string][]string{},
+	}
+}
+
+func (fs *fakeServer) module() *databaseModule {
+	return &databaseModule{
+		connect: func(_ context.Context, conf postgres.ServerConfig) (serverAdmin, error) {
+			fs.conf = conf
+			if fs.connErr != nil {
+				return nil, fs.connErr
+			}
+			return fs, nil
+		},
+		generatePassword: func() (string, error) { return "generated", nil },
+	}
+}
+
+func (fs *fakeServer) EnsureRole(_ context.Context, name, password string) error {
+	fs.roles[name] = password
+	return nil
+}
+
+func (fs *fakeServer) EnsureDatabase(_ context.Context, name, owner string) error {
+	fs.databases[name] = owner
+	return nil
+}
+
+func (fs *fakeServer) SetGrants(_ context.Context, database, role string, privileges []string) error {
+	key := database + "/" + role
+	if len(privileges) == 0 {
+		delete(fs.grants, key)
+	} else {
+		fs.grants[key] = privileges
+	}
+	return nil
+}
+
+func (fs *fakeServer) DropDatabase(_ context.Context, name string) error {
+	delete(fs.databases, name)
+	return nil
+}
+
+func (fs *fakeServer) DropRole(_ context.Context, name string) error {
+	delete(fs.roles, name)
+	return nil
+}
+
+func (*fakeServer) Close() error { return nil }
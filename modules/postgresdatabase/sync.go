@@ -0,0 +1,121 @@
+package postgresdatabase
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/worker"
+)
+
+const networkErrorRetryDuration = 5 * time.Second
+
+var ErrNetwork = worker.RetryableError{RetryAfter: networkErrorRetryDuration}
+
+func (m *databaseModule) Sync(ctx context.Context, res module.ExpandedResource) (*resource.State, error) {
+	r := res.Resource
+
+	var data moduleData
+	if err := json.Unmarshal(r.State.ModuleData, &data); err != nil {
+		return nil, err
+	}
+
+	var pendingStep string
+	if len(data.PendingSteps) != 0 {
+		pendingStep = data.PendingSteps[0]
+		data.PendingSteps = data.PendingSteps[1:]
+	}
+
+	var curOut Output
+	if err := json.Unmarshal(r.State.Output, &curOut); err != nil {
+		return nil, errors.ErrInvalid.WithMsgf("invalid output json: %v", err)
+	}
+
+	conf, err := readConfig(r, r.Spec.Configs)
+	if err != nil {
+		return nil, err
+	}
+
+	serverConf, err := conf.serverConfig(res.Secrets)
+	if err != nil {
+		return nil, err
+	}
+
+	errorState := &resource.State{
+		Status:     resource.StatusError,
+		Output:     r.State.Output,
+		ModuleData: data.JSON(),
+	}
+
+	admin, err := m.connect(ctx, *serverConf)
+	if err != nil {
+		if errors.Is(err, errors.ErrInvalid) {
+			// the admin credentials were rejected. retrying will not help
+			// until the config is fixed.
+			return errorState, nil
+		}
+		return nil, ErrNetwork.WithCause(err)
+	}
+	defer admin.Close()
+
+	output := curOut
+	switch pendingStep {
+	case stepCreate, stepUpdate:
+		password := curOut.Password
+		if password == "" {
+			password, err = m.generatePassword()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		err = m.applyDatabase(ctx, admin, *conf, password, data.RevokedRoles)
+		if err == nil {
+			data.RevokedRoles = nil
+			output = newOutput(*conf, password)
+		}
+
+	case stepDelete:
+		err = admin.DropDatabase(ctx, conf.Database)
+		if err == nil {
+			err = admin.DropRole(ctx, conf.Owner)
+		}
+	}
+
+	if err != nil {
+		if errors.Is(err, errors.ErrInvalid) {
+			return errorState, nil
+		}
+		return nil, ErrNetwork.WithCause(err)
+	}
+
+	return &resource.State{
+		Status:     resource.StatusCompleted,
+		Output:     output.JSON(),
+		ModuleData: data.JSON(),
+	}, nil
+}
+
+func (*databaseModule) applyDatabase(ctx context.Context, admin serverAdmin, conf moduleConfig, password string, revokedRoles []string) error {
+	if err := admin.EnsureRole(ctx, conf.Owner, password); err != nil {
+		return err
+	} else if err := admin.EnsureDatabase(ctx, conf.Database, conf.Owner); err != nil {
+		return err
+	}
+
+	for _, g := range conf.Grants {
+		if err := admin.SetGrants(ctx, conf.Database, g.Role, g.Privileges); err != nil {
+			return err
+		}
+	}
+
+	for _, role := range revokedRoles {
+		if err := admin.SetGrants(ctx, conf.Database, role, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
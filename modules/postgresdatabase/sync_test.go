@@ -0,0 +1,143 @@
+package postgresdatabase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/entropy/core/module"
+	"github.com/odpf/entropy/core/resource"
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/worker"
+)
+
+func TestDatabaseModule_Sync(t *testing.T) {
+	t.Parallel()
+
+	const (
+		configs = `{"server":{"host":"localhost","port":5432,"user":"postgres","password":{"secret_ref":"pg-admin"}},"database":"orders","owner":"orders_owner","grants":[{"role":"readers","privileges":["CONNECT"]}]}`
+		created = `{"host":"localhost","port":5432,"database":"orders","username":"orders_owner","password":"existing","jdbc_url":"jdbc:postgresql://localhost:5432/orders"}`
+	)
+
+	newRes := func(output, moduleData string) module.ExpandedResource {
+		return module.ExpandedResource{
+			Resource: resource.Resource{
+				URN:     "orn:entropy:postgres_database:demo:orders",
+				Kind:    "postgres_database",
+				Name:    "orders",
+				Project: "demo",
+				Spec:    resource.Spec{Configs: []byte(configs)},
+				State: resource.State{
+					Status:     resource.StatusPending,
+					Output:     []byte(output),
+					ModuleData: []byte(moduleData),
+				},
+			},
+			Secrets: map[string]string{"pg-admin": "admin-pass"},
+		}
+	}
+
+	table := []struct {
+		title      string
+		server     func() *fakeServer
+		res        module.ExpandedResource
+		wantStatus string
+		wantOutput string
+		wantServer func(t *testing.T, fs *fakeServer)
+		wantErr    bool
+	}{
+		{
+			title:      "Create",
+			server:     newFakeServer,
+			res:        newRes(`{"host":"localhost","port":5432,"database":"orders","username":"orders_owner","jdbc_url":"jdbc:postgresql://localhost:5432/orders"}`, `{"pending_steps":["database_create"]}`),
+			wantStatus: resource.StatusCompleted,
+			wantOutput: `{"host":"localhost","port":5432,"database":"orders","username":"orders_owner","password":"generated","jdbc_url":"jdbc:postgresql://localhost:5432/orders"}`,
+			wantServer: func(t *testing.T, fs *fakeServer) {
+				t.Helper()
+				assert.Equal(t, "admin-pass", fs.conf.Password)
+				assert.Equal(t, map[string]string{"orders_owner": "generated"}, fs.roles)
+				assert.Equal(t, map[string]string{"orders": "orders_owner"}, fs.databases)
+				assert.Equal(t, map[string][]string{"orders/readers": {"CONNECT"}}, fs.grants)
+			},
+		},
+		{
+			title: "UpdateKeepsPasswordAndRevokes",
+			server: func() *fakeServer {
+				fs := newFakeServer()
+				fs.roles["orders_owner"] = "existing"
+				fs.databases["orders"] = "orders_owner"
+				fs.grants["orders/etl"] = []string{"ALL"}
+				return fs
+			},
+			res:        newRes(created, `{"pending_steps":["database_update"],"revoked_roles":["etl"]}`),
+			wantStatus: resource.StatusCompleted,
+			wantOutput: created,
+			wantServer: func(t *testing.T, fs *fakeServer) {
+				t.Helper()
+				assert.Equal(t, map[string]string{"orders_owner": "existing"}, fs.roles)
+				assert.Equal(t, map[string][]string{"orders/readers": {"CONNECT"}}, fs.grants)
+			},
+		},
+		{
+			title: "Delete",
+			server: func() *fakeServer {
+				fs := newFakeServer()
+				fs.roles["orders_owner"] = "existing"
+				fs.databases["orders"] = "orders_owner"
+				return fs
+			},
+			res:        newRes(created, `{"pending_steps":["database_delete"]}`),
+			wantStatus: resource.StatusCompleted,
+			wantOutput: created,
+			wantServer: func(t *testing.T, fs *fakeServer) {
+				t.Helper()
+				assert.Empty(t, fs.roles)
+				assert.Empty(t, fs.databases)
+			},
+		},
+		{
+			title: "AdminCredentialsRejected",
+			server: func() *fakeServer {
+				fs := newFakeServer()
+				fs.connErr = errors.ErrInvalid.WithMsgf("password authentication failed")
+				return fs
+			},
+			res:        newRes(created, `{"pending_steps":["database_update"]}`),
+			wantStatus: resource.StatusError,
+			wantOutput: created,
+		},
+		{
+			title: "ServerUnreachable",
+			server: func() *fakeServer {
+				fs := newFakeServer()
+				fs.connErr = errors.New("connection refused")
+				return fs
+			},
+			res:     newRes(created, `{"pending_steps":["database_update"]}`),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			fs := tt.server()
+			got, err := fs.module().Sync(context.Background(), tt.res)
+			if tt.wantErr {
+				var retryErr *worker.RetryableError
+				assert.ErrorAs(t, err, &retryErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, got.Status)
+			assert.JSONEq(t, tt.wantOutput, string(got.Output))
+			if tt.wantServer != nil {
+				tt.wantServer(t, fs)
+			}
+		})
+	}
+}
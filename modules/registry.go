@@ -3,6 +3,7 @@ package modules
 import (
 	"context"
 	"reflect"
+	"sort"
 	"sync"
 
 	"github.com/odpf/entropy/core/module"
@@ -34,6 +35,33 @@ func (mr *Registry) GetDriver(_ context.Context, mod module.Module) (module.Driv
 	return driver, desc, nil
 }
 
+func (mr *Registry) GetDescriptor(_ context.Context, kind string) (*module.Descriptor, error) {
+	mr.mu.RLock()
+	defer mr.mu.RUnlock()
+
+	desc, found := mr.modules[kind]
+	if !found {
+		return nil, errors.ErrNotFound
+	}
+	return &desc, nil
+}
+
+// ListDescriptors returns descriptors of all the registered modules, sorted
+// by kind.
+func (mr *Registry) ListDescriptors(_ context.Context) ([]module.Descriptor, error) {
+	mr.mu.RLock()
+	defer mr.mu.RUnlock()
+
+	descs := make([]module.Descriptor, 0, len(mr.modules))
+	for _, desc := range mr.modules {
+		descs = append(descs, desc)
+	}
+	sort.Slice(descs, func(i, j int) bool {
+		return descs[i].Kind < descs[j].Kind
+	})
+	return descs, nil
+}
+
 // Register adds a module to the registry.
 func (mr *Registry) Register(desc module.Descriptor) error {
 	mr.mu.Lock()
@@ -53,6 +81,12 @@ func (mr *Registry) Register(desc module.Descriptor) error {
 		}
 		desc.Actions[i] = action
 	}
+
+	if _, err := desc.Secrets(); err != nil {
+		return errors.ErrInvalid.
+			WithMsgf("config/output schema for kind '%s' is not valid", desc.Kind).
+			WithCausef(err.Error())
+	}
 	mr.modules[desc.Kind] = desc
 	return nil
 }
@@ -124,3 +124,40 @@ func TestRegistry_Register(t *testing.T) {
 		assert.True(t, errors.Is(got, errors.ErrInvalid), cmp.Diff(got, errors.ErrInvalid))
 	})
 }
+
+func TestRegistry_Descriptors(t *testing.T) {
+	t.Parallel()
+
+	reg := &modules.Registry{}
+	for _, kind := range []string{"foo", "bar"} {
+		require.NoError(t, reg.Register(module.Descriptor{
+			Kind: kind,
+			DriverFactory: func(_ json.RawMessage) (module.Driver, error) {
+				return &mocks.ModuleDriver{}, nil
+			},
+		}))
+	}
+
+	t.Run("GetDescriptor_UnknownKind", func(t *testing.T) {
+		t.Parallel()
+		desc, err := reg.GetDescriptor(context.Background(), "unknown_kind")
+		assert.True(t, errors.Is(err, errors.ErrNotFound))
+		assert.Nil(t, desc)
+	})
+
+	t.Run("GetDescriptor_KnownKind", func(t *testing.T) {
+		t.Parallel()
+		desc, err := reg.GetDescriptor(context.Background(), "foo")
+		assert.NoError(t, err)
+		assert.Equal(t, "foo", desc.Kind)
+	})
+
+	t.Run("ListDescriptors_SortedByKind", func(t *testing.T) {
+		t.Parallel()
+		descs, err := reg.ListDescriptors(context.Background())
+		assert.NoError(t, err)
+		require.Len(t, descs, 2)
+		assert.Equal(t, "bar", descs[0].Kind)
+		assert.Equal(t, "foo", descs[1].Kind)
+	})
+}
@@ -1,6 +1,8 @@
 package helm
 
 import (
+	"time"
+
 	"github.com/mcuadros/go-defaults"
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/cli"
@@ -17,6 +19,8 @@ type Config struct {
 	HelmDriver string `default:"secret"`
 	// Kubernetes configuration.
 	Kubernetes kube.Config
+	// Timeout - Limit for each request to the kubernetes API. No limit if 0.
+	Timeout time.Duration
 }
 
 type Client struct {
@@ -44,6 +48,9 @@ func (p *Client) getActionConfiguration(namespace string) (*action.Configuration
 	overrides.AuthInfo.Token = p.config.Kubernetes.Token
 	overrides.ClusterInfo.CertificateAuthorityData = []byte(p.config.Kubernetes.ClusterCACertificate)
 	overrides.ClusterInfo.InsecureSkipTLSVerify = p.config.Kubernetes.Insecure
+	if p.config.Timeout > 0 {
+		overrides.Timeout = p.config.Timeout.String()
+	}
 
 	hasCA := len(overrides.ClusterInfo.CertificateAuthorityData) != 0
 	hasCert := len(overrides.AuthInfo.ClientCertificateData) != 0
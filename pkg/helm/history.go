@@ -0,0 +1,72 @@
+package helm
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/release"
+
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+// ReleaseRevision is a revision of a helm release, as listed by 'helm history'.
+type ReleaseRevision struct {
+	Revision     int       `json:"revision"`
+	Status       string    `json:"status"`
+	Chart        string    `json:"chart,omitempty"`
+	ChartVersion string    `json:"chart_version,omitempty"`
+	AppVersion   string    `json:"app_version,omitempty"`
+	Description  string    `json:"description,omitempty"`
+	UpdatedAt    time.Time `json:"updated_at"`
+
+	// Values are the values supplied to the revision, excluding the
+	// defaults of the chart.
+	Values map[string]interface{} `json:"-"`
+}
+
+// History - lists the revisions of a helm release, oldest first. If max is
+// more than 0, only the latest max revisions are listed.
+func (p *Client) History(config *ReleaseConfig, max int) ([]ReleaseRevision, error) {
+	actionConfig, err := p.getActionConfiguration(config.Namespace)
+	if err != nil {
+		return nil, errors.ErrInternal.WithMsgf("error while getting action configuration : %s", err)
+	}
+
+	// action.History ignores its Max field, and lists all the revisions.
+	rels, err := action.NewHistory(actionConfig).Run(config.Name)
+	if err != nil {
+		if strings.Contains(err.Error(), "release: not found") {
+			return nil, errors.ErrNotFound.WithMsgf("release doesn't exists: %s", err)
+		}
+		return nil, errors.ErrInternal.WithMsgf("error while getting release history: %s", err)
+	}
+	return releaseRevisions(rels, max), nil
+}
+
+func releaseRevisions(rels []*release.Release, max int) []ReleaseRevision {
+	revisions := make([]ReleaseRevision, 0, len(rels))
+	for _, rel := range rels {
+		rev := ReleaseRevision{Revision: rel.Version, Values: rel.Config}
+		if rel.Info != nil {
+			rev.Status = rel.Info.Status.String()
+			rev.Description = rel.Info.Description
+			rev.UpdatedAt = rel.Info.LastDeployed.Time
+		}
+		if rel.Chart != nil && rel.Chart.Metadata != nil {
+			rev.Chart = rel.Chart.Metadata.Name
+			rev.ChartVersion = rel.Chart.Metadata.Version
+			rev.AppVersion = rel.Chart.Metadata.AppVersion
+		}
+		revisions = append(revisions, rev)
+	}
+
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Revision < revisions[j].Revision
+	})
+	if max > 0 && len(revisions) > max {
+		revisions = revisions[len(revisions)-max:]
+	}
+	return revisions
+}
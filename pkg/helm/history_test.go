@@ -0,0 +1,82 @@
+package helm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/release"
+	helmtime "helm.sh/helm/v3/pkg/time"
+)
+
+func TestReleaseRevisions(t *testing.T) {
+	t.Parallel()
+
+	deployedAt := time.Date(2022, 4, 21, 10, 0, 0, 0, time.UTC)
+	firehoseChart := func(version string) *chart.Chart {
+		return &chart.Chart{Metadata: &chart.Metadata{Name: "firehose", Version: version, AppVersion: "0.4.1"}}
+	}
+
+	rels := []*release.Release{
+		{
+			Version: 2,
+			Chart:   firehoseChart("0.1.3"),
+			Info: &release.Info{
+				Status:       release.StatusFailed,
+				Description:  "Upgrade \"orders-firehose\" failed: timed out waiting for the condition",
+				LastDeployed: helmtime.Time{Time: deployedAt.Add(time.Hour)},
+			},
+		},
+		{
+			Version: 1,
+			Chart:   firehoseChart("0.1.2"),
+			Config:  map[string]interface{}{"replicaCount": 2},
+			Info: &release.Info{
+				Status:       release.StatusSuperseded,
+				Description:  "Install complete",
+				LastDeployed: helmtime.Time{Time: deployedAt},
+			},
+		},
+		{Version: 3},
+	}
+
+	assert.Equal(t, []ReleaseRevision{
+		{
+			Revision:     1,
+			Status:       "superseded",
+			Chart:        "firehose",
+			ChartVersion: "0.1.2",
+			AppVersion:   "0.4.1",
+			Description:  "Install complete",
+			UpdatedAt:    deployedAt,
+			Values:       map[string]interface{}{"replicaCount": 2},
+		},
+		{
+			Revision:     2,
+			Status:       "failed",
+			Chart:        "firehose",
+			ChartVersion: "0.1.3",
+			AppVersion:   "0.4.1",
+			Description:  "Upgrade \"orders-firehose\" failed: timed out waiting for the condition",
+			UpdatedAt:    deployedAt.Add(time.Hour),
+		},
+		{Revision: 3},
+	}, releaseRevisions(rels, 0))
+}
+
+func TestReleaseRevisions_Max(t *testing.T) {
+	t.Parallel()
+
+	var rels []*release.Release
+	for _, version := range []int{4, 1, 5, 3, 2} {
+		rels = append(rels, &release.Release{Version: version})
+	}
+
+	assert.Equal(t, []ReleaseRevision{
+		{Revision: 3},
+		{Revision: 4},
+		{Revision: 5},
+	}, releaseRevisions(rels, 3))
+	assert.Len(t, releaseRevisions(rels, 10), 5)
+}
@@ -11,6 +11,7 @@ import (
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
 	"helm.sh/helm/v3/pkg/release"
 
 	"github.com/odpf/entropy/pkg/errors"
@@ -230,11 +231,89 @@ func (p *Client) Delete(config *ReleaseConfig) error {
 
 	uninstall := action.NewUninstall(actionConfig)
 	run, err := uninstall.Run(config.Name)
-	if run != nil && run.Release.Info.Status == release.StatusUninstalled || run.Release.Info.Status == release.StatusUninstalling {
+	if err != nil && strings.Contains(err.Error(), "release: not found") {
+		// nothing to uninstall.
 		return nil
-	} else {
-		return errors.ErrInternal.WithMsgf("unable to uninstall release %s", err)
 	}
+
+	if run != nil && run.Release != nil &&
+		(run.Release.Info.Status == release.StatusUninstalled || run.Release.Info.Status == release.StatusUninstalling) {
+		return nil
+	}
+	return errors.ErrInternal.WithMsgf("unable to uninstall release %s", err)
+}
+
+// Rollback - rolls back a helm release to the given revision. If revision
+// is 0, the release is rolled back to the previous revision.
+func (p *Client) Rollback(config *ReleaseConfig, revision int) (*Release, error) {
+	actionConfig, err := p.getActionConfiguration(config.Namespace)
+	if err != nil {
+		return nil, errors.ErrInternal.WithMsgf("error while getting action configuration : %s", err)
+	}
+
+	client := action.NewRollback(actionConfig)
+	client.Version = revision
+	client.Wait = config.Wait
+	client.WaitForJobs = config.WaitForJobs
+	client.Timeout = time.Second * time.Duration(config.Timeout)
+	client.Force = config.ForceUpdate
+	client.Recreate = config.RecreatePods
+
+	if err := client.Run(config.Name); err != nil {
+		if strings.Contains(err.Error(), "release: not found") {
+			return nil, errors.ErrNotFound.WithMsgf("release doesn't exists: %s", err)
+		}
+		return nil, errors.ErrInternal.WithMsgf("error while rolling back release: %s", err)
+	}
+
+	rel, err := p.getRelease(actionConfig, config.Name)
+	if err != nil {
+		return nil, errors.ErrInternal.WithMsgf("error while getting release: %s", err)
+	}
+
+	releaseJSON, err := json.Marshal(rel)
+	if err != nil {
+		return nil, errors.ErrInternal.WithMsgf("error while json marshalling release: %s", err)
+	}
+
+	return &Release{
+		Config: config,
+		Output: ReleaseOutput{
+			Status:  mapReleaseStatus(rel.Info.Status),
+			Release: string(releaseJSON),
+		},
+	}, nil
+}
+
+// ValidateValues - fetches the chart and validates the values in the config
+// against the values.schema.json of the chart, if the chart has one.
+func (p *Client) ValidateValues(config *ReleaseConfig) error {
+	chartPathOptions, chartName := p.chartPathOptions(config)
+
+	fetchedChart, err := p.getChart(chartName, chartPathOptions)
+	if err != nil {
+		// the chart repository may be unreachable, so this is not reported
+		// as an invalid request.
+		return errors.ErrInternal.WithMsgf("failed to fetch chart '%s'", chartName).WithCausef(err.Error())
+	}
+
+	if fetchedChart.Metadata.Type != typeApplication {
+		return errors.ErrInvalid.WithMsgf("chart '%s' is not an application chart", chartName)
+	}
+
+	if len(fetchedChart.Schema) == 0 {
+		return nil
+	}
+
+	values, err := chartutil.CoalesceValues(fetchedChart, config.Values)
+	if err != nil {
+		return errors.ErrInvalid.WithMsgf("invalid values").WithCausef(err.Error())
+	}
+
+	if err := chartutil.ValidateAgainstSchema(fetchedChart, values); err != nil {
+		return errors.ErrInvalid.WithMsgf("values do not match the chart schema").WithCausef(err.Error())
+	}
+	return nil
 }
 
 func (*Client) chartPathOptions(config *ReleaseConfig) (*action.ChartPathOptions, string) {
@@ -0,0 +1,237 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+const defaultTimeout = 30 * time.Second
+
+var (
+	ErrTopicNotFound = errors.ErrNotFound.WithMsgf("topic not found")
+	ErrTopicExists   = errors.ErrConflict.WithMsgf("topic already exists")
+)
+
+// TopicSpec is the desired state of a topic.
+type TopicSpec struct {
+	Name              string
+	Partitions        int
+	ReplicationFactor int
+	Configs           map[string]string
+}
+
+// TopicInfo is the current state of a topic in the cluster.
+type TopicInfo struct {
+	Name              string            `json:"name"`
+	Partitions        int               `json:"partitions"`
+	ReplicationFactor int               `json:"replication_factor"`
+	Configs           map[string]string `json:"configs,omitempty"`
+}
+
+// Admin performs administrative operations on a kafka cluster.
+type Admin struct {
+	client *kafka.Client
+}
+
+// NewAdmin returns an admin client for the cluster with given brokers.
+func NewAdmin(brokers []string) *Admin {
+	return newAdmin(brokers, nil)
+}
+
+func newAdmin(brokers []string, transport kafka.RoundTripper) *Admin {
+	return &Admin{
+		client: &kafka.Client{
+			Addr:      kafka.TCP(brokers...),
+			Timeout:   defaultTimeout,
+			Transport: transport,
+		},
+	}
+}
+
+// GetTopic returns the partition count, replication factor & the configs
+// that are explicitly set (i.e., not defaults) for the topic.
+func (a *Admin) GetTopic(ctx context.Context, name string) (*TopicInfo, error) {
+	meta, err := a.client.Metadata(ctx, &kafka.MetadataRequest{Topics: []string{name}})
+	if err != nil {
+		return nil, err
+	} else if len(meta.Topics) == 0 {
+		return nil, ErrTopicNotFound.WithCausef(name)
+	}
+
+	topic := meta.Topics[0]
+	if topic.Error != nil {
+		if errors.Is(topic.Error, kafka.UnknownTopicOrPartition) {
+			return nil, ErrTopicNotFound.WithCausef(name)
+		}
+		return nil, topic.Error
+	}
+
+	info := &TopicInfo{
+		Name:       topic.Name,
+		Partitions: len(topic.Partitions),
+		Configs:    map[string]string{},
+	}
+	if len(topic.Partitions) > 0 {
+		info.ReplicationFactor = len(topic.Partitions[0].Replicas)
+	}
+
+	resp, err := a.client.DescribeConfigs(ctx, &kafka.DescribeConfigsRequest{
+		Resources: []kafka.DescribeConfigRequestResource{
+			{ResourceType: kafka.ResourceTypeTopic, ResourceName: name},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, res := range resp.Resources {
+		if res.Error != nil {
+			return nil, res.Error
+		}
+		for _, entry := range res.ConfigEntries {
+			if !entry.IsDefault && !entry.IsSensitive {
+				info.Configs[entry.ConfigName] = entry.ConfigValue
+			}
+		}
+	}
+	return info, nil
+}
+
+// CreateTopic creates the topic as per the spec.
+func (a *Admin) CreateTopic(ctx context.Context, spec TopicSpec) error {
+	var entries []kafka.ConfigEntry
+	for _, name := range sortedKeys(spec.Configs) {
+		entries = append(entries, kafka.ConfigEntry{
+			ConfigName:  name,
+			ConfigValue: spec.Configs[name],
+		})
+	}
+
+	resp, err := a.client.CreateTopics(ctx, &kafka.CreateTopicsRequest{
+		Topics: []kafka.TopicConfig{
+			{
+				Topic:             spec.Name,
+				NumPartitions:     spec.Partitions,
+				ReplicationFactor: spec.ReplicationFactor,
+				ConfigEntries:     entries,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if topicErr := resp.Errors[spec.Name]; topicErr != nil {
+		if errors.Is(topicErr, kafka.TopicAlreadyExists) {
+			return ErrTopicExists.WithCausef(spec.Name)
+		}
+		return translateErr(topicErr)
+	}
+	return nil
+}
+
+// SetPartitions increases the number of partitions of the topic to the
+// given count.
+func (a *Admin) SetPartitions(ctx context.Context, name string, count int) error {
+	resp, err := a.client.CreatePartitions(ctx, &kafka.CreatePartitionsRequest{
+		Topics: []kafka.TopicPartitionsConfig{
+			{Name: name, Count: int32(count)},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return translateErr(resp.Errors[name])
+}
+
+// AlterTopicConfigs sets the given configs and resets the configs listed
+// in 'remove' to their defaults.
+func (a *Admin) AlterTopicConfigs(ctx context.Context, name string, set map[string]string, remove []string) error {
+	var configs []kafka.IncrementalAlterConfigsRequestConfig
+	for _, key := range sortedKeys(set) {
+		configs = append(configs, kafka.IncrementalAlterConfigsRequestConfig{
+			Name:            key,
+			Value:           set[key],
+			ConfigOperation: kafka.ConfigOperationSet,
+		})
+	}
+	for _, key := range remove {
+		configs = append(configs, kafka.IncrementalAlterConfigsRequestConfig{
+			Name:            key,
+			ConfigOperation: kafka.ConfigOperationDelete,
+		})
+	}
+	if len(configs) == 0 {
+		return nil
+	}
+
+	resp, err := a.client.IncrementalAlterConfigs(ctx, &kafka.IncrementalAlterConfigsRequest{
+		Resources: []kafka.IncrementalAlterConfigsRequestResource{
+			{
+				ResourceType: kafka.ResourceTypeTopic,
+				ResourceName: name,
+				Configs:      configs,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, res := range resp.Resources {
+		if res.Error != nil {
+			return translateErr(res.Error)
+		}
+	}
+	return nil
+}
+
+// DeleteTopic deletes the topic. Deleting a topic that does not exist is
+// not an error.
+func (a *Admin) DeleteTopic(ctx context.Context, name string) error {
+	resp, err := a.client.DeleteTopics(ctx, &kafka.DeleteTopicsRequest{Topics: []string{name}})
+	if err != nil {
+		return err
+	}
+
+	if topicErr := resp.Errors[name]; topicErr != nil && !errors.Is(topicErr, kafka.UnknownTopicOrPartition) {
+		return topicErr
+	}
+	return nil
+}
+
+// translateErr marks the errors caused by invalid requests as ErrInvalid,
+// since retrying those will not succeed.
+func translateErr(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	switch {
+	case errors.Is(err, kafka.InvalidPartitionNumber),
+		errors.Is(err, kafka.InvalidReplicationFactor),
+		errors.Is(err, kafka.InvalidTopic),
+		errors.Is(err, kafka.InvalidConfiguration),
+		errors.Is(err, kafka.InvalidRequest),
+		errors.Is(err, kafka.UnknownTopicOrPartition):
+		return errors.ErrInvalid.WithMsgf("%s", err.Error())
+
+	default:
+		return fmt.Errorf("kafka: %w", err)
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,62 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+func TestAdmin(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	admin := newAdmin([]string{"localhost:9092"}, newFakeBroker())
+
+	_, err := admin.GetTopic(ctx, "orders")
+	assert.True(t, errors.Is(err, ErrTopicNotFound))
+
+	err = admin.CreateTopic(ctx, TopicSpec{
+		Name:              "orders",
+		Partitions:        3,
+		ReplicationFactor: 2,
+		Configs:           map[string]string{"retention.ms": "3600000"},
+	})
+	require.NoError(t, err)
+
+	err = admin.CreateTopic(ctx, TopicSpec{Name: "orders", Partitions: 1, ReplicationFactor: 1})
+	assert.True(t, errors.Is(err, ErrTopicExists))
+
+	info, err := admin.GetTopic(ctx, "orders")
+	require.NoError(t, err)
+	assert.Equal(t, &TopicInfo{
+		Name:              "orders",
+		Partitions:        3,
+		ReplicationFactor: 2,
+		Configs:           map[string]string{"retention.ms": "3600000"},
+	}, info)
+
+	t.Run("SetPartitions", func(t *testing.T) {
+		assert.True(t, errors.Is(admin.SetPartitions(ctx, "orders", 2), errors.ErrInvalid))
+		require.NoError(t, admin.SetPartitions(ctx, "orders", 6))
+	})
+
+	t.Run("AlterTopicConfigs", func(t *testing.T) {
+		err := admin.AlterTopicConfigs(ctx, "orders", map[string]string{"cleanup.policy": "compact"}, []string{"retention.ms"})
+		require.NoError(t, err)
+	})
+
+	info, err = admin.GetTopic(ctx, "orders")
+	require.NoError(t, err)
+	assert.Equal(t, 6, info.Partitions)
+	assert.Equal(t, map[string]string{"cleanup.policy": "compact"}, info.Configs)
+
+	require.NoError(t, admin.DeleteTopic(ctx, "orders"))
+	require.NoError(t, admin.DeleteTopic(ctx, "orders"))
+
+	_, err = admin.GetTopic(ctx, "orders")
+	assert.True(t, errors.Is(err, ErrTopicNotFound))
+}
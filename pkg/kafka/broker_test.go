@@ -0,0 +1,283 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/protocol/createpartitions"
+	"github.com/segmentio/kafka-go/protocol/createtopics"
+	"github.com/segmentio/kafka-go/protocol/deletetopics"
+	"github.com/segmentio/kafka-go/protocol/describeconfigs"
+	"github.com/segmentio/kafka-go/protocol/describegroups"
+	"github.com/segmentio/kafka-go/protocol/incrementalalterconfigs"
+	"github.com/segmentio/kafka-go/protocol/listoffsets"
+	"github.com/segmentio/kafka-go/protocol/metadata"
+	"github.com/segmentio/kafka-go/protocol/offsetcommit"
+	"github.com/segmentio/kafka-go/protocol/offsetfetch"
+)
+
+// fakeBroker is an in-process stand-in for a kafka cluster that supports
+// just enough of the protocol for the admin operations.
+type fakeBroker struct {
+	mu     sync.Mutex
+	topics map[string]*fakeTopic
+
+	// commits are the committed offsets by group, topic & partition.
+	commits map[string]map[string]map[int32]int64
+
+	// groupStates are the states of the groups that are not 'Empty'.
+	groupStates map[string]string
+}
+
+type fakeTopic struct {
+	partitions        int32
+	replicationFactor int16
+	configs           map[string]string
+
+	// latestOffsets are the offsets of the next message by partition.
+	latestOffsets map[int32]int64
+
+	// earliestOffsets are the offsets of the first retained message, and
+	// timestamps are the timestamps (in millis) of the retained messages
+	// starting from it, by partition.
+	earliestOffsets map[int32]int64
+	timestamps      map[int32][]int64
+}
+
+func newFakeBroker() *fakeBroker {
+	return &fakeBroker{
+		topics:      map[string]*fakeTopic{},
+		commits:     map[string]map[string]map[int32]int64{},
+		groupStates: map[string]string{},
+	}
+}
+
+func (fb *fakeBroker) RoundTrip(_ context.Context, _ net.Addr, req kafka.Request) (kafka.Response, error) {
+	fb.mu.Lock()
+	defer fb.mu.Unlock()
+
+	switch r := req.(type) {
+	case *metadata.Request:
+		resp := &metadata.Response{}
+		for _, name := range r.TopicNames {
+			t, found := fb.topics[name]
+			if !found {
+				resp.Topics = append(resp.Topics, metadata.ResponseTopic{
+					Name:      name,
+					ErrorCode: int16(kafka.UnknownTopicOrPartition),
+				})
+				continue
+			}
+
+			topic := metadata.ResponseTopic{Name: name}
+			for i := int32(0); i < t.partitions; i++ {
+				topic.Partitions = append(topic.Partitions, metadata.ResponsePartition{
+					PartitionIndex: i,
+					ReplicaNodes:   make([]int32, t.replicationFactor),
+				})
+			}
+			resp.Topics = append(resp.Topics, topic)
+		}
+		return resp, nil
+
+	case *createtopics.Request:
+		resp := &createtopics.Response{}
+		for _, rt := range r.Topics {
+			if _, exists := fb.topics[rt.Name]; exists {
+				resp.Topics = append(resp.Topics, createtopics.ResponseTopic{
+					Name:      rt.Name,
+					ErrorCode: int16(kafka.TopicAlreadyExists),
+				})
+				continue
+			}
+
+			t := &fakeTopic{
+				partitions:        rt.NumPartitions,
+				replicationFactor: rt.ReplicationFactor,
+				configs:           map[string]string{},
+				latestOffsets:     map[int32]int64{},
+			}
+			for _, c := range rt.Configs {
+				t.configs[c.Name] = c.Value
+			}
+			fb.topics[rt.Name] = t
+			resp.Topics = append(resp.Topics, createtopics.ResponseTopic{Name: rt.Name})
+		}
+		return resp, nil
+
+	case *describeconfigs.Request:
+		resp := &describeconfigs.Response{}
+		for _, rr := range r.Resources {
+			res := describeconfigs.ResponseResource{
+				ResourceType: rr.ResourceType,
+				ResourceName: rr.ResourceName,
+			}
+
+			t, found := fb.topics[rr.ResourceName]
+			if !found {
+				res.ErrorCode = int16(kafka.UnknownTopicOrPartition)
+			} else {
+				res.ConfigEntries = append(res.ConfigEntries, describeconfigs.ResponseConfigEntry{
+					ConfigName:  "cleanup.policy",
+					ConfigValue: "delete",
+					IsDefault:   true,
+				})
+				for k, v := range t.configs {
+					res.ConfigEntries = append(res.ConfigEntries, describeconfigs.ResponseConfigEntry{
+						ConfigName:  k,
+						ConfigValue: v,
+					})
+				}
+			}
+			resp.Resources = append(resp.Resources, res)
+		}
+		return resp, nil
+
+	case *createpartitions.Request:
+		resp := &createpartitions.Response{}
+		for _, rt := range r.Topics {
+			result := createpartitions.ResponseResult{Name: rt.Name}
+
+			t, found := fb.topics[rt.Name]
+			if !found {
+				result.ErrorCode = int16(kafka.UnknownTopicOrPartition)
+			} else if rt.Count <= t.partitions {
+				result.ErrorCode = int16(kafka.InvalidPartitionNumber)
+			} else {
+				t.partitions = rt.Count
+			}
+			resp.Results = append(resp.Results, result)
+		}
+		return resp, nil
+
+	case *incrementalalterconfigs.Request:
+		resp := &incrementalalterconfigs.Response{}
+		for _, rr := range r.Resources {
+			res := incrementalalterconfigs.ResponseAlterResponse{
+				ResourceType: rr.ResourceType,
+				ResourceName: rr.ResourceName,
+			}
+
+			t, found := fb.topics[rr.ResourceName]
+			if !found {
+				res.ErrorCode = int16(kafka.UnknownTopicOrPartition)
+			} else {
+				for _, c := range rr.Configs {
+					if c.ConfigOperation == int8(kafka.ConfigOperationDelete) {
+						delete(t.configs, c.Name)
+					} else {
+						t.configs[c.Name] = c.Value
+					}
+				}
+			}
+			resp.Responses = append(resp.Responses, res)
+		}
+		return resp, nil
+
+	case *deletetopics.Request:
+		resp := &deletetopics.Response{}
+		for _, name := range r.TopicNames {
+			topic := deletetopics.ResponseTopic{Name: name}
+			if _, found := fb.topics[name]; !found {
+				topic.ErrorCode = int16(kafka.UnknownTopicOrPartition)
+			}
+			delete(fb.topics, name)
+			resp.Responses = append(resp.Responses, topic)
+		}
+		return resp, nil
+
+	case *offsetfetch.Request:
+		resp := &offsetfetch.Response{}
+		for _, rt := range r.Topics {
+			topic := offsetfetch.ResponseTopic{Name: rt.Name}
+			for _, p := range rt.PartitionIndexes {
+				offset, found := fb.commits[r.GroupID][rt.Name][p]
+				if !found {
+					offset = -1
+				}
+				topic.Partitions = append(topic.Partitions, offsetfetch.ResponsePartition{
+					PartitionIndex:  p,
+					CommittedOffset: offset,
+				})
+			}
+			resp.Topics = append(resp.Topics, topic)
+		}
+		return resp, nil
+
+	case *listoffsets.Request:
+		resp := &listoffsets.Response{}
+		for _, rt := range r.Topics {
+			topic := listoffsets.ResponseTopic{Topic: rt.Topic}
+			for _, p := range rt.Partitions {
+				partition := listoffsets.ResponsePartition{Partition: p.Partition, Timestamp: p.Timestamp}
+				if t, found := fb.topics[rt.Topic]; !found {
+					partition.ErrorCode = int16(kafka.UnknownTopicOrPartition)
+				} else {
+					partition.Offset = t.offsetAt(p.Partition, p.Timestamp)
+				}
+				topic.Partitions = append(topic.Partitions, partition)
+			}
+			resp.Topics = append(resp.Topics, topic)
+		}
+		return resp, nil
+
+	case *describegroups.Request:
+		resp := &describegroups.Response{}
+		for _, id := range r.Groups {
+			state, found := fb.groupStates[id]
+			if !found {
+				state = "Empty"
+			}
+			resp.Groups = append(resp.Groups, describegroups.ResponseGroup{GroupID: id, GroupState: state})
+		}
+		return resp, nil
+
+	case *offsetcommit.Request:
+		resp := &offsetcommit.Response{}
+		for _, rt := range r.Topics {
+			topic := offsetcommit.ResponseTopic{Name: rt.Name}
+			for _, p := range rt.Partitions {
+				partition := offsetcommit.ResponsePartition{PartitionIndex: p.PartitionIndex}
+				if r.GenerationID != -1 {
+					partition.ErrorCode = int16(kafka.IllegalGeneration)
+				} else {
+					if fb.commits[r.GroupID] == nil {
+						fb.commits[r.GroupID] = map[string]map[int32]int64{}
+					}
+					if fb.commits[r.GroupID][rt.Name] == nil {
+						fb.commits[r.GroupID][rt.Name] = map[int32]int64{}
+					}
+					fb.commits[r.GroupID][rt.Name][p.PartitionIndex] = p.CommittedOffset
+				}
+				topic.Partitions = append(topic.Partitions, partition)
+			}
+			resp.Topics = append(resp.Topics, topic)
+		}
+		return resp, nil
+
+	default:
+		return nil, fmt.Errorf("fake broker: unsupported request %T", req)
+	}
+}
+
+// offsetAt returns the offset for a ListOffsets request with the timestamp.
+func (t *fakeTopic) offsetAt(partition int32, timestamp int64) int64 {
+	switch timestamp {
+	case kafka.LastOffset:
+		return t.latestOffsets[partition]
+
+	case kafka.FirstOffset:
+		return t.earliestOffsets[partition]
+
+	default:
+		for i, ts := range t.timestamps[partition] {
+			if ts >= timestamp {
+				return t.earliestOffsets[partition] + int64(i)
+			}
+		}
+		return -1
+	}
+}
@@ -0,0 +1,101 @@
+package kafka
+
+import (
+	"context"
+	"sort"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+// ConsumerLag is the number of messages of a topic that are yet to be
+// consumed by a consumer group.
+type ConsumerLag struct {
+	Group      string         `json:"group"`
+	Topic      string         `json:"topic"`
+	Total      int64          `json:"total"`
+	Partitions []PartitionLag `json:"partitions"`
+}
+
+// PartitionLag is the consumer lag on a single partition. CommittedOffset
+// is -1 if the group has not committed any offset on the partition, in
+// which case the lag is taken as zero.
+type PartitionLag struct {
+	Partition       int   `json:"partition"`
+	CommittedOffset int64 `json:"committed_offset"`
+	LatestOffset    int64 `json:"latest_offset"`
+	Lag             int64 `json:"lag"`
+}
+
+// GetConsumerLag returns the lag of the consumer group on each partition of
+// the topic, and the total lag.
+func (a *Admin) GetConsumerLag(ctx context.Context, group, topic string) (*ConsumerLag, error) {
+	meta, err := a.client.Metadata(ctx, &kafka.MetadataRequest{Topics: []string{topic}})
+	if err != nil {
+		return nil, err
+	} else if len(meta.Topics) == 0 || errors.Is(meta.Topics[0].Error, kafka.UnknownTopicOrPartition) {
+		return nil, ErrTopicNotFound.WithCausef(topic)
+	} else if meta.Topics[0].Error != nil {
+		return nil, meta.Topics[0].Error
+	}
+
+	var partitions []int
+	var offsetReqs []kafka.OffsetRequest
+	for _, p := range meta.Topics[0].Partitions {
+		partitions = append(partitions, p.ID)
+		offsetReqs = append(offsetReqs, kafka.LastOffsetOf(p.ID))
+	}
+	sort.Ints(partitions)
+
+	committed, err := a.client.OffsetFetch(ctx, &kafka.OffsetFetchRequest{
+		GroupID: group,
+		Topics:  map[string][]int{topic: partitions},
+	})
+	if err != nil {
+		return nil, err
+	} else if committed.Error != nil {
+		return nil, committed.Error
+	}
+
+	latest, err := a.client.ListOffsets(ctx, &kafka.ListOffsetsRequest{
+		Topics: map[string][]kafka.OffsetRequest{topic: offsetReqs},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	committedOffsets := map[int]int64{}
+	for _, p := range committed.Topics[topic] {
+		if p.Error != nil {
+			return nil, p.Error
+		}
+		committedOffsets[p.Partition] = p.CommittedOffset
+	}
+
+	latestOffsets := map[int]int64{}
+	for _, p := range latest.Topics[topic] {
+		if p.Error != nil {
+			return nil, p.Error
+		}
+		latestOffsets[p.Partition] = p.LastOffset
+	}
+
+	lag := &ConsumerLag{Group: group, Topic: topic, Partitions: []PartitionLag{}}
+	for _, id := range partitions {
+		pl := PartitionLag{
+			Partition:       id,
+			CommittedOffset: -1,
+			LatestOffset:    latestOffsets[id],
+		}
+		if offset, found := committedOffsets[id]; found && offset >= 0 {
+			pl.CommittedOffset = offset
+			if pl.LatestOffset > offset {
+				pl.Lag = pl.LatestOffset - offset
+			}
+		}
+		lag.Total += pl.Lag
+		lag.Partitions = append(lag.Partitions, pl)
+	}
+	return lag, nil
+}
@@ -0,0 +1,42 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+func TestAdmin_GetConsumerLag(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	broker := newFakeBroker()
+	admin := newAdmin([]string{"localhost:9092"}, broker)
+
+	_, err := admin.GetConsumerLag(ctx, "orders-sink", "orders")
+	assert.True(t, errors.Is(err, ErrTopicNotFound))
+
+	require.NoError(t, admin.CreateTopic(ctx, TopicSpec{Name: "orders", Partitions: 3, ReplicationFactor: 1}))
+	broker.topics["orders"].latestOffsets = map[int32]int64{0: 1500, 1: 700, 2: 40}
+	broker.commits["orders-sink"] = map[string]map[int32]int64{
+		// consumer is ahead of the (stale) latest offset of partition 1.
+		"orders": {0: 1000, 1: 800},
+	}
+
+	lag, err := admin.GetConsumerLag(ctx, "orders-sink", "orders")
+	require.NoError(t, err)
+	assert.Equal(t, &ConsumerLag{
+		Group: "orders-sink",
+		Topic: "orders",
+		Total: 500,
+		Partitions: []PartitionLag{
+			{Partition: 0, CommittedOffset: 1000, LatestOffset: 1500, Lag: 500},
+			{Partition: 1, CommittedOffset: 800, LatestOffset: 700, Lag: 0},
+			{Partition: 2, CommittedOffset: -1, LatestOffset: 40, Lag: 0},
+		},
+	}, lag)
+}
@@ -0,0 +1,107 @@
+// Package kafkatest provides an in-memory kafka cluster for testing the
+// modules that manage kafka topics.
+package kafkatest
+
+import (
+	"context"
+
+	"github.com/odpf/entropy/pkg/errors"
+	"github.com/odpf/entropy/pkg/kafka"
+)
+
+// Cluster is an in-memory stand-in for kafka.Admin.
+type Cluster struct {
+	// Err, when set, is returned from every call as if the brokers were
+	// unreachable.
+	Err error
+
+	// Topics are the topics on the cluster by their names.
+	Topics map[string]*kafka.TopicInfo
+}
+
+// NewCluster returns a cluster with the given topics.
+func NewCluster(topics ...kafka.TopicInfo) *Cluster {
+	c := &Cluster{Topics: map[string]*kafka.TopicInfo{}}
+	for _, t := range topics {
+		t := t
+		c.Topics[t.Name] = &t
+	}
+	return c
+}
+
+func (c *Cluster) GetTopic(_ context.Context, name string) (*kafka.TopicInfo, error) {
+	if c.Err != nil {
+		return nil, c.Err
+	}
+
+	t, found := c.Topics[name]
+	if !found {
+		return nil, kafka.ErrTopicNotFound
+	}
+	info := *t
+	return &info, nil
+}
+
+func (c *Cluster) CreateTopic(_ context.Context, spec kafka.TopicSpec) error {
+	if c.Err != nil {
+		return c.Err
+	} else if _, exists := c.Topics[spec.Name]; exists {
+		return kafka.ErrTopicExists
+	}
+
+	configs := map[string]string{}
+	for k, v := range spec.Configs {
+		configs[k] = v
+	}
+	if c.Topics == nil {
+		c.Topics = map[string]*kafka.TopicInfo{}
+	}
+	c.Topics[spec.Name] = &kafka.TopicInfo{
+		Name:              spec.Name,
+		Partitions:        spec.Partitions,
+		ReplicationFactor: spec.ReplicationFactor,
+		Configs:           configs,
+	}
+	return nil
+}
+
+func (c *Cluster) SetPartitions(_ context.Context, name string, count int) error {
+	if c.Err != nil {
+		return c.Err
+	}
+
+	t, found := c.Topics[name]
+	if !found {
+		return kafka.ErrTopicNotFound
+	} else if count <= t.Partitions {
+		return errors.ErrInvalid.WithMsgf("partitions can only be increased")
+	}
+	t.Partitions = count
+	return nil
+}
+
+func (c *Cluster) AlterTopicConfigs(_ context.Context, name string, set map[string]string, remove []string) error {
+	if c.Err != nil {
+		return c.Err
+	}
+
+	t, found := c.Topics[name]
+	if !found {
+		return kafka.ErrTopicNotFound
+	}
+	for k, v := range set {
+		t.Configs[k] = v
+	}
+	for _, k := range remove {
+		delete(t.Configs, k)
+	}
+	return nil
+}
+
+func (c *Cluster) DeleteTopic(_ context.Context, name string) error {
+	if c.Err != nil {
+		return c.Err
+	}
+	delete(c.Topics, name)
+	return nil
+}
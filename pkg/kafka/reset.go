@@ -0,0 +1,255 @@
+package kafka
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+const (
+	ResetEarliest         = "EARLIEST"
+	ResetLatest           = "LATEST"
+	ResetDatetime         = "DATETIME"
+	ResetPartitionOffsets = "OFFSETS"
+	ResetShiftBy          = "SHIFT_BY"
+	ResetFromGroup        = "GROUP"
+)
+
+var (
+	ErrGroupActive = errors.ErrConflict.WithMsgf("consumer group has active members")
+	ErrUnreachable = errors.ErrInternal.WithMsgf("kafka brokers are unreachable")
+)
+
+// ResetSpec describes the offsets to reset a consumer group to. Offsets
+// outside of the retained range of a partition are moved to the nearest
+// end of the range.
+type ResetSpec struct {
+	// To is one of ResetEarliest, ResetLatest, ResetDatetime,
+	// ResetPartitionOffsets, ResetShiftBy or ResetFromGroup.
+	To string
+
+	// Datetime is used with ResetDatetime. Partitions are reset to the
+	// first message at or after it, or to the latest offset if there is
+	// no such message.
+	Datetime time.Time
+
+	// Offsets is used with ResetPartitionOffsets, as the offset to reset
+	// to by partition. Other partitions are not reset.
+	Offsets map[int]int64
+
+	// ShiftBy is used with ResetShiftBy, to move the committed offset of
+	// every partition by the given number of messages (negative to move
+	// back). The latest offset is used for partitions without a committed
+	// offset.
+	ShiftBy int64
+
+	// Group is used with ResetFromGroup, to reset to the committed offsets
+	// of the given group. Partitions on which it has not committed an
+	// offset are not reset.
+	Group string
+}
+
+// ResetResult is the outcome of resetting the offsets of a consumer group
+// on a topic.
+type ResetResult struct {
+	Group      string           `json:"group"`
+	Topic      string           `json:"topic"`
+	Partitions []PartitionReset `json:"partitions"`
+}
+
+// PartitionReset is the outcome of resetting the offset of a consumer group
+// on a single partition. PreviousOffset is -1 if the group had not committed
+// any offset on the partition.
+type PartitionReset struct {
+	Partition      int   `json:"partition"`
+	PreviousOffset int64 `json:"previous_offset"`
+	NewOffset      int64 `json:"new_offset"`
+}
+
+// ResetOffsets commits new offsets for the consumer group on the partitions
+// of the topic, as described by the spec. Like kafka-consumer-groups.sh, it refuses to reset a group
+// with active members (ErrGroupActive). ErrUnreachable is returned if the
+// brokers cannot be reached at all, in which case nothing is changed.
+func (a *Admin) ResetOffsets(ctx context.Context, group, topic string, spec ResetSpec) (*ResetResult, error) {
+	groups, err := a.client.DescribeGroups(ctx, &kafka.DescribeGroupsRequest{GroupIDs: []string{group}})
+	if err != nil {
+		if isNetworkErr(err) {
+			return nil, ErrUnreachable.WithCausef(err.Error())
+		}
+		return nil, err
+	}
+
+	for _, g := range groups.Groups {
+		if g.Error != nil {
+			return nil, g.Error
+		} else if g.GroupState != "" && g.GroupState != "Empty" && g.GroupState != "Dead" {
+			return nil, ErrGroupActive.WithCausef("group '%s' is in state '%s'", group, g.GroupState)
+		}
+	}
+
+	current, err := a.GetConsumerLag(ctx, group, topic)
+	if err != nil {
+		return nil, err
+	}
+
+	targets, err := a.resetTargets(ctx, current, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []kafka.OffsetCommit
+	result := &ResetResult{Group: group, Topic: topic, Partitions: []PartitionReset{}}
+	for _, p := range current.Partitions {
+		if _, found := targets[p.Partition]; !found {
+			continue
+		}
+
+		commits = append(commits, kafka.OffsetCommit{Partition: p.Partition, Offset: targets[p.Partition]})
+		result.Partitions = append(result.Partitions, PartitionReset{
+			Partition:      p.Partition,
+			PreviousOffset: p.CommittedOffset,
+			NewOffset:      targets[p.Partition],
+		})
+	}
+
+	resp, err := a.client.OffsetCommit(ctx, &kafka.OffsetCommitRequest{
+		GroupID: group,
+		// commits from outside of the group must not have a generation.
+		GenerationID: -1,
+		Topics:       map[string][]kafka.OffsetCommit{topic: commits},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range resp.Topics[topic] {
+		if p.Error != nil {
+			return nil, p.Error
+		}
+	}
+
+	return result, nil
+}
+
+// resetTargets returns the offsets to reset to, by partition.
+func (a *Admin) resetTargets(ctx context.Context, current *ConsumerLag, spec ResetSpec) (map[int]int64, error) {
+	earliest, err := a.listOffsets(ctx, current, kafka.FirstOffsetOf)
+	if err != nil {
+		return nil, err
+	}
+
+	latest := map[int]int64{}
+	committed := map[int]int64{}
+	for _, p := range current.Partitions {
+		latest[p.Partition] = p.LatestOffset
+		committed[p.Partition] = p.CommittedOffset
+	}
+
+	targets := map[int]int64{}
+	switch spec.To {
+	case ResetEarliest:
+		for id, offset := range earliest {
+			targets[id] = offset.FirstOffset
+		}
+
+	case ResetLatest:
+		targets = latest
+
+	case ResetDatetime:
+		byTime, err := a.listOffsets(ctx, current, func(partition int) kafka.OffsetRequest {
+			return kafka.TimeOffsetOf(partition, spec.Datetime)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for id, p := range byTime {
+			// offset is -1 if there is no message at or after the time.
+			targets[id] = latest[id]
+			for offset := range p.Offsets {
+				if offset >= 0 {
+					targets[id] = offset
+				}
+			}
+		}
+
+	case ResetPartitionOffsets:
+		if len(spec.Offsets) == 0 {
+			return nil, errors.ErrInvalid.WithMsgf("offsets must be set for reset to '%s'", spec.To)
+		}
+		for id, offset := range spec.Offsets {
+			if _, found := latest[id]; !found {
+				return nil, errors.ErrInvalid.WithMsgf("topic '%s' has no partition %d", current.Topic, id)
+			}
+			targets[id] = offset
+		}
+
+	case ResetShiftBy:
+		for id, offset := range committed {
+			if offset < 0 {
+				offset = latest[id]
+			}
+			targets[id] = offset + spec.ShiftBy
+		}
+
+	case ResetFromGroup:
+		other, err := a.GetConsumerLag(ctx, spec.Group, current.Topic)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range other.Partitions {
+			if p.CommittedOffset >= 0 {
+				targets[p.Partition] = p.CommittedOffset
+			}
+		}
+		if len(targets) == 0 {
+			return nil, errors.ErrInvalid.WithMsgf("group '%s' has no committed offsets on topic '%s'", spec.Group, current.Topic)
+		}
+
+	default:
+		return nil, errors.ErrInvalid.WithMsgf("unknown reset target '%s'", spec.To)
+	}
+
+	for id, offset := range targets {
+		if first := earliest[id].FirstOffset; offset < first {
+			targets[id] = first
+		} else if offset > latest[id] {
+			targets[id] = latest[id]
+		}
+	}
+	return targets, nil
+}
+
+// listOffsets lists the offsets of every partition of the topic, using the
+// request returned by offsetOf for each partition.
+func (a *Admin) listOffsets(ctx context.Context, current *ConsumerLag, offsetOf func(partition int) kafka.OffsetRequest) (map[int]kafka.PartitionOffsets, error) {
+	var reqs []kafka.OffsetRequest
+	for _, p := range current.Partitions {
+		reqs = append(reqs, offsetOf(p.Partition))
+	}
+
+	resp, err := a.client.ListOffsets(ctx, &kafka.ListOffsetsRequest{
+		Topics: map[string][]kafka.OffsetRequest{current.Topic: reqs},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	offsets := map[int]kafka.PartitionOffsets{}
+	for _, p := range resp.Topics[current.Topic] {
+		if p.Error != nil {
+			return nil, p.Error
+		}
+		offsets[p.Partition] = p
+	}
+	return offsets, nil
+}
+
+func isNetworkErr(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded)
+}
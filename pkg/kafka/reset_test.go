@@ -0,0 +1,149 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+func TestAdmin_ResetOffsets(t *testing.T) {
+	t.Parallel()
+
+	t0 := time.Date(2022, 6, 22, 0, 0, 0, 0, time.UTC)
+
+	setup := func(t *testing.T) (*fakeBroker, *Admin) {
+		t.Helper()
+
+		broker := newFakeBroker()
+		admin := newAdmin([]string{"localhost:9092"}, broker)
+		require.NoError(t, admin.CreateTopic(context.Background(), TopicSpec{Name: "orders", Partitions: 2, ReplicationFactor: 1}))
+
+		topic := broker.topics["orders"]
+		topic.earliestOffsets = map[int32]int64{0: 100, 1: 10}
+		topic.latestOffsets = map[int32]int64{0: 103, 1: 12}
+		topic.timestamps = map[int32][]int64{
+			0: {t0.UnixMilli(), t0.Add(time.Hour).UnixMilli(), t0.Add(2 * time.Hour).UnixMilli()},
+			1: {t0.UnixMilli(), t0.Add(30 * time.Minute).UnixMilli()},
+		}
+		broker.commits["orders-sink"] = map[string]map[int32]int64{"orders": {0: 102}}
+		broker.commits["orders-replay"] = map[string]map[int32]int64{"orders": {1: 11}}
+		return broker, admin
+	}
+
+	table := []struct {
+		title   string
+		spec    ResetSpec
+		state   string
+		want    []PartitionReset
+		wantErr error
+	}{
+		{
+			title: "Earliest",
+			spec:  ResetSpec{To: ResetEarliest},
+			want: []PartitionReset{
+				{Partition: 0, PreviousOffset: 102, NewOffset: 100},
+				{Partition: 1, PreviousOffset: -1, NewOffset: 10},
+			},
+		},
+		{
+			title: "Latest",
+			spec:  ResetSpec{To: ResetLatest},
+			want: []PartitionReset{
+				{Partition: 0, PreviousOffset: 102, NewOffset: 103},
+				{Partition: 1, PreviousOffset: -1, NewOffset: 12},
+			},
+		},
+		{
+			title: "Datetime",
+			spec:  ResetSpec{To: ResetDatetime, Datetime: t0.Add(45 * time.Minute)},
+			want: []PartitionReset{
+				{Partition: 0, PreviousOffset: 102, NewOffset: 101},
+				// no message after the time, reset to latest.
+				{Partition: 1, PreviousOffset: -1, NewOffset: 12},
+			},
+		},
+		{
+			title: "PartitionOffsets",
+			spec:  ResetSpec{To: ResetPartitionOffsets, Offsets: map[int]int64{1: 5}},
+			want: []PartitionReset{
+				// offset before the earliest retained message.
+				{Partition: 1, PreviousOffset: -1, NewOffset: 10},
+			},
+		},
+		{
+			title:   "PartitionOffsetsUnknownPartition",
+			spec:    ResetSpec{To: ResetPartitionOffsets, Offsets: map[int]int64{2: 5}},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title: "ShiftBy",
+			spec:  ResetSpec{To: ResetShiftBy, ShiftBy: -1},
+			want: []PartitionReset{
+				{Partition: 0, PreviousOffset: 102, NewOffset: 101},
+				{Partition: 1, PreviousOffset: -1, NewOffset: 11},
+			},
+		},
+		{
+			title: "ShiftByPastLatest",
+			spec:  ResetSpec{To: ResetShiftBy, ShiftBy: 5},
+			want: []PartitionReset{
+				{Partition: 0, PreviousOffset: 102, NewOffset: 103},
+				{Partition: 1, PreviousOffset: -1, NewOffset: 12},
+			},
+		},
+		{
+			title: "FromGroup",
+			spec:  ResetSpec{To: ResetFromGroup, Group: "orders-replay"},
+			want: []PartitionReset{
+				{Partition: 1, PreviousOffset: -1, NewOffset: 11},
+			},
+		},
+		{
+			title:   "FromGroupWithoutCommits",
+			spec:    ResetSpec{To: ResetFromGroup, Group: "orders-unknown"},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title:   "UnknownTarget",
+			spec:    ResetSpec{To: "NEWEST"},
+			wantErr: errors.ErrInvalid,
+		},
+		{
+			title:   "ActiveGroup",
+			spec:    ResetSpec{To: ResetEarliest},
+			state:   "Stable",
+			wantErr: ErrGroupActive,
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			broker, admin := setup(t)
+			if tt.state != "" {
+				broker.groupStates["orders-sink"] = tt.state
+			}
+
+			got, err := admin.ResetOffsets(context.Background(), "orders-sink", "orders", tt.spec)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				assert.Nil(t, got)
+				assert.Equal(t, map[int32]int64{0: 102}, broker.commits["orders-sink"]["orders"])
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, &ResetResult{Group: "orders-sink", Topic: "orders", Partitions: tt.want}, got)
+			for _, p := range tt.want {
+				assert.Equal(t, p.NewOffset, broker.commits["orders-sink"]["orders"][int32(p.Partition)])
+			}
+		})
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/mitchellh/mapstructure"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
@@ -51,6 +52,20 @@ type LogChunk struct {
 type Pod struct {
 	Name       string   `json:"name"`
 	Containers []string `json:"containers"`
+	Phase      string   `json:"phase"`
+	Ready      bool     `json:"ready"`
+	Node       string   `json:"node,omitempty"`
+
+	// Restarts is the total number of restarts of the containers.
+	Restarts int32 `json:"restarts"`
+
+	// LastTerminationReason is the reason for the latest termination of
+	// a container of the pod (e.g., 'OOMKilled', 'Error').
+	LastTerminationReason string `json:"last_termination_reason,omitempty"`
+
+	// WaitingReason is the reason for a container of the pod not running
+	// (e.g., 'CrashLoopBackOff', 'ImagePullBackOff').
+	WaitingReason string `json:"waiting_reason,omitempty"`
 }
 
 type LogOptions struct {
@@ -287,17 +302,58 @@ func (c Client) GetPodDetails(ctx context.Context, namespace string, labelSelect
 	}
 
 	for _, pod := range pods.Items {
-		podDetail := Pod{
-			Name: pod.Name,
-		}
+		podDetails = append(podDetails, podDetailOf(pod))
+	}
+
+	return podDetails, nil
+}
 
-		for _, container := range pod.Spec.Containers {
-			podDetail.Containers = append(podDetail.Containers, container.Name)
+// ApplySecret creates the secret with given data in the namespace, or
+// replaces the data if the secret already exists.
+func (c Client) ApplySecret(ctx context.Context, namespace, name string, data map[string]string) error {
+	clientSet, err := kubernetes.NewForConfig(&c.restConfig)
+	if err != nil {
+		return err
+	}
+
+	secrets := clientSet.CoreV1().Secrets(namespace)
+
+	existing, err := secrets.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
 		}
-		podDetails = append(podDetails, podDetail)
+
+		_, err = secrets.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Type:       corev1.SecretTypeOpaque,
+			StringData: data,
+		}, metav1.CreateOptions{})
+		return err
 	}
 
-	return podDetails, nil
+	existing.Data = nil
+	existing.StringData = data
+	_, err = secrets.Update(ctx, existing, metav1.UpdateOptions{})
+	return err
+}
+
+// DeleteSecret deletes the secret from the namespace. It is not an error
+// if the secret does not exist.
+func (c Client) DeleteSecret(ctx context.Context, namespace, name string) error {
+	clientSet, err := kubernetes.NewForConfig(&c.restConfig)
+	if err != nil {
+		return err
+	}
+
+	err = clientSet.CoreV1().Secrets(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
 }
 
 func streamContainerLogs(ctx context.Context, ns, podName string, logCh chan<- LogChunk, clientSet *kubernetes.Clientset,
@@ -0,0 +1,227 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+// ErrCronJobNotFound is returned when the cron job does not exist.
+var ErrCronJobNotFound = errors.ErrNotFound.WithMsgf("cron job not found")
+
+// CronJobSpec describes a cron job. The embedded JobSpec describes both the
+// cron job and the jobs created by it.
+type CronJobSpec struct {
+	JobSpec
+
+	Schedule string
+
+	// ConcurrencyPolicy is one of Allow, Forbid or Replace.
+	ConcurrencyPolicy string
+
+	// SuccessfulJobsHistoryLimit & FailedJobsHistoryLimit are the number
+	// of finished jobs to keep.
+	SuccessfulJobsHistoryLimit int32
+	FailedJobsHistoryLimit     int32
+
+	Suspend bool
+}
+
+// CronJobStatus is the status of a cron job along with its recent runs.
+type CronJobStatus struct {
+	Name               string      `json:"name"`
+	Namespace          string      `json:"namespace"`
+	Schedule           string      `json:"schedule"`
+	Suspended          bool        `json:"suspended"`
+	LastScheduleTime   *time.Time  `json:"last_schedule_time,omitempty"`
+	LastSuccessfulTime *time.Time  `json:"last_successful_time,omitempty"`
+	Runs               []JobStatus `json:"runs"`
+}
+
+// ApplyCronJob creates the cron job, or updates it if it exists already.
+func (c Client) ApplyCronJob(ctx context.Context, spec CronJobSpec) error {
+	clientSet, err := kubernetes.NewForConfig(&c.restConfig)
+	if err != nil {
+		return err
+	}
+
+	suspend := spec.Suspend
+	successLimit, failLimit := spec.SuccessfulJobsHistoryLimit, spec.FailedJobsHistoryLimit
+	cronJobSpec := batchv1.CronJobSpec{
+		Schedule:                   spec.Schedule,
+		ConcurrencyPolicy:          batchv1.ConcurrencyPolicy(spec.ConcurrencyPolicy),
+		Suspend:                    &suspend,
+		SuccessfulJobsHistoryLimit: &successLimit,
+		FailedJobsHistoryLimit:     &failLimit,
+		JobTemplate: batchv1.JobTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Labels: spec.Labels},
+			Spec:       jobSpecOf(spec.JobSpec),
+		},
+	}
+
+	cronJobs := clientSet.BatchV1().CronJobs(spec.Namespace)
+	existing, err := cronJobs.Get(ctx, spec.Name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+
+		cronJob := &batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      spec.Name,
+				Namespace: spec.Namespace,
+				Labels:    spec.Labels,
+			},
+			Spec: cronJobSpec,
+		}
+		_, err = cronJobs.Create(ctx, cronJob, metav1.CreateOptions{})
+	} else {
+		existing.Labels = spec.Labels
+		existing.Spec = cronJobSpec
+		_, err = cronJobs.Update(ctx, existing, metav1.UpdateOptions{})
+	}
+
+	if err != nil && apierrors.IsInvalid(err) {
+		return errors.ErrInvalid.WithMsgf("invalid cron job: %s", err.Error())
+	}
+	return err
+}
+
+// TriggerCronJob creates a job from the template of the cron job, to run
+// it immediately irrespective of its schedule. The job is owned by the
+// cron job, and is included in its runs. If a job with the same name
+// exists, ErrJobExists is returned.
+func (c Client) TriggerCronJob(ctx context.Context, namespace, name, jobName string) error {
+	clientSet, err := kubernetes.NewForConfig(&c.restConfig)
+	if err != nil {
+		return err
+	}
+
+	cronJob, err := clientSet.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return ErrCronJobNotFound.WithCausef(name)
+		}
+		return err
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: namespace,
+			Labels:    cronJob.Spec.JobTemplate.Labels,
+			Annotations: map[string]string{
+				// same as the jobs created by 'kubectl create job --from'.
+				"cronjob.kubernetes.io/instantiate": "manual",
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(cronJob, batchv1.SchemeGroupVersion.WithKind("CronJob")),
+			},
+		},
+		Spec: cronJob.Spec.JobTemplate.Spec,
+	}
+
+	_, err = clientSet.BatchV1().Jobs(namespace).Create(ctx, job, metav1.CreateOptions{})
+	if err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return ErrJobExists.WithCausef(jobName)
+		}
+		return err
+	}
+	return nil
+}
+
+// GetCronJobStatus returns the status of the cron job along with the jobs
+// it has created that still exist, most recent first.
+func (c Client) GetCronJobStatus(ctx context.Context, namespace, name string) (*CronJobStatus, error) {
+	clientSet, err := kubernetes.NewForConfig(&c.restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	cronJob, err := clientSet.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, ErrCronJobNotFound.WithCausef(name)
+		}
+		return nil, err
+	}
+
+	jobs, err := clientSet.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: metav1.FormatLabelSelector(metav1.SetAsLabelSelector(cronJob.Spec.JobTemplate.Labels)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	status := cronJobStatusOf(*cronJob, jobs.Items)
+	for i, run := range status.Runs {
+		if run.Phase != JobFailed {
+			continue
+		}
+
+		pods, err := clientSet.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: fmt.Sprintf("job-name=%s", run.Name),
+		})
+		if err != nil {
+			return nil, err
+		}
+		setExitStatus(&status.Runs[i], pods.Items)
+	}
+	return &status, nil
+}
+
+// DeleteCronJob deletes the cron job along with its jobs. Deleting a cron
+// job that does not exist is not an error.
+func (c Client) DeleteCronJob(ctx context.Context, namespace, name string) error {
+	clientSet, err := kubernetes.NewForConfig(&c.restConfig)
+	if err != nil {
+		return err
+	}
+
+	propagation := metav1.DeletePropagationBackground
+	err = clientSet.BatchV1().CronJobs(namespace).Delete(ctx, name, metav1.DeleteOptions{PropagationPolicy: &propagation})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func cronJobStatusOf(cronJob batchv1.CronJob, jobs []batchv1.Job) CronJobStatus {
+	status := CronJobStatus{
+		Name:      cronJob.Name,
+		Namespace: cronJob.Namespace,
+		Schedule:  cronJob.Spec.Schedule,
+		Suspended: cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend,
+		Runs:      []JobStatus{},
+	}
+	if t := cronJob.Status.LastScheduleTime; t != nil {
+		status.LastScheduleTime = &t.Time
+	}
+	if t := cronJob.Status.LastSuccessfulTime; t != nil {
+		status.LastSuccessfulTime = &t.Time
+	}
+
+	var owned []batchv1.Job
+	for i := range jobs {
+		if ref := metav1.GetControllerOf(&jobs[i]); ref != nil && ref.UID == cronJob.UID {
+			owned = append(owned, jobs[i])
+		}
+	}
+	sort.Slice(owned, func(i, j int) bool {
+		return owned[j].CreationTimestamp.Before(&owned[i].CreationTimestamp)
+	})
+
+	for _, job := range owned {
+		status.Runs = append(status.Runs, jobStatusOf(job))
+	}
+	return status
+}
@@ -0,0 +1,59 @@
+package kube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCronJobStatusOf(t *testing.T) {
+	t.Parallel()
+
+	suspend := true
+	scheduled := metav1.NewTime(time.Date(2022, 10, 10, 10, 0, 0, 0, time.UTC))
+	cronJob := batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "report", Namespace: "jobs", UID: "cron-uid"},
+		Spec:       batchv1.CronJobSpec{Schedule: "0 * * * *", Suspend: &suspend},
+		Status:     batchv1.CronJobStatus{LastScheduleTime: &scheduled},
+	}
+
+	ownedJob := func(name string, createdAt time.Time, status batchv1.JobStatus) batchv1.Job {
+		job := batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "jobs", CreationTimestamp: metav1.NewTime(createdAt)},
+			Status:     status,
+		}
+		job.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(&cronJob, batchv1.SchemeGroupVersion.WithKind("CronJob"))}
+		return job
+	}
+
+	jobs := []batchv1.Job{
+		ownedJob("report-1", scheduled.Add(-2*time.Hour), batchv1.JobStatus{
+			Succeeded:  1,
+			Conditions: []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}},
+		}),
+		{ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "jobs"}},
+		ownedJob("report-3", scheduled.Time, batchv1.JobStatus{Active: 1}),
+		ownedJob("report-2", scheduled.Add(-1*time.Hour), batchv1.JobStatus{
+			Failed:     1,
+			Conditions: []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "BackoffLimitExceeded"}},
+		}),
+	}
+
+	got := cronJobStatusOf(cronJob, jobs)
+	assert.Equal(t, CronJobStatus{
+		Name:             "report",
+		Namespace:        "jobs",
+		Schedule:         "0 * * * *",
+		Suspended:        true,
+		LastScheduleTime: &scheduled.Time,
+		Runs: []JobStatus{
+			{Name: "report-3", Namespace: "jobs", Phase: JobRunning, Active: 1},
+			{Name: "report-2", Namespace: "jobs", Phase: JobFailed, Failed: 1, Reason: "BackoffLimitExceeded"},
+			{Name: "report-1", Namespace: "jobs", Phase: JobSucceeded, Succeeded: 1},
+		},
+	}, got)
+}
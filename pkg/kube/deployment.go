@@ -0,0 +1,113 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+// Phases of a rollout as reported in RolloutStatus.
+const (
+	RolloutInProgress = "IN_PROGRESS"
+	RolloutComplete   = "COMPLETE"
+	RolloutFailed     = "FAILED"
+)
+
+// ErrDeploymentNotFound is returned when no deployment matches the labels.
+var ErrDeploymentNotFound = errors.ErrNotFound.WithMsgf("deployment not found")
+
+// RolloutStatus is the progress of the rollout of the deployments, the same
+// as reported by 'kubectl rollout status'.
+type RolloutStatus struct {
+	Phase   string `json:"phase"`
+	Message string `json:"message,omitempty"`
+}
+
+// GetRolloutStatus returns the combined rollout status of the deployments
+// matching the labels. The rollout is complete only when all of them are.
+func (c Client) GetRolloutStatus(ctx context.Context, namespace string, labelSelectors map[string]string) (*RolloutStatus, error) {
+	clientSet, err := kubernetes.NewForConfig(&c.restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	deployments, err := clientSet.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labelSelectors).String(),
+	})
+	if err != nil {
+		return nil, err
+	} else if len(deployments.Items) == 0 {
+		return nil, ErrDeploymentNotFound.WithCausef("no deployment with labels %v in '%s'", labelSelectors, namespace)
+	}
+
+	status := RolloutStatus{Phase: RolloutComplete}
+	var messages []string
+	for _, d := range deployments.Items {
+		ds := rolloutStatusOf(d)
+		switch {
+		case ds.Phase == RolloutFailed:
+			status.Phase = RolloutFailed
+		case ds.Phase == RolloutInProgress && status.Phase == RolloutComplete:
+			status.Phase = RolloutInProgress
+		}
+		if ds.Message != "" {
+			messages = append(messages, ds.Message)
+		}
+	}
+	status.Message = strings.Join(messages, "; ")
+	return &status, nil
+}
+
+func rolloutStatusOf(d appsv1.Deployment) RolloutStatus {
+	if d.Generation > d.Status.ObservedGeneration {
+		return RolloutStatus{
+			Phase:   RolloutInProgress,
+			Message: fmt.Sprintf("deployment %q: waiting for the spec update to be observed", d.Name),
+		}
+	}
+
+	for _, cond := range d.Status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing && cond.Status == corev1.ConditionFalse && cond.Reason == "ProgressDeadlineExceeded" {
+			return RolloutStatus{
+				Phase:   RolloutFailed,
+				Message: fmt.Sprintf("deployment %q exceeded its progress deadline: %s", d.Name, cond.Message),
+			}
+		}
+	}
+
+	desired := int32(1)
+	if d.Spec.Replicas != nil {
+		desired = *d.Spec.Replicas
+	}
+
+	st := d.Status
+	switch {
+	case st.UpdatedReplicas < desired:
+		return RolloutStatus{
+			Phase:   RolloutInProgress,
+			Message: fmt.Sprintf("deployment %q: %d of %d new replicas have been updated", d.Name, st.UpdatedReplicas, desired),
+		}
+
+	case st.Replicas > st.UpdatedReplicas:
+		return RolloutStatus{
+			Phase:   RolloutInProgress,
+			Message: fmt.Sprintf("deployment %q: %d old replicas are pending termination", d.Name, st.Replicas-st.UpdatedReplicas),
+		}
+
+	case st.AvailableReplicas < st.UpdatedReplicas:
+		return RolloutStatus{
+			Phase:   RolloutInProgress,
+			Message: fmt.Sprintf("deployment %q: %d of %d updated replicas are available", d.Name, st.AvailableReplicas, st.UpdatedReplicas),
+		}
+	}
+
+	return RolloutStatus{Phase: RolloutComplete}
+}
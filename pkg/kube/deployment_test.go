@@ -0,0 +1,75 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRolloutStatusOf(t *testing.T) {
+	t.Parallel()
+
+	replicas := int32(3)
+	deployment := func(generation int64, status appsv1.DeploymentStatus) appsv1.Deployment {
+		return appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "orders-firehose", Generation: generation},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status:     status,
+		}
+	}
+
+	table := []struct {
+		title      string
+		deployment appsv1.Deployment
+		want       string
+	}{
+		{
+			title:      "NotObserved",
+			deployment: deployment(2, appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 3}),
+			want:       RolloutInProgress,
+		},
+		{
+			title:      "UpdatingReplicas",
+			deployment: deployment(2, appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 4, UpdatedReplicas: 1, AvailableReplicas: 3}),
+			want:       RolloutInProgress,
+		},
+		{
+			title:      "TerminatingOldReplicas",
+			deployment: deployment(2, appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 4, UpdatedReplicas: 3, AvailableReplicas: 3}),
+			want:       RolloutInProgress,
+		},
+		{
+			title:      "WaitingForAvailability",
+			deployment: deployment(2, appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 2}),
+			want:       RolloutInProgress,
+		},
+		{
+			title: "ProgressDeadlineExceeded",
+			deployment: deployment(2, appsv1.DeploymentStatus{
+				ObservedGeneration: 2, Replicas: 4, UpdatedReplicas: 1, AvailableReplicas: 3,
+				Conditions: []appsv1.DeploymentCondition{
+					{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionFalse, Reason: "ProgressDeadlineExceeded"},
+				},
+			}),
+			want: RolloutFailed,
+		},
+		{
+			title:      "Complete",
+			deployment: deployment(2, appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 3}),
+			want:       RolloutComplete,
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+			got := rolloutStatusOf(tt.deployment)
+			assert.Equal(t, tt.want, got.Phase)
+			assert.Equal(t, tt.want == RolloutComplete, got.Message == "")
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+// Phases of a job as reported in JobStatus.
+const (
+	JobPending   = "PENDING"
+	JobRunning   = "RUNNING"
+	JobSucceeded = "SUCCEEDED"
+	JobFailed    = "FAILED"
+)
+
+// ErrJobExists is returned when a job with the same name exists already.
+var ErrJobExists = errors.ErrConflict.WithMsgf("job already exists")
+
+// JobSpec describes a job that runs a single container to completion.
+type JobSpec struct {
+	Name      string
+	Namespace string
+	Labels    map[string]string
+
+	Image   string
+	Command []string
+	Args    []string
+	Env     map[string]string
+
+	// Retries is the number of times a failed pod is retried.
+	Retries int32
+
+	// DeadlineSeconds is the duration after which the job is failed, if it
+	// has not completed. Zero means no deadline.
+	DeadlineSeconds int64
+}
+
+// JobStatus is the progress of a job.
+type JobStatus struct {
+	Name           string     `json:"name"`
+	Namespace      string     `json:"namespace"`
+	Phase          string     `json:"phase"`
+	Active         int32      `json:"active"`
+	Succeeded      int32      `json:"succeeded"`
+	Failed         int32      `json:"failed"`
+	StartTime      *time.Time `json:"start_time,omitempty"`
+	CompletionTime *time.Time `json:"completion_time,omitempty"`
+
+	// Reason & Message explain why the job failed (e.g., BackoffLimitExceeded).
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+
+	// ExitCode & ExitReason are of the container of the last failed pod.
+	ExitCode   *int32 `json:"exit_code,omitempty"`
+	ExitReason string `json:"exit_reason,omitempty"`
+}
+
+// IsFinished returns true if the job has succeeded or failed.
+func (js JobStatus) IsFinished() bool {
+	return js.Phase == JobSucceeded || js.Phase == JobFailed
+}
+
+// CreateJob creates the job and returns without waiting for it to run. If
+// a job with the same name exists, ErrJobExists is returned.
+func (c Client) CreateJob(ctx context.Context, spec JobSpec) error {
+	clientSet, err := kubernetes.NewForConfig(&c.restConfig)
+	if err != nil {
+		return err
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      spec.Name,
+			Namespace: spec.Namespace,
+			Labels:    spec.Labels,
+		},
+		Spec: jobSpecOf(spec),
+	}
+
+	_, err = clientSet.BatchV1().Jobs(spec.Namespace).Create(ctx, job, metav1.CreateOptions{})
+	if err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return ErrJobExists.WithCausef(spec.Name)
+		} else if apierrors.IsInvalid(err) {
+			return errors.ErrInvalid.WithMsgf("invalid job: %s", err.Error())
+		}
+		return err
+	}
+	return nil
+}
+
+// GetJobStatus returns the progress of the job. If the job has failed, the
+// exit code & reason of its last failed pod are included.
+func (c Client) GetJobStatus(ctx context.Context, namespace, name string) (*JobStatus, error) {
+	clientSet, err := kubernetes.NewForConfig(&c.restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := clientSet.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, ErrJobNotFound.WithCausef(name)
+		}
+		return nil, err
+	}
+
+	status := jobStatusOf(*job)
+	if status.Phase != JobFailed {
+		return &status, nil
+	}
+
+	pods, err := clientSet.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("job-name=%s", name),
+	})
+	if err != nil {
+		return nil, err
+	}
+	setExitStatus(&status, pods.Items)
+	return &status, nil
+}
+
+// DeleteJob deletes the job along with its pods. Deleting a job that does
+// not exist is not an error.
+func (c Client) DeleteJob(ctx context.Context, namespace, name string) error {
+	clientSet, err := kubernetes.NewForConfig(&c.restConfig)
+	if err != nil {
+		return err
+	}
+
+	propagation := metav1.DeletePropagationBackground
+	err = clientSet.BatchV1().Jobs(namespace).Delete(ctx, name, metav1.DeleteOptions{PropagationPolicy: &propagation})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// jobSpecOf returns the kubernetes spec of a job that runs the container
+// described by the spec.
+func jobSpecOf(spec JobSpec) batchv1.JobSpec {
+	var env []corev1.EnvVar
+	for _, name := range sortedKeys(spec.Env) {
+		env = append(env, corev1.EnvVar{Name: name, Value: spec.Env[name]})
+	}
+
+	retries := spec.Retries
+	jobSpec := batchv1.JobSpec{
+		BackoffLimit: &retries,
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: spec.Labels,
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name:    "job",
+						Image:   spec.Image,
+						Command: spec.Command,
+						Args:    spec.Args,
+						Env:     env,
+					},
+				},
+				RestartPolicy: corev1.RestartPolicyNever,
+			},
+		},
+	}
+	if spec.DeadlineSeconds > 0 {
+		deadline := spec.DeadlineSeconds
+		jobSpec.ActiveDeadlineSeconds = &deadline
+	}
+	return jobSpec
+}
+
+func jobStatusOf(job batchv1.Job) JobStatus {
+	status := JobStatus{
+		Name:      job.Name,
+		Namespace: job.Namespace,
+		Phase:     JobPending,
+		Active:    job.Status.Active,
+		Succeeded: job.Status.Succeeded,
+		Failed:    job.Status.Failed,
+	}
+	if job.Status.StartTime != nil {
+		t := job.Status.StartTime.Time
+		status.StartTime = &t
+	}
+	if job.Status.CompletionTime != nil {
+		t := job.Status.CompletionTime.Time
+		status.CompletionTime = &t
+	}
+
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		switch cond.Type {
+		case batchv1.JobComplete:
+			status.Phase = JobSucceeded
+			return status
+
+		case batchv1.JobFailed:
+			status.Phase = JobFailed
+			status.Reason = cond.Reason
+			status.Message = cond.Message
+			return status
+		}
+	}
+
+	if job.Status.Active > 0 {
+		status.Phase = JobRunning
+	}
+	return status
+}
+
+func setExitStatus(status *JobStatus, pods []corev1.Pod) {
+	// the most recently created pod has the latest attempt.
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[j].CreationTimestamp.Before(&pods[i].CreationTimestamp)
+	})
+
+	for _, pod := range pods {
+		for _, cs := range pod.Status.ContainerStatuses {
+			if term := cs.State.Terminated; term != nil && term.ExitCode != 0 {
+				exitCode := term.ExitCode
+				status.ExitCode = &exitCode
+				status.ExitReason = term.Reason
+				if term.Message != "" {
+					status.ExitReason = fmt.Sprintf("%s: %s", term.Reason, term.Message)
+				}
+				return
+			}
+		}
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
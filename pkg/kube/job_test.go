@@ -0,0 +1,99 @@
+package kube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestJobStatusOf(t *testing.T) {
+	t.Parallel()
+
+	started := metav1.NewTime(time.Date(2022, 10, 10, 10, 0, 0, 0, time.UTC))
+
+	table := []struct {
+		title string
+		job   batchv1.Job
+		want  JobStatus
+	}{
+		{
+			title: "NotStarted",
+			job:   batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "backfill", Namespace: "jobs"}},
+			want:  JobStatus{Name: "backfill", Namespace: "jobs", Phase: JobPending},
+		},
+		{
+			title: "Running",
+			job: batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: "backfill", Namespace: "jobs"},
+				Status:     batchv1.JobStatus{Active: 1, Failed: 1, StartTime: &started},
+			},
+			want: JobStatus{Name: "backfill", Namespace: "jobs", Phase: JobRunning, Active: 1, Failed: 1, StartTime: &started.Time},
+		},
+		{
+			title: "Succeeded",
+			job: batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: "backfill", Namespace: "jobs"},
+				Status: batchv1.JobStatus{
+					Succeeded:  1,
+					Conditions: []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}},
+				},
+			},
+			want: JobStatus{Name: "backfill", Namespace: "jobs", Phase: JobSucceeded, Succeeded: 1},
+		},
+		{
+			title: "Failed",
+			job: batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: "backfill", Namespace: "jobs"},
+				Status: batchv1.JobStatus{
+					Failed: 3,
+					Conditions: []batchv1.JobCondition{
+						{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "BackoffLimitExceeded", Message: "Job has reached the specified backoff limit"},
+					},
+				},
+			},
+			want: JobStatus{
+				Name: "backfill", Namespace: "jobs", Phase: JobFailed, Failed: 3,
+				Reason: "BackoffLimitExceeded", Message: "Job has reached the specified backoff limit",
+			},
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, jobStatusOf(tt.job))
+		})
+	}
+}
+
+func TestSetExitStatus(t *testing.T) {
+	t.Parallel()
+
+	failedPod := func(created time.Time, exitCode int32, reason string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{
+					{State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: exitCode, Reason: reason}}},
+				},
+			},
+		}
+	}
+
+	now := time.Now()
+	status := JobStatus{Phase: JobFailed}
+	setExitStatus(&status, []corev1.Pod{
+		failedPod(now.Add(-time.Minute), 1, "Error"),
+		failedPod(now, 137, "OOMKilled"),
+	})
+
+	if assert.NotNil(t, status.ExitCode) {
+		assert.Equal(t, int32(137), *status.ExitCode)
+	}
+	assert.Equal(t, "OOMKilled", status.ExitReason)
+}
@@ -0,0 +1,245 @@
+// Package kubetest provides an in-memory kubernetes cluster for testing the
+// modules that deploy to kubernetes.
+package kubetest
+
+import (
+	"context"
+
+	"github.com/odpf/entropy/pkg/kube"
+)
+
+// Cluster is an in-memory stand-in for kube.Client. It implements the
+// subsets of kube.Client used by the modules. The zero value is an empty
+// cluster that accepts everything.
+type Cluster struct {
+	// Err, when set, is returned from every call as if the cluster was
+	// unreachable.
+	Err error
+
+	// Rejected maps a kind to the error returned when an object of the kind
+	// is applied (or a job or cron job is created). As with kube.Client, the
+	// objects preceding the rejected one are applied.
+	Rejected map[string]error
+
+	// Objects are the applied objects by their references.
+	Objects map[kube.ObjectRef]map[string]interface{}
+
+	// Jobs maps "namespace/name" of a job to the statuses it goes through.
+	// Each status lookup returns the next one, the last one is kept.
+	Jobs map[string][]kube.JobStatus
+
+	// CronJobs maps "namespace/name" of a cron job to its spec.
+	CronJobs map[string]kube.CronJobSpec
+
+	// Logs are streamed by every StreamLogs call.
+	Logs []kube.LogChunk
+
+	// Created, Triggered & Deleted record the calls made to the cluster.
+	Created   []kube.JobSpec
+	Triggered []string
+	Deleted   []kube.ObjectRef
+
+	runs map[string][]string
+}
+
+func (c *Cluster) ApplyObjects(_ context.Context, _, defaultNamespace string, objs []map[string]interface{}) ([]kube.ObjectRef, error) {
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	if c.Objects == nil {
+		c.Objects = map[kube.ObjectRef]map[string]interface{}{}
+	}
+
+	refs := make([]kube.ObjectRef, 0, len(objs))
+	for _, obj := range objs {
+		ref := kube.RefOf(obj)
+		if err := c.Rejected[ref.Kind]; err != nil {
+			return refs, err
+		}
+
+		// namespaces are the only cluster scoped objects known here.
+		if ref.Namespace == "" && ref.Kind != "Namespace" {
+			ref.Namespace = defaultNamespace
+		}
+		c.Objects[ref] = obj
+		refs = append(refs, ref)
+	}
+	return refs, nil
+}
+
+func (c *Cluster) DeleteObjects(_ context.Context, refs []kube.ObjectRef) error {
+	if c.Err != nil {
+		return c.Err
+	}
+
+	for _, ref := range refs {
+		delete(c.Objects, ref)
+		c.Deleted = append(c.Deleted, ref)
+	}
+	return nil
+}
+
+func (c *Cluster) GetObjectStatuses(_ context.Context, refs []kube.ObjectRef) ([]kube.ObjectStatus, error) {
+	if c.Err != nil {
+		return nil, c.Err
+	}
+
+	statuses := make([]kube.ObjectStatus, 0, len(refs))
+	for _, ref := range refs {
+		_, exists := c.Objects[ref]
+		statuses = append(statuses, kube.ObjectStatus{ObjectRef: ref, Exists: exists})
+	}
+	return statuses, nil
+}
+
+func (c *Cluster) CreateJob(_ context.Context, spec kube.JobSpec) error {
+	if c.Err != nil {
+		return c.Err
+	} else if err := c.Rejected["Job"]; err != nil {
+		return err
+	}
+
+	key := spec.Namespace + "/" + spec.Name
+	if _, exists := c.Jobs[key]; exists {
+		return kube.ErrJobExists
+	}
+	c.setJob(key, kube.JobStatus{Phase: kube.JobPending})
+	c.Created = append(c.Created, spec)
+	return nil
+}
+
+func (c *Cluster) GetJobStatus(_ context.Context, namespace, name string) (*kube.JobStatus, error) {
+	if c.Err != nil {
+		return nil, c.Err
+	}
+
+	key := namespace + "/" + name
+	statuses, found := c.Jobs[key]
+	if !found {
+		return nil, kube.ErrJobNotFound
+	}
+
+	status := statuses[0]
+	if len(statuses) > 1 {
+		c.Jobs[key] = statuses[1:]
+	}
+	status.Name, status.Namespace = name, namespace
+	return &status, nil
+}
+
+func (c *Cluster) DeleteJob(_ context.Context, namespace, name string) error {
+	if c.Err != nil {
+		return c.Err
+	}
+
+	delete(c.Jobs, namespace+"/"+name)
+	c.Deleted = append(c.Deleted, kube.ObjectRef{APIVersion: "batch/v1", Kind: "Job", Namespace: namespace, Name: name})
+	return nil
+}
+
+func (c *Cluster) ApplyCronJob(_ context.Context, spec kube.CronJobSpec) error {
+	if c.Err != nil {
+		return c.Err
+	} else if err := c.Rejected["CronJob"]; err != nil {
+		return err
+	}
+
+	if c.CronJobs == nil {
+		c.CronJobs = map[string]kube.CronJobSpec{}
+	}
+	c.CronJobs[spec.Namespace+"/"+spec.Name] = spec
+	return nil
+}
+
+func (c *Cluster) TriggerCronJob(_ context.Context, namespace, name, jobName string) error {
+	if c.Err != nil {
+		return c.Err
+	}
+
+	key := namespace + "/" + name
+	if _, found := c.CronJobs[key]; !found {
+		return kube.ErrCronJobNotFound
+	}
+	c.setJob(namespace+"/"+jobName, kube.JobStatus{Phase: kube.JobRunning, Active: 1})
+
+	if c.runs == nil {
+		c.runs = map[string][]string{}
+	}
+	c.runs[key] = append(c.runs[key], jobName)
+	c.Triggered = append(c.Triggered, jobName)
+	return nil
+}
+
+func (c *Cluster) GetCronJobStatus(ctx context.Context, namespace, name string) (*kube.CronJobStatus, error) {
+	if c.Err != nil {
+		return nil, c.Err
+	}
+
+	key := namespace + "/" + name
+	spec, found := c.CronJobs[key]
+	if !found {
+		return nil, kube.ErrCronJobNotFound
+	}
+
+	status := &kube.CronJobStatus{
+		Name:      name,
+		Namespace: namespace,
+		Schedule:  spec.Schedule,
+		Suspended: spec.Suspend,
+		Runs:      []kube.JobStatus{},
+	}
+	for _, jobName := range c.runs[key] {
+		run, err := c.GetJobStatus(ctx, namespace, jobName)
+		if err != nil {
+			continue // removed from outside.
+		}
+		status.Runs = append(status.Runs, *run)
+	}
+	return status, nil
+}
+
+func (c *Cluster) DeleteCronJob(_ context.Context, namespace, name string) error {
+	if c.Err != nil {
+		return c.Err
+	}
+
+	key := namespace + "/" + name
+	delete(c.CronJobs, key)
+	for _, jobName := range c.runs[key] {
+		delete(c.Jobs, namespace+"/"+jobName)
+	}
+	delete(c.runs, key)
+	c.Deleted = append(c.Deleted, kube.ObjectRef{APIVersion: "batch/v1", Kind: "CronJob", Namespace: namespace, Name: name})
+	return nil
+}
+
+func (c *Cluster) StreamLogs(_ context.Context, _ string, filter map[string]string) (<-chan kube.LogChunk, error) {
+	if c.Err != nil {
+		return nil, c.Err
+	}
+
+	ch := make(chan kube.LogChunk, len(c.Logs))
+	for _, chunk := range c.Logs {
+		if matches(chunk.Labels, filter) {
+			ch <- chunk
+		}
+	}
+	close(ch)
+	return ch, nil
+}
+
+func (c *Cluster) setJob(key string, status kube.JobStatus) {
+	if c.Jobs == nil {
+		c.Jobs = map[string][]kube.JobStatus{}
+	}
+	c.Jobs[key] = []kube.JobStatus{status}
+}
+
+func matches(labels, filter map[string]string) bool {
+	for k, v := range filter {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,205 @@
+package kube
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+// ErrUnknownKind is returned when the cluster does not serve a kind.
+var ErrUnknownKind = errors.ErrInvalid.WithMsgf("kind not served by the cluster")
+
+// ObjectRef identifies a kubernetes object.
+type ObjectRef struct {
+	APIVersion string `json:"api_version"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+// ObjectStatus is the live status of a kubernetes object.
+type ObjectStatus struct {
+	ObjectRef
+	Exists bool            `json:"exists"`
+	Status json.RawMessage `json:"status,omitempty"`
+}
+
+func (ref ObjectRef) String() string {
+	if ref.Namespace == "" {
+		return fmt.Sprintf("%s/%s %s", ref.APIVersion, ref.Kind, ref.Name)
+	}
+	return fmt.Sprintf("%s/%s %s/%s", ref.APIVersion, ref.Kind, ref.Namespace, ref.Name)
+}
+
+// RefOf returns the reference to the given object.
+func RefOf(obj map[string]interface{}) ObjectRef {
+	u := unstructured.Unstructured{Object: obj}
+	return ObjectRef{
+		APIVersion: u.GetAPIVersion(),
+		Kind:       u.GetKind(),
+		Namespace:  u.GetNamespace(),
+		Name:       u.GetName(),
+	}
+}
+
+// ApplyObjects applies the objects using server-side apply with the given
+// field manager. Namespaced objects without a namespace are applied to the
+// defaultNamespace. References to the applied objects are returned in the
+// same order as the objects.
+func (c Client) ApplyObjects(ctx context.Context, fieldManager, defaultNamespace string, objs []map[string]interface{}) ([]ObjectRef, error) {
+	dyn, mapper, err := c.dynamicClient()
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]ObjectRef, 0, len(objs))
+	for _, obj := range objs {
+		u := &unstructured.Unstructured{Object: obj}
+
+		ri, namespaced, err := resourceFor(dyn, mapper, u.GroupVersionKind())
+		if err != nil {
+			if meta.IsNoMatchError(err) {
+				return refs, ErrUnknownKind.WithCausef("%s: %s", RefOf(u.Object), err)
+			}
+			return refs, err
+		}
+
+		if namespaced && u.GetNamespace() == "" {
+			u = u.DeepCopy()
+			u.SetNamespace(defaultNamespace)
+		} else if !namespaced {
+			u = u.DeepCopy()
+			u.SetNamespace("")
+		}
+
+		data, err := u.MarshalJSON()
+		if err != nil {
+			return refs, err
+		}
+
+		force := true
+		_, err = resourceInterface(ri, namespaced, u.GetNamespace()).
+			Patch(ctx, u.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{
+				FieldManager: fieldManager,
+				Force:        &force,
+			})
+		if err != nil {
+			return refs, fmt.Errorf("failed to apply %s: %w", RefOf(u.Object), err)
+		}
+		refs = append(refs, RefOf(u.Object))
+	}
+	return refs, nil
+}
+
+// DeleteObjects deletes the referenced objects. Objects that do not exist
+// and objects of kinds no longer served by the cluster are ignored.
+func (c Client) DeleteObjects(ctx context.Context, refs []ObjectRef) error {
+	dyn, mapper, err := c.dynamicClient()
+	if err != nil {
+		return err
+	}
+
+	propagation := metav1.DeletePropagationBackground
+	for _, ref := range refs {
+		ri, namespaced, err := resourceFor(dyn, mapper, ref.gvk())
+		if err != nil {
+			if meta.IsNoMatchError(err) {
+				continue
+			}
+			return err
+		}
+
+		err = resourceInterface(ri, namespaced, ref.Namespace).
+			Delete(ctx, ref.Name, metav1.DeleteOptions{PropagationPolicy: &propagation})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete %s: %w", ref, err)
+		}
+	}
+	return nil
+}
+
+// GetObjectStatuses returns the live status of the referenced objects.
+func (c Client) GetObjectStatuses(ctx context.Context, refs []ObjectRef) ([]ObjectStatus, error) {
+	dyn, mapper, err := c.dynamicClient()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]ObjectStatus, 0, len(refs))
+	for _, ref := range refs {
+		st := ObjectStatus{ObjectRef: ref}
+
+		ri, namespaced, err := resourceFor(dyn, mapper, ref.gvk())
+		if err != nil {
+			if meta.IsNoMatchError(err) {
+				statuses = append(statuses, st)
+				continue
+			}
+			return nil, err
+		}
+
+		obj, err := resourceInterface(ri, namespaced, ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				statuses = append(statuses, st)
+				continue
+			}
+			return nil, err
+		}
+
+		st.Exists = true
+		if status, found := obj.Object["status"]; found {
+			st.Status, _ = json.Marshal(status)
+		}
+		statuses = append(statuses, st)
+	}
+	return statuses, nil
+}
+
+func (c Client) dynamicClient() (dynamic.Interface, meta.RESTMapper, error) {
+	dyn, err := dynamic.NewForConfig(&c.restConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	disc, err := discovery.NewDiscoveryClientForConfig(&c.restConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(disc))
+	return dyn, mapper, nil
+}
+
+func resourceFor(dyn dynamic.Interface, mapper meta.RESTMapper, gvk schema.GroupVersionKind) (dynamic.NamespaceableResourceInterface, bool, error) {
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, false, err
+	}
+	namespaced := mapping.Scope.Name() == meta.RESTScopeNameNamespace
+	return dyn.Resource(mapping.Resource), namespaced, nil
+}
+
+func resourceInterface(ri dynamic.NamespaceableResourceInterface, namespaced bool, namespace string) dynamic.ResourceInterface {
+	if namespaced {
+		return ri.Namespace(namespace)
+	}
+	return ri
+}
+
+func (ref ObjectRef) gvk() schema.GroupVersionKind {
+	return schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind)
+}
@@ -0,0 +1,38 @@
+package kube
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func podDetailOf(pod corev1.Pod) Pod {
+	podDetail := Pod{
+		Name:  pod.Name,
+		Phase: string(pod.Status.Phase),
+		Node:  pod.Spec.NodeName,
+	}
+
+	for _, container := range pod.Spec.Containers {
+		podDetail.Containers = append(podDetail.Containers, container.Name)
+	}
+
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			podDetail.Ready = cond.Status == corev1.ConditionTrue
+		}
+	}
+
+	var lastTerminatedAt metav1.Time
+	for _, cs := range pod.Status.ContainerStatuses {
+		podDetail.Restarts += cs.RestartCount
+
+		if t := cs.LastTerminationState.Terminated; t != nil && !t.FinishedAt.Before(&lastTerminatedAt) {
+			lastTerminatedAt = t.FinishedAt
+			podDetail.LastTerminationReason = t.Reason
+		}
+		if w := cs.State.Waiting; w != nil && podDetail.WaitingReason == "" {
+			podDetail.WaitingReason = w.Reason
+		}
+	}
+	return podDetail
+}
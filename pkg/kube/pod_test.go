@@ -0,0 +1,124 @@
+package kube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPodDetailOf(t *testing.T) {
+	t.Parallel()
+
+	finishedAt := metav1.NewTime(time.Date(2022, 4, 21, 10, 0, 0, 0, time.UTC))
+
+	table := []struct {
+		title string
+		pod   corev1.Pod
+		want  Pod
+	}{
+		{
+			title: "Running",
+			pod: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "orders-firehose-7c9f"},
+				Spec: corev1.PodSpec{
+					NodeName:   "node-1",
+					Containers: []corev1.Container{{Name: "firehose"}, {Name: "telegraf"}},
+				},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodRunning,
+					Conditions: []corev1.PodCondition{
+						{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+					},
+					ContainerStatuses: []corev1.ContainerStatus{
+						{Name: "firehose", RestartCount: 1, LastTerminationState: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", FinishedAt: finishedAt},
+						}},
+						{Name: "telegraf"},
+					},
+				},
+			},
+			want: Pod{
+				Name:                  "orders-firehose-7c9f",
+				Containers:            []string{"firehose", "telegraf"},
+				Phase:                 "Running",
+				Ready:                 true,
+				Node:                  "node-1",
+				Restarts:              1,
+				LastTerminationReason: "OOMKilled",
+			},
+		},
+		{
+			title: "CrashLooping",
+			pod: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "orders-firehose-7c9f"},
+				Spec: corev1.PodSpec{
+					NodeName:   "node-1",
+					Containers: []corev1.Container{{Name: "firehose"}, {Name: "telegraf"}},
+				},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodRunning,
+					Conditions: []corev1.PodCondition{
+						{Type: corev1.PodReady, Status: corev1.ConditionFalse},
+					},
+					ContainerStatuses: []corev1.ContainerStatus{
+						{
+							Name:         "firehose",
+							RestartCount: 5,
+							State: corev1.ContainerState{
+								Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+							},
+							LastTerminationState: corev1.ContainerState{
+								Terminated: &corev1.ContainerStateTerminated{Reason: "Error", FinishedAt: finishedAt},
+							},
+						},
+						{
+							Name:         "telegraf",
+							RestartCount: 1,
+							LastTerminationState: corev1.ContainerState{
+								Terminated: &corev1.ContainerStateTerminated{
+									Reason:     "OOMKilled",
+									FinishedAt: metav1.NewTime(finishedAt.Add(-time.Hour)),
+								},
+							},
+						},
+					},
+				},
+			},
+			want: Pod{
+				Name:                  "orders-firehose-7c9f",
+				Containers:            []string{"firehose", "telegraf"},
+				Phase:                 "Running",
+				Node:                  "node-1",
+				Restarts:              6,
+				LastTerminationReason: "Error",
+				WaitingReason:         "CrashLoopBackOff",
+			},
+		},
+		{
+			title: "Unscheduled",
+			pod: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "orders-firehose-7c9f"},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "firehose"}},
+				},
+				Status: corev1.PodStatus{Phase: corev1.PodPending},
+			},
+			want: Pod{
+				Name:       "orders-firehose-7c9f",
+				Containers: []string{"firehose"},
+				Phase:      "Pending",
+			},
+		},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, podDetailOf(tt.pod))
+		})
+	}
+}
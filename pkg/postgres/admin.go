@@ -0,0 +1,178 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"github.com/odpf/entropy/pkg/errors"
+)
+
+const defaultPort = 5432
+
+// ServerConfig holds the details to connect to a PostgreSQL server.
+type ServerConfig struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port,omitempty"`
+	User     string `json:"user"`
+	Password string `json:"password,omitempty"`
+	SSLMode  string `json:"ssl_mode,omitempty"`
+}
+
+// ConnString returns the connection URL for the given database.
+func (sc ServerConfig) ConnString(database string) string {
+	port := sc.Port
+	if port == 0 {
+		port = defaultPort
+	}
+
+	u := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(sc.User, sc.Password),
+		Host:   net.JoinHostPort(sc.Host, strconv.Itoa(port)),
+		Path:   "/" + database,
+	}
+	if sc.SSLMode != "" {
+		u.RawQuery = url.Values{"sslmode": []string{sc.SSLMode}}.Encode()
+	}
+	return u.String()
+}
+
+// Admin performs administrative operations on a PostgreSQL server.
+type Admin struct {
+	db *sql.DB
+}
+
+// Connect opens a connection to the server as the admin user.
+func Connect(ctx context.Context, conf ServerConfig) (*Admin, error) {
+	db, err := sql.Open("postgres", conf.ConnString("postgres"))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, translateErr(err)
+	}
+	return &Admin{db: db}, nil
+}
+
+func (a *Admin) Close() error { return a.db.Close() }
+
+// EnsureRole creates a role with login using the given password. If the
+// role exists already, its password is updated.
+func (a *Admin) EnsureRole(ctx context.Context, name, password string) error {
+	exists, err := a.exists(ctx, `SELECT 1 FROM pg_roles WHERE rolname = $1`, name)
+	if err != nil {
+		return err
+	}
+
+	verb := "CREATE"
+	if exists {
+		verb = "ALTER"
+	}
+	query := fmt.Sprintf("%s ROLE %s WITH LOGIN PASSWORD %s", verb, pq.QuoteIdentifier(name), pq.QuoteLiteral(password))
+	return a.exec(ctx, query)
+}
+
+// EnsureDatabase creates the database owned by the given role. If the
+// database exists already, its owner is updated.
+func (a *Admin) EnsureDatabase(ctx context.Context, name, owner string) error {
+	exists, err := a.DatabaseExists(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return a.exec(ctx, fmt.Sprintf("ALTER DATABASE %s OWNER TO %s", pq.QuoteIdentifier(name), pq.QuoteIdentifier(owner)))
+	}
+	return a.exec(ctx, fmt.Sprintf("CREATE DATABASE %s OWNER %s", pq.QuoteIdentifier(name), pq.QuoteIdentifier(owner)))
+}
+
+// DatabaseExists returns true if the database exists.
+func (a *Admin) DatabaseExists(ctx context.Context, name string) (bool, error) {
+	return a.exists(ctx, `SELECT 1 FROM pg_database WHERE datname = $1`, name)
+}
+
+// SetGrants replaces the privileges the role has on the database with the
+// given ones. Empty privileges revoke all the privileges of the role.
+func (a *Admin) SetGrants(ctx context.Context, database, role string, privileges []string) error {
+	db, r := pq.QuoteIdentifier(database), pq.QuoteIdentifier(role)
+
+	_, err := a.db.ExecContext(ctx, fmt.Sprintf("REVOKE ALL ON DATABASE %s FROM %s", db, r))
+	if len(privileges) == 0 && isUndefinedObject(err) {
+		// nothing to revoke from a role that does not exist anymore.
+		return nil
+	} else if err != nil {
+		return translateErr(err)
+	} else if len(privileges) == 0 {
+		return nil
+	}
+
+	// privileges are keywords and cannot be quoted. callers are expected to
+	// validate them against the known set.
+	query := fmt.Sprintf("GRANT %s ON DATABASE %s TO %s", strings.Join(privileges, ", "), db, r)
+	return a.exec(ctx, query)
+}
+
+// DropDatabase terminates the open connections to the database and drops
+// it. Dropping a database that does not exist is not an error.
+func (a *Admin) DropDatabase(ctx context.Context, name string) error {
+	const terminate = `SELECT pg_terminate_backend(pid) FROM pg_stat_activity WHERE datname = $1 AND pid <> pg_backend_pid()`
+	if _, err := a.db.ExecContext(ctx, terminate, name); err != nil {
+		return translateErr(err)
+	}
+	return a.exec(ctx, fmt.Sprintf("DROP DATABASE IF EXISTS %s", pq.QuoteIdentifier(name)))
+}
+
+// DropRole drops the role. Dropping a role that does not exist is not an
+// error.
+func (a *Admin) DropRole(ctx context.Context, name string) error {
+	return a.exec(ctx, fmt.Sprintf("DROP ROLE IF EXISTS %s", pq.QuoteIdentifier(name)))
+}
+
+func (a *Admin) exists(ctx context.Context, query string, args ...interface{}) (bool, error) {
+	var one int
+	err := a.db.QueryRowContext(ctx, query, args...).Scan(&one)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, translateErr(err)
+	}
+	return true, nil
+}
+
+func (a *Admin) exec(ctx context.Context, query string) error {
+	_, err := a.db.ExecContext(ctx, query)
+	return translateErr(err)
+}
+
+func isUndefinedObject(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "42704"
+}
+
+// translateErr marks the errors caused by invalid requests or insufficient
+// privileges as ErrInvalid, since retrying those will not succeed.
+func translateErr(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code.Class() {
+		case "28", // invalid authorization specification
+			"42": // syntax error or access rule violation
+			return errors.ErrInvalid.WithMsgf("%s", pqErr.Message)
+		}
+	}
+	return fmt.Errorf("postgres: %w", err)
+}
@@ -0,0 +1,62 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run against the server in docker-compose.yaml using:
+//
+//	TEST_PG_HOST=localhost TEST_PG_USER=postgres go test -tags integration ./pkg/postgres
+var (
+	envHost     = os.Getenv("TEST_PG_HOST")
+	envUser     = os.Getenv("TEST_PG_USER")
+	envPassword = os.Getenv("TEST_PG_PASSWORD")
+)
+
+func TestAdmin(t *testing.T) {
+	ctx := context.Background()
+	conf := ServerConfig{Host: envHost, User: envUser, Password: envPassword, SSLMode: "disable"}
+
+	admin, err := Connect(ctx, conf)
+	require.NoError(t, err)
+	defer admin.Close()
+
+	const db, owner = "entropy_it_orders", "entropy_it_orders_owner"
+	defer func() {
+		_ = admin.DropDatabase(ctx, db)
+		_ = admin.DropRole(ctx, owner)
+	}()
+
+	require.NoError(t, admin.EnsureRole(ctx, owner, "secret-1"))
+	require.NoError(t, admin.EnsureRole(ctx, owner, "secret-2"))
+	require.NoError(t, admin.EnsureDatabase(ctx, db, owner))
+	require.NoError(t, admin.EnsureDatabase(ctx, db, owner))
+	require.NoError(t, admin.SetGrants(ctx, db, "public", []string{"CONNECT"}))
+	require.NoError(t, admin.SetGrants(ctx, db, "public", nil))
+
+	exists, err := admin.DatabaseExists(ctx, db)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	ownerConf := conf
+	ownerConf.User, ownerConf.Password = owner, "secret-2"
+	ownerConn, err := Connect(ctx, ownerConf)
+	require.NoError(t, err)
+	_ = ownerConn.Close()
+
+	assert.NoError(t, admin.DropDatabase(ctx, db))
+	assert.NoError(t, admin.DropDatabase(ctx, db))
+	assert.NoError(t, admin.DropRole(ctx, owner))
+
+	exists, err = admin.DatabaseExists(ctx, db)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}